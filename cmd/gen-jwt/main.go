@@ -1,11 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func main() {
@@ -16,13 +18,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	role := flag.String("role", "", "role claim, e.g. admin")
+	tenant := flag.String("tenant", "", "tenant claim, selects the catalog overlay")
+	flag.Parse()
+
 	sub := "demo-user"
-	if len(os.Args) > 1 {
-		sub = os.Args[1]
+	if flag.NArg() > 0 {
+		sub = flag.Arg(0)
 	}
 
 	jwtManager := pkg.NewJWTManager(secret, duration)
-	token, err := jwtManager.Generate(sub)
+	token, err := jwtManager.GenerateClaims(pkg.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: sub},
+		Role:             *role,
+		Tenant:           *tenant,
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to generate token: %v\n", err)
 		os.Exit(1)
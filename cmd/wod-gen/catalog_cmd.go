@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/config"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+var errUsage = errors.New("usage: wod-gen catalog import [-catalog name] [-format csv|json] [-dry-run] [-o out.yml] [-apply] FILE")
+
+// catalogCmd runs the catalog subcommands. The import is validated and diffed
+// against the catalog loaded from CATALOG_PATH, or from the database with
+// -apply, then written as YAML with -o and stored with -apply.
+func catalogCmd(ctx context.Context, cfg config.Config, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "import" {
+		return errUsage
+	}
+
+	fs := flag.NewFlagSet("catalog import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	name := fs.String("catalog", "", "catalog to import into, the default catalog when empty")
+	format := fs.String("format", "", "csv or json, from the file extension when empty")
+	dryRun := fs.Bool("dry-run", false, "validate and print the diff only")
+	out := fs.String("o", "", `write the imported catalog as YAML to this file, "-" for stdout`)
+	apply := fs.Bool("apply", false, "store the imported catalog in the database")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("os.Open(%s): %w", path, err)
+	}
+	defer f.Close()
+
+	imported, err := catalog.Import(f, *format)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	catalogs, err := catalog.Load(cfg.Catalog.Path, cfg.Catalog.Default)
+	if err != nil {
+		return fmt.Errorf("catalog.Load: %w", err)
+	}
+	registry, err := catalog.NewRegistry(cfg.Catalog.Default, catalogs)
+	if err != nil {
+		return fmt.Errorf("catalog.NewRegistry: %w", err)
+	}
+
+	manager := core.NewCatalogManager(registry, nil)
+	if *apply {
+		database, err := initDB(cfg.DB)
+		if err != nil {
+			return fmt.Errorf("initDB: %w", err)
+		}
+		defer database.Close()
+
+		manager = core.NewCatalogManager(registry, repository.NewCatalogRepository(database))
+		if err := manager.Seed(ctx, catalogs); err != nil {
+			return fmt.Errorf("manager.Seed: %w", err)
+		}
+	}
+
+	result, diff, err := manager.Import(ctx, *name, imported, *dryRun || !*apply)
+	if err != nil {
+		return err
+	}
+	printDiff(stderr, result.Name, diff)
+	if *dryRun {
+		return nil
+	}
+	if *apply {
+		fmt.Fprintf(stderr, "stored %s v%d\n", result.Name, result.Version)
+	}
+
+	if *out == "" {
+		return nil
+	}
+	raw, err := result.YAML()
+	if err != nil {
+		return err
+	}
+	if *out == "-" {
+		_, err = stdout.Write(raw)
+		return err
+	}
+	if err := os.WriteFile(*out, raw, 0o644); err != nil { //nolint:gosec // catalog files are not secret
+		return fmt.Errorf("os.WriteFile(%s): %w", *out, err)
+	}
+	return nil
+}
+
+func printDiff(w io.Writer, name string, d catalog.Diff) {
+	if d.Empty() {
+		fmt.Fprintf(w, "%s: no changes\n", name)
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", name)
+	for _, line := range []struct {
+		label string
+		ids   []string
+	}{{"+", d.Added}, {"-", d.Removed}, {"~", d.Changed}} {
+		for _, id := range line.ids {
+			fmt.Fprintf(w, "  %s %s\n", line.label, id)
+		}
+	}
+	if d.LevelsChanged {
+		fmt.Fprintln(w, "  ~ levels")
+	}
+	if d.EquipmentChanged {
+		fmt.Fprintln(w, "  ~ equipment")
+	}
+}
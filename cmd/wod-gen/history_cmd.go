@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/config"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+var (
+	errExportUsage   = errors.New("usage: wod-gen export [-results] [-format jsonl|csv] [-owner sub] [-from time] [-to time] [-o file]")
+	errImportUsage   = errors.New("usage: wod-gen import [-format jsonl|csv] [-owner sub] [-dry-run] file")
+	errHistoryMemory = errors.New("memory storage starts empty, nothing to move")
+)
+
+// exportCmd writes the wods, or the results with -results, of STORAGE_DRIVER
+// to stdout or -o, those of every subject unless -owner.
+func exportCmd(ctx context.Context, cfg config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	results := fs.Bool("results", false, "export the results logged instead of the wods")
+	format := fs.String("format", core.FormatJSONL, "jsonl or csv")
+	owner := fs.String("owner", "", "only the wods, or results, of this subject")
+	from := fs.String("from", "", "only those created, or completed, at or after this RFC 3339 time")
+	to := fs.String("to", "", "only those created, or completed, before this RFC 3339 time")
+	out := fs.String("o", "", "file written instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errExportUsage
+	}
+	q := core.ExportQuery{Format: *format}
+	var err error
+	if q.From, err = flagTime(*from); err != nil {
+		return fmt.Errorf("-from: %w", err)
+	}
+	if q.To, err = flagTime(*to); err != nil {
+		return fmt.Errorf("-to: %w", err)
+	}
+	if cfg.Storage.Driver == "memory" {
+		return errHistoryMemory
+	}
+
+	store, err := initStorage(ctx, cfg, slog.New(slog.NewTextHandler(stderr, nil)))
+	if err != nil {
+		return fmt.Errorf("initStorage: %w", err)
+	}
+	defer store.close()
+
+	f := repository.WodFilter{Owner: *owner, AnyOwner: *owner == ""}
+	history := core.NewHistory(store.wods, store.results)
+	export := history.ExportWods
+	if *results {
+		export = history.ExportResults
+	}
+	exporter, err := export(ctx, f, q)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("os.Create: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	n, err := exporter(w)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		fmt.Fprintf(stdout, "exported %d rows to %s\n", n, *out)
+	}
+	return nil
+}
+
+// importCmd stores the wods of an export in STORAGE_DRIVER, of any owner;
+// the rows without one are -owner's.
+func importCmd(ctx context.Context, cfg config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "", "jsonl or csv, from the file extension when omitted")
+	owner := fs.String("owner", "", "owner of the rows without owner_sub")
+	dryRun := fs.Bool("dry-run", false, "validate the rows, store none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errImportUsage
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = core.FormatJSONL
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			*format = core.FormatCSV
+		}
+	}
+	if cfg.Storage.Driver == "memory" {
+		return errHistoryMemory
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer file.Close()
+
+	store, err := initStorage(ctx, cfg, slog.New(slog.NewTextHandler(stderr, nil)))
+	if err != nil {
+		return fmt.Errorf("initStorage: %w", err)
+	}
+	defer store.close()
+
+	history := core.NewHistory(store.wods, store.results)
+	report, err := history.ImportWods(ctx, file, core.ImportQuery{
+		Format: *format, Owner: *owner, AnyOwner: true, DryRun: *dryRun,
+	})
+	for _, e := range report.Errors {
+		fmt.Fprintf(stderr, "line %d: %v\n", e.Line, e.Err)
+	}
+	// a failed import may have stored a few batches already.
+	if *dryRun {
+		fmt.Fprintf(stdout, "read %d rows, %d valid, %d refused\n", report.Rows, report.Rows-report.Failed, report.Failed)
+	} else {
+		fmt.Fprintf(stdout, "read %d rows, imported %d, %d refused\n", report.Rows, report.Imported, report.Failed)
+	}
+	return err
+}
+
+// flagTime parses an RFC 3339 time flag, zero when empty.
+func flagTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -15,6 +15,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/LinaKACI-pro/wod-gen/db"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/config"
 	"github.com/LinaKACI-pro/wod-gen/internal/core"
 	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
@@ -41,6 +43,37 @@ func main() {
 		log.Fatalf("invalid config: %v", err)
 	}
 
+	// subcommands, the server runs without any.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "catalog":
+			if err := catalogCmd(context.Background(), cfg, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				log.Fatalf("catalog: %v", err)
+			}
+			return
+		case "migrate":
+			if err := migrateCmd(context.Background(), cfg, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				log.Fatalf("migrate: %v", err)
+			}
+			return
+		case "purge":
+			if err := purgeCmd(context.Background(), cfg, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				log.Fatalf("purge: %v", err)
+			}
+			return
+		case "export":
+			if err := exportCmd(context.Background(), cfg, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				log.Fatalf("export: %v", err)
+			}
+			return
+		case "import":
+			if err := importCmd(context.Background(), cfg, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+				log.Fatalf("import: %v", err)
+			}
+			return
+		}
+	}
+
 	// init logger
 	logger := pkg.NewLogger()
 
@@ -48,6 +81,10 @@ func main() {
 		slog.Int("port", cfg.HTTP.Port),
 		slog.Bool("obs", cfg.Obs.Enabled),
 		slog.String("rate_strategy", cfg.RateLimit.Strategy),
+		slog.String("catalog_path", cfg.Catalog.Path),
+		slog.String("catalog_default", cfg.Catalog.Default),
+		slog.String("storage", cfg.Storage.Driver),
+		slog.Bool("retention", cfg.Retention.Enabled),
 	)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -65,25 +102,55 @@ func main() {
 		}
 	}()
 
-	// init db
-	database, err := initDB(cfg.DB)
+	// init storage
+	store, err := initStorage(ctx, cfg, logger)
 	if err != nil {
-		logger.Error("initDb: ", slog.Any("err", err))
+		logger.Error("initStorage: ", slog.Any("err", err))
 		return
 	}
 	defer func() {
-		if err = database.Close(); err != nil {
-			logger.Warn("failed to close rows: ", slog.Any("err", err))
+		if err = store.close(); err != nil {
+			logger.Warn("failed to close storage: ", slog.Any("err", err))
 		}
 	}()
 
-	r := initRouter(cfg, logger)
+	// load catalogs of wod, from CATALOG_PATH when set and the embedded ones otherwise.
+	catalogs, err := catalog.Load(cfg.Catalog.Path, cfg.Catalog.Default)
+	if err != nil {
+		logger.Error("catalog.Load: ", slog.Any("err", err))
+		return
+	}
+	registry, err := catalog.NewRegistry(cfg.Catalog.Default, catalogs)
+	if err != nil {
+		logger.Error("catalog.NewRegistry: ", slog.Any("err", err))
+		return
+	}
+
+	if cfg.Catalog.Overlays != "" {
+		overlays, err := catalog.LoadOverlays(cfg.Catalog.Overlays)
+		if err != nil {
+			logger.Error("catalog.LoadOverlays: ", slog.Any("err", err))
+			return
+		}
+		if err := registry.SetOverlays(overlays); err != nil {
+			logger.Error("registry.SetOverlays: ", slog.Any("err", err))
+			return
+		}
+		logger.Info("catalog overlays loaded", slog.Int("tenants", len(overlays)))
+	}
+
+	r := initRouter(cfg, logger, registry)
 
 	// API v1 (auth + rate-limit)
 	api := r.Group("/api/v1")
 
 	jwtManager := pkg.NewJWTManager(cfg.Auth.JWTSecret, 24*time.Hour)
-	api.Use(pkg.AuthJWT(jwtManager, logger))
+	api.Use(pkg.AuthJWT(jwtManager, logger,
+		"GET /api/v1/catalog",
+		"GET /api/v1/catalog/moves/:name",
+		// calendar apps subscribe with the feed token instead.
+		"GET /api/v1/calendar/feed.ics",
+	))
 
 	if cfg.RateLimit.Enabled {
 		rl := pkg.NewLimiter(&cfg.RateLimit)
@@ -97,24 +164,51 @@ func main() {
 		return
 	}
 
-	swagger.Servers = nil
-	r.Use(ginvalidator.OapiRequestValidator(swagger))
+	// The server URL of the spec is the /api/v1 prefix, it checks no Host.
+	api.Use(ginvalidator.OapiRequestValidatorWithOptions(swagger, &ginvalidator.Options{
+		SilenceServersWarning: true,
+		ErrorHandler: func(c *gin.Context, message string, statusCode int) {
+			c.AbortWithStatusJSON(statusCode, gin.H{"code": statusCode, "message": message})
+		},
+	}))
 
-	// load catalog of wod.
-	c, err := catalog.NewCatalog(catalog.Raw)
+	catalogManager, err := initCatalog(ctx, cfg.Catalog, store.pg, catalogs, registry, logger)
 	if err != nil {
-		logger.Error("catalog.NewCatalog: ", slog.Any("err", err))
+		logger.Error("initCatalog: ", slog.Any("err", err))
 		return
 	}
 
-	// init repository
-	wodRepo := repository.NewWodRepository(database)
+	for _, name := range registry.Names() {
+		c, _ := registry.Get(name)
+		logger.Info("catalog loaded",
+			slog.String("catalog", name),
+			slog.Int64("version", c.Version),
+			slog.String("hash", c.Hash()),
+		)
+	}
 
 	// init core
-	wodGenerateCore := core.NewWodGenerator(c, wodRepo)
-	wodListCore := core.NewWodList(c, wodRepo)
+	wodGenerateCore := core.NewWodGenerator(registry, store.wods)
+	wodListCore := core.NewWodList(registry, store.wods, store.annotations)
+	resultsCore := core.NewResults(store.wods, store.results)
+	calendarCore := core.NewCalendar(store.wods, store.calendar)
+	statsCore := core.NewStats(store.stats)
+	historyCore := core.NewHistory(store.wods, store.results)
+
+	if cfg.Retention.Enabled {
+		retention := core.NewRetention(store.retention, cfg.Retention.Days, cfg.Retention.BatchSize, obs.WodsPurged())
+		go retention.Run(ctx, cfg.Retention.Interval, logger)
+	}
 
-	server := handlers.NewServer(wodGenerateCore, wodListCore)
+	server := handlers.NewServer(handlers.Services{
+		WodGenerate: wodGenerateCore,
+		WodList:     wodListCore,
+		Catalog:     catalogManager,
+		Results:     resultsCore,
+		Calendar:    calendarCore,
+		Stats:       statsCore,
+		History:     historyCore,
+	})
 	handlers.RegisterHandlersWithOptions(api, handlers.NewStrictHandler(server, nil), handlers.GinServerOptions{
 		BaseURL: "",
 	})
@@ -140,7 +234,143 @@ func initDB(dbCfg config.DBConfig) (*sql.DB, error) {
 	return db, nil
 }
 
-func initRouter(cfg config.Config, logger *slog.Logger) *gin.Engine {
+// initSQLite opens the SQLite file at path. SQLite only enforces the
+// REFERENCES of the schema, and their cascades, with foreign_keys on.
+func initSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open(sqlite): %w", err)
+	}
+	return db, nil
+}
+
+// storage is the backend selected by STORAGE_DRIVER. pg is only set for
+// postgres, the one storing catalogs.
+type storage struct {
+	pg          *sql.DB
+	wods        repository.WodRepositoryInterface
+	annotations repository.AnnotationRepositoryInterface
+	results     repository.ResultRepositoryInterface
+	calendar    repository.CalendarRepositoryInterface
+	retention   repository.RetentionRepositoryInterface
+	stats       repository.StatsRepositoryInterface
+	close       func() error
+}
+
+func initStorage(ctx context.Context, cfg config.Config, logger *slog.Logger) (storage, error) {
+	switch cfg.Storage.Driver {
+	case "memory":
+		wods := repository.NewMemoryWodRepository()
+		results := repository.NewMemoryResultRepository()
+		calendar := repository.NewMemoryCalendarRepository()
+		return storage{
+			wods:        wods,
+			annotations: wods,
+			results:     results,
+			calendar:    calendar,
+			retention:   repository.NewMemoryRetentionRepository(wods, results, calendar),
+			stats:       repository.NewMemoryStatsRepository(wods, results),
+			close:       func() error { return nil },
+		}, nil
+	case "sqlite":
+		database, err := initSQLite(cfg.Storage.SQLitePath)
+		if err != nil {
+			return storage{}, err
+		}
+		// the file has a single writer, its schema is always migrated on boot.
+		migrator, err := repository.NewSQLiteMigrator(database, db.SQLiteMigrations())
+		if err != nil {
+			database.Close()
+			return storage{}, err
+		}
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			database.Close()
+			return storage{}, fmt.Errorf("migrator.Up: %w", err)
+		}
+		for _, m := range applied {
+			logger.Info("migration applied", slog.Int64("version", m.Version), slog.String("name", m.Name))
+		}
+		wods := repository.NewSQLiteWodRepository(database)
+		results := repository.NewSQLiteResultRepository(database)
+		calendar := repository.NewSQLiteCalendarRepository(database)
+		retention := repository.NewSQLiteRetentionRepository(database)
+		return storage{
+			wods:        wods,
+			annotations: wods,
+			results:     results,
+			calendar:    calendar,
+			retention:   retention,
+			stats:       repository.NewSQLiteStatsRepository(database),
+			close:       database.Close,
+		}, nil
+	}
+
+	database, err := initDB(cfg.DB)
+	if err != nil {
+		return storage{}, err
+	}
+	// replicas booting together wait on the migration lock, the first applies.
+	if cfg.DB.AutoMigrate {
+		migrator, err := repository.NewMigrator(database, db.Migrations())
+		if err != nil {
+			database.Close()
+			return storage{}, err
+		}
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			database.Close()
+			return storage{}, fmt.Errorf("migrator.Up: %w", err)
+		}
+		for _, m := range applied {
+			logger.Info("migration applied", slog.Int64("version", m.Version), slog.String("name", m.Name))
+		}
+	}
+	wods := repository.NewWodRepository(database)
+	return storage{
+		pg:          database,
+		wods:        wods,
+		annotations: wods,
+		results:     repository.NewResultRepository(database),
+		calendar:    repository.NewCalendarRepository(database),
+		retention:   repository.NewRetentionRepository(database),
+		stats:       repository.NewStatsRepository(database),
+		close:       database.Close,
+	}, nil
+}
+
+// initCatalog wires the catalog source. From the database, the loaded YAML
+// only seeds the catalogs not stored yet and replicas poll for changes; from
+// files, the YAML is served as is and reloaded when it changes. Without
+// Postgres the catalogs always come from files.
+func initCatalog(ctx context.Context, cfg config.CatalogConfig, db *sql.DB, seeds map[string]*catalog.Catalog,
+	registry *catalog.Registry, logger *slog.Logger,
+) (*core.CatalogManager, error) {
+	if cfg.Source == "db" && db == nil {
+		logger.Warn("no catalog store without postgres, the catalogs are served from files")
+	}
+	if cfg.Source == "file" || db == nil {
+		if cfg.Path != "" {
+			reloader := catalog.NewReloader(registry, cfg.Path, logger)
+			go func() {
+				if err := reloader.Run(ctx); err != nil {
+					logger.Error("reloader.Run", slog.Any("err", err))
+				}
+			}()
+		}
+		return core.NewCatalogManager(registry, nil), nil
+	}
+
+	manager := core.NewCatalogManager(registry, repository.NewCatalogRepository(db))
+	if err := manager.Seed(ctx, seeds); err != nil {
+		return nil, fmt.Errorf("manager.Seed: %w", err)
+	}
+	go manager.Run(ctx, cfg.RefreshInterval, logger)
+
+	return manager, nil
+}
+
+func initRouter(cfg config.Config, logger *slog.Logger, registry *catalog.Registry) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 
@@ -156,16 +386,43 @@ func initRouter(cfg config.Config, logger *slog.Logger) *gin.Engine {
 
 	isProd := os.Getenv("ENV") == "prod"
 	r.Use(pkg.SecurityHeaders(isProd))
-	r.Use(pkg.TimeoutMiddleware(10 * time.Second))
+	r.Use(pkg.TimeoutMiddleware(10*time.Second, map[string]time.Duration{
+		"/api/v1/wod/export":     cfg.HTTP.ExportTimeout,
+		"/api/v1/results/export": cfg.HTTP.ExportTimeout,
+	}))
 	r.Use(pkg.BodyLimit(cfg.HTTP))
+	r.Use(pkg.AcceptLanguage(common.Locales()...))
 
 	// Health / Ready
 	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
-	r.GET("/readyz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/readyz", readyz(registry))
 
 	return r
 }
 
+// readyz reports the catalogs served, so a replayed seed giving other blocks
+// can be traced to a catalog change. The versions and hashes follow reloads.
+func readyz(registry *catalog.Registry) gin.HandlerFunc {
+	type catalogInfo struct {
+		Name    string `json:"name"`
+		Version int64  `json:"version"`
+		Hash    string `json:"hash"`
+	}
+
+	return func(c *gin.Context) {
+		names := registry.Names()
+		infos := make([]catalogInfo, 0, len(names))
+		for _, name := range names {
+			cat, err := registry.Get(name)
+			if err != nil {
+				continue
+			}
+			infos = append(infos, catalogInfo{Name: name, Version: cat.Version, Hash: cat.Hash()})
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "catalogs": infos})
+	}
+}
+
 func runServer(ctx context.Context, cfg config.Config, logger *slog.Logger, handler http.Handler) {
 	srv := &http.Server{
 		Addr:              ":" + strconv.Itoa(cfg.HTTP.Port),
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/LinaKACI-pro/wod-gen/db"
+	"github.com/LinaKACI-pro/wod-gen/internal/config"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+var (
+	errMigrateUsage  = errors.New("usage: wod-gen migrate up | down [-steps n] | status")
+	errMigrateMemory = errors.New("memory storage has no schema to migrate")
+)
+
+// migrateCmd applies, reverts or lists the embedded migrations of
+// STORAGE_DRIVER, Postgres or SQLite.
+func migrateCmd(ctx context.Context, cfg config.Config, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	steps := fs.Int("steps", 1, "migrations to revert, newest first")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errMigrateUsage
+	}
+
+	var migrator *repository.Migrator
+	switch cfg.Storage.Driver {
+	case "memory":
+		return errMigrateMemory
+	case "sqlite":
+		database, err := initSQLite(cfg.Storage.SQLitePath)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+		if migrator, err = repository.NewSQLiteMigrator(database, db.SQLiteMigrations()); err != nil {
+			return err
+		}
+	default:
+		database, err := initDB(cfg.DB)
+		if err != nil {
+			return fmt.Errorf("initDB: %w", err)
+		}
+		defer database.Close()
+		if migrator, err = repository.NewMigrator(database, db.Migrations()); err != nil {
+			return err
+		}
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(stdout, "applied %03d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "down":
+		if *steps < 1 {
+			return errMigrateUsage
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		for _, m := range reverted {
+			fmt.Fprintf(stdout, "reverted %03d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		st, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "version %d", st.Version)
+		if st.Dirty {
+			fmt.Fprint(stdout, " (dirty)")
+		}
+		fmt.Fprintln(stdout)
+		for _, m := range st.Pending {
+			fmt.Fprintf(stdout, "pending %03d_%s\n", m.Version, m.Name)
+		}
+		return nil
+	default:
+		return errMigrateUsage
+	}
+}
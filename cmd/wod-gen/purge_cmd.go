@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/config"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+)
+
+var (
+	errPurgeUsage  = errors.New("usage: wod-gen purge [-dry-run] [-days n] [-batch-size n]")
+	errPurgeMemory = errors.New("memory storage starts empty, nothing to purge")
+)
+
+// purgeCmd deletes the wods past retention from STORAGE_DRIVER, as the
+// server does with RETENTION_ENABLED, or counts them with -dry-run.
+func purgeCmd(ctx context.Context, cfg config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("purge", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dryRun := fs.Bool("dry-run", false, "count the wods to purge, delete none")
+	days := fs.Int("days", cfg.Retention.Days, "days the unused wods are kept")
+	batchSize := fs.Int("batch-size", cfg.Retention.BatchSize, "wods deleted a statement")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *days < 1 || *batchSize < 1 {
+		return errPurgeUsage
+	}
+	if cfg.Storage.Driver == "memory" {
+		return errPurgeMemory
+	}
+
+	store, err := initStorage(ctx, cfg, slog.New(slog.NewTextHandler(stderr, nil)))
+	if err != nil {
+		return fmt.Errorf("initStorage: %w", err)
+	}
+	defer store.close()
+
+	retention := core.NewRetention(store.retention, *days, *batchSize, nil)
+	cutoff := retention.Cutoff(time.Now()).Format(time.RFC3339)
+	n, err := retention.Purge(ctx, *dryRun)
+	// a failed purge may have deleted a few batches already.
+	if err != nil && n == 0 {
+		return err
+	}
+	if *dryRun {
+		fmt.Fprintf(stdout, "would purge %d wods created before %s\n", n, cutoff)
+		return nil
+	}
+	fmt.Fprintf(stdout, "purged %d wods created before %s\n", n, cutoff)
+	return err
+}
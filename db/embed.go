@@ -0,0 +1,29 @@
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql sqlite/*.sql
+var migrations embed.FS
+
+// Migrations returns the schema migrations shipped with the binary, applied
+// in version order by `wod-gen migrate`.
+func Migrations() fs.FS {
+	return sub("migrations")
+}
+
+// SQLiteMigrations returns the migrations of the SQLite files of
+// STORAGE_DRIVER=sqlite, versioned apart from the Postgres ones.
+func SQLiteMigrations() fs.FS {
+	return sub("sqlite")
+}
+
+func sub(dir string) fs.FS {
+	sub, err := fs.Sub(migrations, dir)
+	if err != nil {
+		panic(err) // the directory is embedded, it exists
+	}
+	return sub
+}
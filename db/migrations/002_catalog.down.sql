@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS catalog_version;
+DROP TABLE IF EXISTS catalog_moves;
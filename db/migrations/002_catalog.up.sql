@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS catalog_moves (
+    name TEXT PRIMARY KEY,
+    needs_one_of TEXT[] NOT NULL DEFAULT '{}',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    ranges JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- single row, bumped by every write on catalog_moves so replicas can tell
+-- their cached snapshot is stale.
+CREATE TABLE IF NOT EXISTS catalog_version (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    version BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO catalog_version (id, version) VALUES (TRUE, 0)
+    ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,20 @@
+-- only the hyrox catalog existed before named catalogs, the others are lost.
+ALTER TABLE wods DROP COLUMN IF EXISTS catalog_version;
+ALTER TABLE wods DROP COLUMN IF EXISTS catalog;
+
+CREATE TABLE IF NOT EXISTS catalog_version (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    version BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO catalog_version (id, version)
+SELECT TRUE, COALESCE((SELECT version FROM catalogs WHERE name = 'hyrox'), 0)
+ON CONFLICT (id) DO NOTHING;
+
+DELETE FROM catalog_moves WHERE catalog <> 'hyrox';
+ALTER TABLE catalog_moves DROP CONSTRAINT IF EXISTS catalog_moves_pkey;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS catalog;
+ALTER TABLE catalog_moves ADD PRIMARY KEY (name);
+
+DROP TABLE IF EXISTS catalogs;
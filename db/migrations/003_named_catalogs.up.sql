@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS catalogs (
+    name TEXT PRIMARY KEY,
+    levels TEXT[] NOT NULL DEFAULT '{}',
+    equipment TEXT[] NOT NULL DEFAULT '{}',
+    version BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- moves stored before named catalogs all belong to the hyrox catalog. The
+-- guard lets the migration run again on a database already past it: those the
+-- docker-entrypoint-initdb.d mount created have every table but no
+-- schema_migrations, so they are migrated from 001 and catalog_version is gone.
+-- Wherever catalog_version exists the guarded INSERT is the one 003 always
+-- ran, so databases already at version 3 or more lose nothing by skipping
+-- this text; a later migration could not help, 003 fails before it runs.
+DO $$
+BEGIN
+    IF to_regclass('catalog_version') IS NOT NULL THEN
+        INSERT INTO catalogs (name, version)
+        SELECT 'hyrox', version FROM catalog_version
+        WHERE EXISTS (SELECT 1 FROM catalog_moves)
+        ON CONFLICT (name) DO NOTHING;
+    END IF;
+END $$;
+
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS catalog TEXT NOT NULL DEFAULT 'hyrox'
+    REFERENCES catalogs(name) ON DELETE CASCADE;
+ALTER TABLE catalog_moves ALTER COLUMN catalog DROP DEFAULT;
+ALTER TABLE catalog_moves DROP CONSTRAINT IF EXISTS catalog_moves_pkey;
+ALTER TABLE catalog_moves ADD PRIMARY KEY (catalog, name);
+
+DROP TABLE IF EXISTS catalog_version;
+
+ALTER TABLE wods ADD COLUMN IF NOT EXISTS catalog TEXT NOT NULL DEFAULT 'hyrox';
+ALTER TABLE wods ADD COLUMN IF NOT EXISTS catalog_version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS locales;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS description;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS id;
@@ -0,0 +1,5 @@
+-- moves stored before ids existed keep an empty id, the service derives it
+-- from the name until the move is next written.
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS id TEXT NOT NULL DEFAULT '';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS locales JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS media;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS units;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS pattern;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS muscles;
+ALTER TABLE catalog_moves DROP COLUMN IF EXISTS cues;
@@ -0,0 +1,5 @@
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS cues TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS muscles TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS pattern TEXT NOT NULL DEFAULT '';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS units JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE catalog_moves ADD COLUMN IF NOT EXISTS media JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wods_catalog_version;
+ALTER TABLE wods DROP COLUMN IF EXISTS catalog_hash;
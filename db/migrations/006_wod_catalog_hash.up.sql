@@ -0,0 +1,5 @@
+-- wods generated before the hash was recorded keep an empty one.
+ALTER TABLE wods ADD COLUMN IF NOT EXISTS catalog_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_wods_catalog_version
+    ON wods(catalog, catalog_version, created_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wods_owner_created_at;
+ALTER TABLE wods DROP COLUMN IF EXISTS owner_sub;
@@ -0,0 +1,14 @@
+-- wods are owned by the JWT subject that generated them. The ones stored
+-- before have no known owner: they go to the subject set in
+-- wodgen.legacy_owner when running this migration, 'legacy' otherwise, and
+-- stay visible to admins.
+ALTER TABLE wods ADD COLUMN IF NOT EXISTS owner_sub TEXT NOT NULL DEFAULT '';
+
+UPDATE wods
+SET owner_sub = COALESCE(NULLIF(current_setting('wodgen.legacy_owner', true), ''), 'legacy')
+WHERE owner_sub = '';
+
+ALTER TABLE wods ALTER COLUMN owner_sub DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_created_at
+    ON wods(owner_sub, created_at DESC);
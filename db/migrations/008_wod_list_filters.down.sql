@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_wods_blocks;
+DROP INDEX IF EXISTS idx_wods_equipment;
+DROP INDEX IF EXISTS idx_wods_owner_duration;
+DROP INDEX IF EXISTS idx_wods_owner_level_duration;
@@ -0,0 +1,13 @@
+-- the list filters of /wod/list, every listing is scoped to an owner.
+CREATE INDEX IF NOT EXISTS idx_wods_owner_level_duration
+    ON wods(owner_sub, level, duration_min, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_duration
+    ON wods(owner_sub, duration_min DESC, created_at DESC);
+
+-- equipment @> / && and blocks @> '[{"id": ...}]'.
+CREATE INDEX IF NOT EXISTS idx_wods_equipment
+    ON wods USING GIN (equipment);
+
+CREATE INDEX IF NOT EXISTS idx_wods_blocks
+    ON wods USING GIN (blocks jsonb_path_ops);
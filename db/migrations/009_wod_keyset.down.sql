@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS idx_wods_owner_duration
+    ON wods(owner_sub, duration_min DESC, created_at DESC);
+DROP INDEX IF EXISTS idx_wods_owner_duration_id;
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_created_at
+    ON wods(owner_sub, created_at DESC);
+DROP INDEX IF EXISTS idx_wods_owner_created_at_id;
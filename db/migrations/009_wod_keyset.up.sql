@@ -0,0 +1,8 @@
+-- keyset pagination seeks on (created_at, id) and (duration_min, created_at, id).
+CREATE INDEX IF NOT EXISTS idx_wods_owner_created_at_id
+    ON wods(owner_sub, created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_wods_owner_created_at;
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_duration_id
+    ON wods(owner_sub, duration_min DESC, created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_wods_owner_duration;
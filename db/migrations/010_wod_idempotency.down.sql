@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wods_owner_idempotency_key;
+ALTER TABLE wods DROP COLUMN IF EXISTS idempotency_key;
@@ -0,0 +1,5 @@
+-- the Idempotency-Key a wod was generated with, a retry returns that wod.
+ALTER TABLE wods ADD COLUMN IF NOT EXISTS idempotency_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wods_owner_idempotency_key
+    ON wods(owner_sub, idempotency_key) WHERE idempotency_key IS NOT NULL;
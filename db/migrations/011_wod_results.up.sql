@@ -0,0 +1,21 @@
+-- how athletes did on a wod, scored by time or by rounds and reps.
+CREATE TABLE IF NOT EXISTS wod_results (
+    id UUID PRIMARY KEY,
+    wod_id UUID NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    time_sec INT CHECK (time_sec > 0),
+    rounds INT CHECK (rounds >= 0),
+    reps INT CHECK (reps >= 0),
+    splits JSONB NOT NULL DEFAULT '[]',
+    rpe SMALLINT CHECK (rpe BETWEEN 1 AND 10),
+    scaling TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((time_sec IS NULL) <> (rounds IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_wod_results_wod
+    ON wod_results(wod_id, completed_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_wod_results_subject
+    ON wod_results(subject, completed_at DESC, id DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wod_results_wod_division;
+DELETE FROM wod_results WHERE load_kg IS NOT NULL;
+ALTER TABLE wod_results DROP CONSTRAINT IF EXISTS wod_results_one_score;
+ALTER TABLE wod_results ADD CONSTRAINT wod_results_check CHECK ((time_sec IS NULL) <> (rounds IS NULL));
+ALTER TABLE wod_results DROP COLUMN IF EXISTS division;
+ALTER TABLE wod_results DROP COLUMN IF EXISTS load_kg;
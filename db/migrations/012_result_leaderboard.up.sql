@@ -0,0 +1,12 @@
+-- results scored by load, and the division athletes compete in.
+ALTER TABLE wod_results ADD COLUMN IF NOT EXISTS load_kg DOUBLE PRECISION CHECK (load_kg > 0);
+ALTER TABLE wod_results ADD COLUMN IF NOT EXISTS division TEXT NOT NULL DEFAULT '';
+
+-- a result has exactly one score: time, rounds or load.
+ALTER TABLE wod_results DROP CONSTRAINT IF EXISTS wod_results_check;
+ALTER TABLE wod_results DROP CONSTRAINT IF EXISTS wod_results_one_score;
+ALTER TABLE wod_results ADD CONSTRAINT wod_results_one_score
+    CHECK (num_nonnulls(time_sec, rounds, load_kg) = 1);
+
+CREATE INDEX IF NOT EXISTS idx_wod_results_wod_division
+    ON wod_results(wod_id, division);
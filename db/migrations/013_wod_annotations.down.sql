@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wod_labels;
+DROP TABLE IF EXISTS wod_notes;
+DROP TABLE IF EXISTS wod_favorites;
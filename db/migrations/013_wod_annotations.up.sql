@@ -0,0 +1,26 @@
+-- what athletes note on wods: favorites, a free text note and labels, each
+-- their own.
+CREATE TABLE IF NOT EXISTS wod_favorites (
+    subject TEXT NOT NULL,
+    wod_id UUID NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subject, wod_id)
+);
+
+CREATE TABLE IF NOT EXISTS wod_notes (
+    subject TEXT NOT NULL,
+    wod_id UUID NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    body TEXT NOT NULL CHECK (body <> ''),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subject, wod_id)
+);
+
+CREATE TABLE IF NOT EXISTS wod_labels (
+    subject TEXT NOT NULL,
+    wod_id UUID NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (subject, wod_id, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wod_labels_subject_label
+    ON wod_labels(subject, label);
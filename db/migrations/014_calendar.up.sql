@@ -0,0 +1,26 @@
+-- sessions planned by date: wods scheduled for a subject, the ones scheduled
+-- together as a program share its name.
+CREATE TABLE IF NOT EXISTS calendar_entries (
+    id UUID PRIMARY KEY,
+    subject TEXT NOT NULL,
+    wod_id UUID NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    program TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_entries_subject_day
+    ON calendar_entries(subject, day, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_entries_subject_program
+    ON calendar_entries(subject, program) WHERE program <> '';
+
+-- the calendar feed of a subject is read with a secret token, only its
+-- sha256 is kept.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    subject TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
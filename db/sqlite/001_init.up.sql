@@ -0,0 +1,25 @@
+-- the SQLite counterpart of db/migrations: equipment and blocks are JSON
+-- text, uuids and timestamps text.
+CREATE TABLE IF NOT EXISTS wods (
+    id TEXT PRIMARY KEY,
+    seed TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    level TEXT NOT NULL,
+    duration_min INTEGER NOT NULL,
+    equipment TEXT NOT NULL DEFAULT '[]',
+    blocks TEXT NOT NULL,
+    catalog TEXT NOT NULL DEFAULT 'hyrox',
+    catalog_version INTEGER NOT NULL DEFAULT 0,
+    catalog_hash TEXT NOT NULL DEFAULT '',
+    owner_sub TEXT NOT NULL,
+    idempotency_key TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_created_at_id
+    ON wods(owner_sub, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_wods_owner_duration_id
+    ON wods(owner_sub, duration_min DESC, created_at DESC, id DESC);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wods_owner_idempotency_key
+    ON wods(owner_sub, idempotency_key) WHERE idempotency_key IS NOT NULL;
@@ -0,0 +1,20 @@
+-- splits are JSON text, like the blocks of wods.
+CREATE TABLE IF NOT EXISTS wod_results (
+    id TEXT PRIMARY KEY,
+    wod_id TEXT NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    time_sec INTEGER,
+    rounds INTEGER,
+    reps INTEGER,
+    splits TEXT NOT NULL DEFAULT '[]',
+    rpe INTEGER,
+    scaling TEXT NOT NULL DEFAULT '',
+    completed_at TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_wod_results_wod
+    ON wod_results(wod_id, completed_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_wod_results_subject
+    ON wod_results(subject, completed_at DESC, id DESC);
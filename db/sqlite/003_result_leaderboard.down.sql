@@ -0,0 +1,3 @@
+DELETE FROM wod_results WHERE load_kg IS NOT NULL;
+ALTER TABLE wod_results DROP COLUMN division;
+ALTER TABLE wod_results DROP COLUMN load_kg;
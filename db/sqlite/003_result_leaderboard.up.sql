@@ -0,0 +1,3 @@
+-- results scored by load, and the division athletes compete in.
+ALTER TABLE wod_results ADD COLUMN load_kg REAL;
+ALTER TABLE wod_results ADD COLUMN division TEXT NOT NULL DEFAULT '';
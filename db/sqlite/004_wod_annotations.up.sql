@@ -0,0 +1,23 @@
+-- what athletes note on wods: favorites, a free text note and labels, each
+-- their own.
+CREATE TABLE IF NOT EXISTS wod_favorites (
+    subject TEXT NOT NULL,
+    wod_id TEXT NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (subject, wod_id)
+);
+
+CREATE TABLE IF NOT EXISTS wod_notes (
+    subject TEXT NOT NULL,
+    wod_id TEXT NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (subject, wod_id)
+);
+
+CREATE TABLE IF NOT EXISTS wod_labels (
+    subject TEXT NOT NULL,
+    wod_id TEXT NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (subject, wod_id, label)
+);
@@ -0,0 +1,22 @@
+-- sessions planned by date: wods scheduled for a subject, days are
+-- YYYY-MM-DD text.
+CREATE TABLE IF NOT EXISTS calendar_entries (
+    id TEXT PRIMARY KEY,
+    subject TEXT NOT NULL,
+    wod_id TEXT NOT NULL REFERENCES wods(id) ON DELETE CASCADE,
+    day TEXT NOT NULL,
+    program TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_entries_subject_day
+    ON calendar_entries(subject, day, created_at, id);
+
+-- only the sha256 of the feed tokens is kept.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    subject TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TEXT NOT NULL
+);
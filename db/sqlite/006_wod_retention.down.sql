@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_calendar_entries_wod;
+DROP INDEX IF EXISTS idx_wod_labels_wod;
+DROP INDEX IF EXISTS idx_wod_notes_wod;
+DROP INDEX IF EXISTS idx_wod_favorites_wod;
+DROP INDEX IF EXISTS idx_wods_created_at;
@@ -0,0 +1,16 @@
+-- the retention purge seeks the oldest wods, then checks nothing references
+-- them: index the references by wod, which also speeds up the cascades.
+CREATE INDEX IF NOT EXISTS idx_wods_created_at
+    ON wods(created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_wod_favorites_wod
+    ON wod_favorites(wod_id);
+
+CREATE INDEX IF NOT EXISTS idx_wod_notes_wod
+    ON wod_notes(wod_id);
+
+CREATE INDEX IF NOT EXISTS idx_wod_labels_wod
+    ON wod_labels(wod_id);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_entries_wod
+    ON calendar_entries(wod_id);
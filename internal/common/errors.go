@@ -6,9 +6,49 @@ import (
 )
 
 var (
-	ErrDuration     = errors.New("duration_min must be between 15 and 120")
-	ErrEmptyCatalog = errors.New("empty catalog")
-	ErrNoMoves      = errors.New("no moves available")
+	ErrDuration       = errors.New("duration_min must be between 15 and 120")
+	ErrEmptyCatalog   = errors.New("empty catalog")
+	ErrInvalidCatalog = errors.New("invalid catalog")
+	ErrNoMoves        = errors.New("no moves available")
+
+	ErrUnknownCatalog   = errors.New("unknown catalog")
+	ErrLevelUnavailable = errors.New("level not offered by this catalog")
+
+	ErrCatalogReadOnly = errors.New("catalog is read-only, it is loaded from files")
+	ErrMoveExists      = errors.New("move already exists")
+	ErrMoveNotFound    = errors.New("move not found")
+
+	ErrMissingBody = errors.New("missing body")
+	ErrUnknownPath = errors.New("unknown path")
+	ErrAdminOnly   = errors.New("admin role required")
+	ErrListWods    = errors.New("failed to list wods")
+	ErrWodNotFound = errors.New("wod not found")
+	ErrWodFilter   = errors.New("invalid wod filter")
+	ErrWodExists   = errors.New("wod already stored")
+	ErrInternal    = errors.New("internal server error")
+
+	ErrInvalidBatch        = errors.New("invalid batch")
+	ErrIdempotencyKey      = errors.New("invalid idempotency key, 1 to 255 characters")
+	ErrIdempotencyConflict = errors.New("idempotency key already used for a request with other parameters")
+
+	ErrResultFilter      = errors.New("invalid result filter")
+	ErrInvalidAnnotation = errors.New("invalid annotation")
+	ErrInvalidResult     = errors.New("invalid result")
+
+	ErrInvalidCalendarEntry  = errors.New("invalid calendar entry")
+	ErrCalendarEntryNotFound = errors.New("calendar entry not found")
+	ErrCalendarFeedNotFound  = errors.New("calendar feed not found")
+
+	ErrPurgeLocked = errors.New("another purge is running")
+
+	ErrStatsFilter = errors.New("invalid stats filter")
+
+	ErrExportFilter  = errors.New("invalid export filter")
+	ErrInvalidImport = errors.New("invalid import")
+	ErrInvalidRow    = errors.New("invalid row")
+
+	ErrInvalidMigration = errors.New("invalid migration")
+	ErrDirtySchema      = errors.New("schema is dirty, a migration failed halfway: fix it by hand first")
 )
 
 type InvalidDataError struct {
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Locales the API answers in.
+const (
+	LocaleEN = "en"
+	LocaleFR = "fr"
+)
+
+// Locales lists the supported locales, the default one first.
+func Locales() []string {
+	return []string{LocaleEN, LocaleFR}
+}
+
+type translation struct {
+	err error
+	fr  string
+}
+
+func translations() []translation {
+	return []translation{
+		{ErrDuration, "duration_min doit être compris entre 15 et 120"},
+		{ErrEmptyCatalog, "catalogue vide"},
+		{ErrInvalidCatalog, "catalogue invalide"},
+		{ErrNoMoves, "aucun mouvement disponible"},
+		{ErrUnknownCatalog, "catalogue inconnu"},
+		{ErrLevelUnavailable, "niveau non proposé par ce catalogue"},
+		{ErrCatalogReadOnly, "le catalogue est en lecture seule, il est chargé depuis des fichiers"},
+		{ErrMoveExists, "le mouvement existe déjà"},
+		{ErrMoveNotFound, "mouvement introuvable"},
+		{ErrMissingBody, "corps de requête manquant"},
+		{ErrUnknownPath, "chemin inconnu"},
+		{ErrAdminOnly, "rôle admin requis"},
+		{ErrListWods, "impossible de lister les WODs"},
+		{ErrWodNotFound, "WOD introuvable"},
+		{ErrWodFilter, "filtre de WOD invalide"},
+		{ErrInvalidResult, "résultat invalide"},
+		{ErrInvalidAnnotation, "annotation invalide"},
+		{ErrResultFilter, "filtre de résultats invalide"},
+		{ErrInvalidCalendarEntry, "entrée de calendrier invalide"},
+		{ErrCalendarEntryNotFound, "entrée de calendrier introuvable"},
+		{ErrCalendarFeedNotFound, "flux de calendrier introuvable"},
+		{ErrPurgeLocked, "une autre purge est en cours"},
+		{ErrStatsFilter, "filtre de statistiques invalide"},
+		{ErrInvalidBatch, "lot invalide"},
+		{ErrExportFilter, "filtre d'export invalide"},
+		{ErrInvalidImport, "import invalide"},
+		{ErrInvalidRow, "ligne invalide"},
+		{ErrIdempotencyKey, "clé d'idempotence invalide, de 1 à 255 caractères"},
+		{ErrIdempotencyConflict, "clé d'idempotence déjà utilisée pour une requête aux paramètres différents"},
+		{ErrInternal, "erreur interne du serveur"},
+	}
+}
+
+// Translate returns the client message for err in locale. English is the
+// error text itself; in other locales the errors of this package are
+// translated, followed by the untranslated details wrapped around them.
+func Translate(err error, locale string) string {
+	if locale != LocaleFR {
+		return err.Error()
+	}
+
+	var invalidDataErr InvalidDataError
+	if errors.As(err, &invalidDataErr) {
+		dataType := invalidDataErr.DataType
+		if dataType == "level" {
+			dataType = "niveau"
+		}
+		return fmt.Sprintf("%s invalide : %s, choisir parmi [beginner, intermediate, advanced]", dataType, invalidDataErr.Data)
+	}
+
+	for _, t := range translations() {
+		if !errors.Is(err, t.err) {
+			continue
+		}
+		if details, ok := strings.CutPrefix(err.Error(), t.err.Error()); ok {
+			return t.fr + details
+		}
+		return t.fr
+	}
+	return err.Error()
+}
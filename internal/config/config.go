@@ -25,6 +25,9 @@ type HTTPConfig struct {
 	IdleTimeout    time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
 	MaxHeaderBytes int           `env:"MAX_HEADER_BYTES" envDefault:"1048576"`
 	InFlightLimit  int           `env:"INFLIGHT_LIMIT" envDefault:"50"`
+	// ExportTimeout replaces the request timeout and WriteTimeout of the
+	// streamed exports.
+	ExportTimeout time.Duration `env:"EXPORT_TIMEOUT" envDefault:"10m"`
 }
 
 type DBConfig struct {
@@ -34,6 +37,24 @@ type DBConfig struct {
 	HOST     string `env:"DATABASE_HOST" envDefault:"pgsql"`
 	PORT     string `env:"DATABASE_PORT" envDefault:"5432"`
 	SSLMODE  string `env:"DATABASE_SSLMODE" envDefault:"disable"`
+	// AutoMigrate applies the pending migrations on boot.
+	AutoMigrate bool `env:"DATABASE_AUTO_MIGRATE" envDefault:"false"`
+}
+
+type StorageConfig struct {
+	// Driver: "postgres", "sqlite" for a single node, or "memory" for tests and demos (lost on restart).
+	Driver string `env:"STORAGE_DRIVER" envDefault:"postgres" validate:"oneof=postgres sqlite memory"`
+	// SQLitePath is the database file of the sqlite driver.
+	SQLitePath string `env:"SQLITE_PATH" envDefault:"wodgen.db"`
+}
+
+type RetentionConfig struct {
+	// Enabled runs the purge in the server, on one replica at a time.
+	Enabled bool `env:"RETENTION_ENABLED" envDefault:"false"`
+	// Days the wods nobody used are kept: no result, favorite, note, label or calendar entry.
+	Days      int           `env:"RETENTION_DAYS" envDefault:"90" validate:"min=1"`
+	Interval  time.Duration `env:"RETENTION_INTERVAL" envDefault:"1h" validate:"gt=0"`
+	BatchSize int           `env:"RETENTION_BATCH_SIZE" envDefault:"1000" validate:"min=1"`
 }
 
 type ObsConfig struct {
@@ -43,6 +64,18 @@ type ObsConfig struct {
 	OTLPProtocol string `env:"OTEL_EXPORTER_OTLP_PROTOCOL" envDefault:"http/protobuf"`
 }
 
+type CatalogConfig struct {
+	// Path to a YAML file or a directory of YAML fragments, overrides the embedded catalog.
+	Path string `env:"CATALOG_PATH"`
+	// Default catalog, used by requests that do not name one.
+	Default string `env:"CATALOG_DEFAULT" envDefault:"hyrox" validate:"required"`
+	// Source: "file" serves the YAML, "db" serves the catalog from Postgres (the YAML only seeds it).
+	Source          string        `env:"CATALOG_SOURCE" envDefault:"file" validate:"oneof=db file"`
+	RefreshInterval time.Duration `env:"CATALOG_REFRESH_INTERVAL" envDefault:"30s"`
+	// Overlays: a YAML file or a directory of per-tenant overlays on the catalogs.
+	Overlays string `env:"CATALOG_OVERLAYS"`
+}
+
 type DebugConfig struct {
 	PprofEnabled bool `env:"PPROF_ENABLED" envDefault:"false"`
 }
@@ -52,6 +85,9 @@ type Config struct {
 	RateLimit RateLimiterConfig
 	HTTP      HTTPConfig
 	DB        DBConfig
+	Storage   StorageConfig
+	Retention RetentionConfig
 	Obs       ObsConfig
+	Catalog   CatalogConfig
 	Debug     DebugConfig
 }
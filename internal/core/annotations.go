@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNote   = 2000
+	MaxLabels = 20
+	MaxLabel  = 32
+)
+
+// AnnotationPatch sets the annotations it has, the nil ones are kept.
+type AnnotationPatch struct {
+	Favorite *bool
+	Note     *string
+	Labels   *[]string
+}
+
+func (w *WodList) Annotate(ctx context.Context, id uuid.UUID, subject string, patch AnnotationPatch) (models.Annotations, error) {
+	// annotations are kept on one's own wods, even by admins.
+	if _, err := getWod(ctx, w.wodRepository, id, repository.WodFilter{Owner: subject}); err != nil {
+		return models.Annotations{}, err
+	}
+	current, err := w.annotationRepository.GetAnnotations(ctx, subject, []uuid.UUID{id})
+	if err != nil {
+		return models.Annotations{}, fmt.Errorf("annotationRepository.GetAnnotations(): %w", err)
+	}
+
+	a := current[id]
+	if patch.Favorite != nil {
+		a.Favorite = *patch.Favorite
+	}
+	if patch.Note != nil {
+		a.Note = strings.TrimSpace(*patch.Note)
+		if utf8.RuneCountInString(a.Note) > MaxNote {
+			return models.Annotations{}, fmt.Errorf("%w: note is limited to %d characters", common.ErrInvalidAnnotation, MaxNote)
+		}
+	}
+	if patch.Labels != nil {
+		if a.Labels, err = normalizeLabels(*patch.Labels); err != nil {
+			return models.Annotations{}, fmt.Errorf("%w: %w", common.ErrInvalidAnnotation, err)
+		}
+	}
+
+	if err := w.annotationRepository.SaveAnnotations(ctx, subject, id, a); err != nil {
+		return models.Annotations{}, fmt.Errorf("annotationRepository.SaveAnnotations(): %w", err)
+	}
+	return a, nil
+}
+
+// annotate sets the annotations of subject on the wods they own.
+func (w *WodList) annotate(ctx context.Context, subject string, wods []models.Wod) error {
+	var ids []uuid.UUID
+	for _, wod := range wods {
+		if subject != "" && wod.OwnerSub == subject {
+			ids = append(ids, wod.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	annotations, err := w.annotationRepository.GetAnnotations(ctx, subject, ids)
+	if err != nil {
+		return fmt.Errorf("annotationRepository.GetAnnotations(): %w", err)
+	}
+	for i := range wods {
+		if wods[i].OwnerSub == subject {
+			a := annotations[wods[i].ID]
+			wods[i].Annotations = &a
+		}
+	}
+	return nil
+}
+
+// normalizeLabels lowercases and sorts labels, without duplicates.
+func normalizeLabels(labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l == "" || utf8.RuneCountInString(l) > MaxLabel {
+			return nil, fmt.Errorf("labels are 1 to %d characters", MaxLabel)
+		}
+		out = append(out, l)
+	}
+	slices.Sort(out)
+	out = slices.Compact(out)
+	if len(out) > MaxLabels {
+		return nil, fmt.Errorf("at most %d labels", MaxLabels)
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotate(t *testing.T) {
+	repo := &mockWodRepo{saved: models.Wod{ID: uuid.New(), OwnerSub: "alice"}}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+	favorite, note := true, "  brutal with 20kg sled "
+
+	a, err := list.Annotate(context.Background(), repo.saved.ID, "alice", AnnotationPatch{
+		Favorite: &favorite,
+		Note:     &note,
+		Labels:   &[]string{"Engine", "sled", "engine "},
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.Annotations{Favorite: true, Note: "brutal with 20kg sled", Labels: []string{"engine", "sled"}}, a)
+
+	unfavorite := false
+	a, err = list.Annotate(context.Background(), repo.saved.ID, "alice", AnnotationPatch{Favorite: &unfavorite})
+	require.NoError(t, err)
+	require.Equal(t, models.Annotations{Note: "brutal with 20kg sled", Labels: []string{"engine", "sled"}}, a, "the rest is kept")
+	require.Equal(t, a, repo.annotations[repo.saved.ID])
+}
+
+func TestAnnotate_Errors(t *testing.T) {
+	repo := &mockWodRepo{saved: models.Wod{ID: uuid.New(), OwnerSub: "alice"}}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+
+	_, err := list.Annotate(context.Background(), repo.saved.ID, "root", AnnotationPatch{})
+	require.ErrorIs(t, err, common.ErrWodNotFound, "only the owner annotates")
+
+	long := strings.Repeat("x", MaxNote+1)
+	many := make([]string, MaxLabels+1)
+	for i := range many {
+		many[i] = strings.Repeat("l", i+1)
+	}
+	for name, patch := range map[string]AnnotationPatch{
+		"note":        {Note: &long},
+		"empty label": {Labels: &[]string{" "}},
+		"long label":  {Labels: &[]string{strings.Repeat("l", MaxLabel+1)}},
+		"many labels": {Labels: &many},
+	} {
+		_, err := list.Annotate(context.Background(), repo.saved.ID, "alice", patch)
+		require.ErrorIs(t, err, common.ErrInvalidAnnotation, name)
+	}
+	require.Nil(t, repo.annotations, "nothing saved")
+}
+
+func TestListAndGet_Annotations(t *testing.T) {
+	mine, theirs := models.Wod{ID: uuid.New(), OwnerSub: "alice"}, models.Wod{ID: uuid.New(), OwnerSub: "bob"}
+	repo := &mockWodRepo{saved: mine, wods: []models.Wod{mine, theirs}, annotations: map[uuid.UUID]models.Annotations{
+		theirs.ID: {Favorite: true},
+	}}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+
+	listing, err := list.List(context.Background(), repository.WodFilter{AnyOwner: true, Annotator: "alice"}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, &models.Annotations{}, listing.Wods[0].Annotations, "the owner gets theirs, even empty")
+	require.Nil(t, listing.Wods[1].Annotations, "not on the wods of others")
+
+	wod, err := list.Get(context.Background(), mine.ID, repository.WodFilter{Owner: "alice", Annotator: "alice"})
+	require.NoError(t, err)
+	require.NotNil(t, wod.Annotations)
+
+	_, err = list.List(context.Background(), repository.WodFilter{Owner: "alice", Annotator: "alice", Labels: []string{" Engine", "engine"}}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"engine"}, repo.filter.Labels)
+
+	_, err = list.List(context.Background(), repository.WodFilter{Owner: "alice", Labels: []string{""}}, Page{Limit: 10})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+)
+
+const (
+	// MaxBatch bounds the generations of a batch.
+	MaxBatch = 50
+	// batchWorkers bounds the generations of a batch running at once.
+	batchWorkers = 8
+)
+
+// BatchItem is the outcome of a generation of a batch: the wod, new or
+// Replayed, or Err.
+type BatchItem struct {
+	Wod      models.Wod
+	Replayed bool
+	Err      error
+}
+
+// DeriveSeeds repeats p n times, seeded "<seed>-1" to "<seed>-<n>" from the
+// seed of p, so the batch replays when sent again, or at random without one.
+func DeriveSeeds(p Params, n int) []Params {
+	ps := make([]Params, n)
+	for i := range ps {
+		ps[i] = p
+		ps[i].Equipment = cloneStrings(p.Equipment)
+		if p.Seed != "" {
+			ps[i].Seed = fmt.Sprintf("%s-%d", p.Seed, i+1)
+		}
+	}
+	return ps
+}
+
+// GenerateBatch generates a wod for each of ps concurrently, and stores the
+// new ones in a single transaction. An item failing to generate only fails
+// its BatchItem; failing to store the wods fails the batch.
+func (w *WodGenerator) GenerateBatch(ctx context.Context, ps []Params) ([]BatchItem, error) {
+	if len(ps) == 0 || len(ps) > MaxBatch {
+		return nil, fmt.Errorf("%w: 1 to %d generations", common.ErrInvalidBatch, MaxBatch)
+	}
+
+	drafts := make([]draft, len(ps))
+	items := make([]BatchItem, len(ps))
+	sem := make(chan struct{}, batchWorkers)
+	var wg sync.WaitGroup
+	for i, p := range ps {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			drafts[i], items[i].Err = w.draft(ctx, p)
+		}()
+	}
+	wg.Wait()
+
+	var wods []models.Wod
+	stored := make([]int, 0, len(ps))
+	// the same seeded params twice make the same wod, stored once.
+	seeded := make(map[string]int)
+	same := make(map[int]int)
+	for i, d := range drafts {
+		if items[i].Err != nil {
+			continue
+		}
+		items[i].Wod, items[i].Replayed = d.wod, d.replayed
+		if d.replayed {
+			continue
+		}
+		if d.p.Seed != "" {
+			key := seededKey(d.wod)
+			if j, ok := seeded[key]; ok {
+				same[i] = j
+				continue
+			}
+			seeded[key] = i
+		}
+		wods = append(wods, d.wod)
+		stored = append(stored, i)
+	}
+
+	saved, err := w.wodRepository.SaveWods(ctx, wods)
+	if err != nil {
+		return nil, fmt.Errorf("wodRepository.SaveWods(): %w", err)
+	}
+	for k, i := range stored {
+		items[i].Wod = saved[k]
+	}
+	for i, j := range same {
+		items[i].Wod, items[i].Replayed = items[j].Wod, true
+	}
+	return items, nil
+}
+
+// seededKey is the same for the wods generated from the same seed and params.
+func seededKey(w models.Wod) string {
+	return fmt.Sprintf("%q|%s|%d|%q|%s|%q", w.Seed, w.Level, w.DurationMin, w.Catalog, w.CatalogHash, w.Equipment)
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func batchGenerator(t *testing.T, repo *mockWodRepo) *WodGenerator {
+	t.Helper()
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{Name: "Run", Weight: 1, Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {100, 200}}}},
+	}}
+	return NewWodGenerator(newRegistry(t, hyrox), repo)
+}
+
+func TestDeriveSeeds(t *testing.T) {
+	p := Params{Owner: "alice", Level: "beginner", DurationMin: 30, Equipment: []string{"rower"}, Seed: "week"}
+
+	ps := DeriveSeeds(p, 3)
+
+	require.Len(t, ps, 3)
+	for i, seed := range []string{"week-1", "week-2", "week-3"} {
+		require.Equal(t, seed, ps[i].Seed)
+		require.Equal(t, "alice", ps[i].Owner)
+	}
+	ps[0].Equipment[0] = "sled"
+	require.Equal(t, []string{"rower"}, ps[1].Equipment, "the params do not share their equipment")
+
+	p.Seed = ""
+	for _, d := range DeriveSeeds(p, 2) {
+		require.Empty(t, d.Seed, "unseeded params stay random")
+	}
+}
+
+func TestGenerateBatch(t *testing.T) {
+	repo := &mockWodRepo{wods: []models.Wod{}}
+	gen := batchGenerator(t, repo)
+
+	items, err := gen.GenerateBatch(context.Background(), []Params{
+		{Owner: "alice", Level: "beginner", DurationMin: 30, Seed: "a"},
+		{Owner: "alice", Level: "expert", DurationMin: 30},
+		{Owner: "alice", Level: "beginner", DurationMin: 45},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.NoError(t, items[0].Err)
+	require.Equal(t, "a", items[0].Wod.Seed)
+	require.Equal(t, "alice", items[0].Wod.OwnerSub)
+	require.ErrorAs(t, items[1].Err, &common.InvalidDataError{}, "an invalid item only fails itself")
+	require.NoError(t, items[2].Err)
+	require.Equal(t, 45, items[2].Wod.DurationMin)
+	require.Len(t, repo.batches, 1, "the new wods are stored at once")
+	require.Equal(t, []models.Wod{items[0].Wod, items[2].Wod}, repo.batches[0])
+}
+
+func TestGenerateBatch_Replays(t *testing.T) {
+	gen := batchGenerator(t, &mockWodRepo{wods: []models.Wod{}})
+	p := Params{Owner: "alice", Level: "beginner", DurationMin: 30, Seed: "s1"}
+	first, _, err := gen.Generate(context.Background(), p)
+	require.NoError(t, err)
+
+	repo := &mockWodRepo{wods: []models.Wod{first}}
+	gen = batchGenerator(t, repo)
+	fresh := Params{Owner: "alice", Level: "beginner", DurationMin: 45, Seed: "s2"}
+
+	items, err := gen.GenerateBatch(context.Background(), []Params{p, fresh, fresh})
+
+	require.NoError(t, err)
+	require.True(t, items[0].Replayed)
+	require.Equal(t, first.ID, items[0].Wod.ID)
+	require.False(t, items[1].Replayed)
+	require.True(t, items[2].Replayed, "the same seeded params twice in a batch make one wod")
+	require.Equal(t, items[1].Wod.ID, items[2].Wod.ID)
+	require.Equal(t, [][]models.Wod{{items[1].Wod}}, repo.batches)
+}
+
+func TestGenerateBatch_Invalid(t *testing.T) {
+	repo := &mockWodRepo{}
+	gen := batchGenerator(t, repo)
+
+	_, err := gen.GenerateBatch(context.Background(), nil)
+	require.ErrorIs(t, err, common.ErrInvalidBatch)
+
+	_, err = gen.GenerateBatch(context.Background(), make([]Params, MaxBatch+1))
+	require.ErrorIs(t, err, common.ErrInvalidBatch)
+	require.Empty(t, repo.batches)
+}
+
+func TestGenerateBatch_SaveError(t *testing.T) {
+	repo := &mockWodRepo{wods: []models.Wod{}, err: errors.New("db down")}
+	gen := batchGenerator(t, repo)
+
+	_, err := gen.GenerateBatch(context.Background(), DeriveSeeds(Params{Level: "beginner", DurationMin: 30}, 4))
+
+	require.ErrorContains(t, err, "wodRepository.SaveWods()")
+	require.Len(t, repo.batches[0], 4)
+	for _, w := range repo.batches[0] {
+		require.NotEqual(t, uuid.Nil, w.ID)
+	}
+}
@@ -0,0 +1,326 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxProgram      = 64
+	MaxProgramWods  = 100
+	MaxProgramEvery = 28
+	MaxEntryNote    = 2000
+	// MaxFeedEntries are served in a feed, from FeedPast ago on.
+	MaxFeedEntries = 1000
+	FeedPast       = 90 * 24 * time.Hour
+	// feedTokenBytes of randomness make a feed token.
+	feedTokenBytes = 32
+)
+
+type CalendarInterface interface {
+	// Schedule adds e to the calendar of e.Subject, its wod must be one the
+	// owner of f sees.
+	Schedule(ctx context.Context, e models.CalendarEntry, f repository.WodFilter) (models.CalendarEntry, error)
+	// ScheduleProgram schedules the wods of p, which the owner of f must see.
+	ScheduleProgram(ctx context.Context, p Program, f repository.WodFilter) ([]models.CalendarEntry, error)
+	Get(ctx context.Context, subject string, id uuid.UUID) (models.CalendarEntry, error)
+	List(ctx context.Context, f repository.CalendarFilter, limit, offset int) ([]models.CalendarEntry, error)
+	// Update applies patch to the entry id of subject, a new wod must be one
+	// the owner of f sees.
+	Update(ctx context.Context, subject string, id uuid.UUID, patch EntryPatch, f repository.WodFilter) (models.CalendarEntry, error)
+	Delete(ctx context.Context, subject string, id uuid.UUID) error
+	// DeleteProgram unschedules the entries of the program of subject and
+	// returns how many there were.
+	DeleteProgram(ctx context.Context, subject, program string) (int, error)
+
+	// RotateFeed returns a new token reading the feed of subject, the
+	// previous one stops working.
+	RotateFeed(ctx context.Context, subject string) (string, error)
+	RevokeFeed(ctx context.Context, subject string) error
+	// Feed returns the calendar read with token.
+	Feed(ctx context.Context, token string) (Feed, error)
+}
+
+// Program schedules WodIDs in order from Start, one every EveryDays days
+// (every day when zero), under Name.
+type Program struct {
+	Subject   string
+	Name      string
+	Start     time.Time
+	EveryDays int
+	WodIDs    []uuid.UUID
+	Note      string
+}
+
+// EntryPatch changes the fields set on an entry.
+type EntryPatch struct {
+	WodID   *uuid.UUID
+	Date    *time.Time
+	Program *string
+	Note    *string
+}
+
+// Feed is a calendar served to subscribers: its entries from FeedPast ago on
+// and the wods they schedule.
+type Feed struct {
+	Subject string
+	Entries []models.CalendarEntry
+	Wods    map[uuid.UUID]models.Wod
+}
+
+type Calendar struct {
+	wodRepository      repository.WodRepositoryInterface
+	calendarRepository repository.CalendarRepositoryInterface
+}
+
+func NewCalendar(wodRepository repository.WodRepositoryInterface, calendarRepository repository.CalendarRepositoryInterface) *Calendar {
+	return &Calendar{wodRepository: wodRepository, calendarRepository: calendarRepository}
+}
+
+func (c *Calendar) Schedule(ctx context.Context, e models.CalendarEntry, f repository.WodFilter) (models.CalendarEntry, error) {
+	if _, err := getWod(ctx, c.wodRepository, e.WodID, f); err != nil {
+		return models.CalendarEntry{}, err
+	}
+
+	now := time.Now().UTC()
+	e.ID = uuid.New()
+	e.CreatedAt, e.UpdatedAt = now, now
+	e = normalizeEntry(e)
+	if err := validateEntry(e); err != nil {
+		return models.CalendarEntry{}, err
+	}
+	if err := c.calendarRepository.SaveEntries(ctx, []models.CalendarEntry{e}); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("calendarRepository.SaveEntries(): %w", err)
+	}
+	return e, nil
+}
+
+func (c *Calendar) ScheduleProgram(ctx context.Context, p Program, f repository.WodFilter) ([]models.CalendarEntry, error) {
+	if p.EveryDays == 0 {
+		p.EveryDays = 1
+	}
+	switch {
+	case strings.TrimSpace(p.Name) == "":
+		return nil, fmt.Errorf("%w: a program needs a name", common.ErrInvalidCalendarEntry)
+	case len(p.WodIDs) == 0 || len(p.WodIDs) > MaxProgramWods:
+		return nil, fmt.Errorf("%w: a program schedules 1 to %d wods", common.ErrInvalidCalendarEntry, MaxProgramWods)
+	case p.EveryDays < 1 || p.EveryDays > MaxProgramEvery:
+		return nil, fmt.Errorf("%w: every_days must be between 1 and %d", common.ErrInvalidCalendarEntry, MaxProgramEvery)
+	}
+
+	// the wods may repeat, every one is checked once.
+	f.IDs = p.WodIDs
+	wods, err := c.wodRepository.ListWods(ctx, f, repository.WodPage{Limit: len(p.WodIDs)})
+	if err != nil {
+		return nil, fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	seen := make(map[uuid.UUID]bool, len(wods))
+	for _, w := range wods {
+		seen[w.ID] = true
+	}
+
+	now := time.Now().UTC()
+	entries := make([]models.CalendarEntry, len(p.WodIDs))
+	for i, id := range p.WodIDs {
+		if !seen[id] {
+			return nil, fmt.Errorf("%w: %s", common.ErrWodNotFound, id)
+		}
+		entries[i] = normalizeEntry(models.CalendarEntry{
+			ID:        uuid.New(),
+			Subject:   p.Subject,
+			WodID:     id,
+			Date:      p.Start.AddDate(0, 0, i*p.EveryDays),
+			Program:   p.Name,
+			Note:      p.Note,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err := validateEntry(entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.calendarRepository.SaveEntries(ctx, entries); err != nil {
+		return nil, fmt.Errorf("calendarRepository.SaveEntries(): %w", err)
+	}
+	return entries, nil
+}
+
+func (c *Calendar) Get(ctx context.Context, subject string, id uuid.UUID) (models.CalendarEntry, error) {
+	entries, err := c.calendarRepository.ListEntries(ctx, repository.CalendarFilter{Subject: subject, ID: id}, 1, 0)
+	if err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("calendarRepository.ListEntries(): %w", err)
+	}
+	if len(entries) == 0 {
+		return models.CalendarEntry{}, common.ErrCalendarEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (c *Calendar) List(ctx context.Context, f repository.CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return nil, fmt.Errorf("%w: from must be before to", common.ErrInvalidCalendarEntry)
+	}
+	f.From, f.To = day(f.From), day(f.To)
+	entries, err := c.calendarRepository.ListEntries(ctx, f, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("calendarRepository.ListEntries(): %w", err)
+	}
+	return entries, nil
+}
+
+func (c *Calendar) Update(ctx context.Context, subject string, id uuid.UUID, patch EntryPatch, f repository.WodFilter) (models.CalendarEntry, error) {
+	e, err := c.Get(ctx, subject, id)
+	if err != nil {
+		return models.CalendarEntry{}, err
+	}
+	if patch.WodID != nil && *patch.WodID != e.WodID {
+		if _, err := getWod(ctx, c.wodRepository, *patch.WodID, f); err != nil {
+			return models.CalendarEntry{}, err
+		}
+		e.WodID = *patch.WodID
+	}
+	if patch.Date != nil {
+		e.Date = *patch.Date
+	}
+	if patch.Program != nil {
+		e.Program = *patch.Program
+	}
+	if patch.Note != nil {
+		e.Note = *patch.Note
+	}
+	e.UpdatedAt = time.Now().UTC()
+	e = normalizeEntry(e)
+	if err := validateEntry(e); err != nil {
+		return models.CalendarEntry{}, err
+	}
+
+	if err := c.calendarRepository.UpdateEntry(ctx, e); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("calendarRepository.UpdateEntry(): %w", err)
+	}
+	return e, nil
+}
+
+func (c *Calendar) Delete(ctx context.Context, subject string, id uuid.UUID) error {
+	n, err := c.calendarRepository.DeleteEntries(ctx, repository.CalendarFilter{Subject: subject, ID: id})
+	if err != nil {
+		return fmt.Errorf("calendarRepository.DeleteEntries(): %w", err)
+	}
+	if n == 0 {
+		return common.ErrCalendarEntryNotFound
+	}
+	return nil
+}
+
+func (c *Calendar) DeleteProgram(ctx context.Context, subject, program string) (int, error) {
+	program = strings.TrimSpace(program)
+	if program == "" {
+		return 0, common.ErrCalendarEntryNotFound
+	}
+	n, err := c.calendarRepository.DeleteEntries(ctx, repository.CalendarFilter{Subject: subject, Program: program})
+	if err != nil {
+		return 0, fmt.Errorf("calendarRepository.DeleteEntries(): %w", err)
+	}
+	if n == 0 {
+		return 0, common.ErrCalendarEntryNotFound
+	}
+	return n, nil
+}
+
+func (c *Calendar) RotateFeed(ctx context.Context, subject string) (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := c.calendarRepository.SaveFeed(ctx, subject, hashFeedToken(token), time.Now().UTC()); err != nil {
+		return "", fmt.Errorf("calendarRepository.SaveFeed(): %w", err)
+	}
+	return token, nil
+}
+
+func (c *Calendar) RevokeFeed(ctx context.Context, subject string) error {
+	if err := c.calendarRepository.DeleteFeed(ctx, subject); err != nil {
+		return fmt.Errorf("calendarRepository.DeleteFeed(): %w", err)
+	}
+	return nil
+}
+
+func (c *Calendar) Feed(ctx context.Context, token string) (Feed, error) {
+	if token == "" {
+		return Feed{}, common.ErrCalendarFeedNotFound
+	}
+	subject, err := c.calendarRepository.FeedSubject(ctx, hashFeedToken(token))
+	if err != nil {
+		return Feed{}, fmt.Errorf("calendarRepository.FeedSubject(): %w", err)
+	}
+
+	f := repository.CalendarFilter{Subject: subject, From: day(time.Now().UTC().Add(-FeedPast))}
+	entries, err := c.calendarRepository.ListEntries(ctx, f, MaxFeedEntries, 0)
+	if err != nil {
+		return Feed{}, fmt.Errorf("calendarRepository.ListEntries(): %w", err)
+	}
+
+	feed := Feed{Subject: subject, Entries: entries, Wods: make(map[uuid.UUID]models.Wod)}
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.WodID)
+	}
+	if len(ids) == 0 {
+		return feed, nil
+	}
+	// the wods stay in the feed even when admins scheduled another owner's.
+	wods, err := c.wodRepository.ListWods(ctx, repository.WodFilter{AnyOwner: true, IDs: ids}, repository.WodPage{Limit: len(ids)})
+	if err != nil {
+		return Feed{}, fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	for _, w := range wods {
+		feed.Wods[w.ID] = w
+	}
+	return feed, nil
+}
+
+// normalizeEntry trims the texts of e and truncates its date to the day.
+func normalizeEntry(e models.CalendarEntry) models.CalendarEntry {
+	e.Date = day(e.Date)
+	e.Program = strings.TrimSpace(e.Program)
+	e.Note = strings.TrimSpace(e.Note)
+	return e
+}
+
+func validateEntry(e models.CalendarEntry) error {
+	switch {
+	case e.Date.IsZero():
+		return fmt.Errorf("%w: date is required", common.ErrInvalidCalendarEntry)
+	case utf8.RuneCountInString(e.Program) > MaxProgram:
+		return fmt.Errorf("%w: program is limited to %d characters", common.ErrInvalidCalendarEntry, MaxProgram)
+	case utf8.RuneCountInString(e.Note) > MaxEntryNote:
+		return fmt.Errorf("%w: note is limited to %d characters", common.ErrInvalidCalendarEntry, MaxEntryNote)
+	}
+	return nil
+}
+
+// day truncates t to midnight UTC of its date, the zero time stays zero.
+func day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,229 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCalendarRepo struct {
+	entries []models.CalendarEntry
+	filter  repository.CalendarFilter
+	deleted int
+	feeds   map[string]string
+}
+
+func (m *mockCalendarRepo) SaveEntries(ctx context.Context, entries []models.CalendarEntry) error {
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *mockCalendarRepo) UpdateEntry(ctx context.Context, e models.CalendarEntry) error {
+	for i := range m.entries {
+		if m.entries[i].ID == e.ID {
+			m.entries[i] = e
+			return nil
+		}
+	}
+	return common.ErrCalendarEntryNotFound
+}
+
+func (m *mockCalendarRepo) DeleteEntries(ctx context.Context, f repository.CalendarFilter) (int, error) {
+	m.filter = f
+	return m.deleted, nil
+}
+
+func (m *mockCalendarRepo) ListEntries(ctx context.Context, f repository.CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	m.filter = f
+	var out []models.CalendarEntry
+	for _, e := range m.entries {
+		if e.Subject == f.Subject && (f.ID == uuid.Nil || e.ID == f.ID) {
+			out = append(out, e)
+		}
+	}
+	return out[:min(limit, len(out))], nil
+}
+
+func (m *mockCalendarRepo) SaveFeed(ctx context.Context, subject, tokenHash string, createdAt time.Time) error {
+	if m.feeds == nil {
+		m.feeds = map[string]string{}
+	}
+	m.feeds[tokenHash] = subject
+	return nil
+}
+
+func (m *mockCalendarRepo) DeleteFeed(ctx context.Context, subject string) error {
+	for h, s := range m.feeds {
+		if s == subject {
+			delete(m.feeds, h)
+		}
+	}
+	return nil
+}
+
+func (m *mockCalendarRepo) FeedSubject(ctx context.Context, tokenHash string) (string, error) {
+	if s, ok := m.feeds[tokenHash]; ok {
+		return s, nil
+	}
+	return "", common.ErrCalendarFeedNotFound
+}
+
+func TestSchedule(t *testing.T) {
+	wod := models.Wod{ID: uuid.New(), OwnerSub: "alice"}
+	repo := &mockCalendarRepo{}
+	calendar := NewCalendar(&mockWodRepo{saved: wod}, repo)
+
+	e, err := calendar.Schedule(context.Background(), models.CalendarEntry{
+		Subject: "alice",
+		WodID:   wod.ID,
+		Date:    time.Date(2025, 9, 1, 18, 30, 0, 0, time.UTC),
+		Program: " block-1 ",
+		Note:    " easy ",
+	}, repository.WodFilter{Owner: "alice"})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, e.ID)
+	require.Equal(t, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), e.Date)
+	require.Equal(t, "block-1", e.Program)
+	require.Equal(t, "easy", e.Note)
+	require.Equal(t, []models.CalendarEntry{e}, repo.entries)
+
+	_, err = calendar.Schedule(context.Background(), models.CalendarEntry{Subject: "bob", WodID: wod.ID, Date: e.Date},
+		repository.WodFilter{Owner: "bob"})
+	require.ErrorIs(t, err, common.ErrWodNotFound, "only the wods the caller sees")
+
+	_, err = calendar.Schedule(context.Background(), models.CalendarEntry{Subject: "alice", WodID: wod.ID},
+		repository.WodFilter{Owner: "alice"})
+	require.ErrorIs(t, err, common.ErrInvalidCalendarEntry, "date required")
+
+	_, err = calendar.Schedule(context.Background(), models.CalendarEntry{Subject: "alice", WodID: wod.ID, Date: e.Date,
+		Note: strings.Repeat("x", MaxEntryNote+1)}, repository.WodFilter{Owner: "alice"})
+	require.ErrorIs(t, err, common.ErrInvalidCalendarEntry)
+}
+
+func TestScheduleProgram(t *testing.T) {
+	a, b := models.Wod{ID: uuid.New(), OwnerSub: "alice"}, models.Wod{ID: uuid.New(), OwnerSub: "alice"}
+	wods := &mockWodRepo{wods: []models.Wod{a, b}}
+	repo := &mockCalendarRepo{}
+	calendar := NewCalendar(wods, repo)
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	entries, err := calendar.ScheduleProgram(context.Background(), Program{
+		Subject: "alice", Name: "block-1", Start: start, EveryDays: 2, WodIDs: []uuid.UUID{a.ID, b.ID, a.ID},
+	}, repository.WodFilter{Owner: "alice"})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		require.Equal(t, start.AddDate(0, 0, 2*i), e.Date)
+		require.Equal(t, "block-1", e.Program)
+	}
+	require.Equal(t, a.ID, entries[2].WodID)
+	require.Equal(t, []uuid.UUID{a.ID, b.ID, a.ID}, wods.filter.IDs)
+	require.Equal(t, "alice", wods.filter.Owner)
+
+	_, err = calendar.ScheduleProgram(context.Background(), Program{
+		Subject: "alice", Name: "block-2", Start: start, WodIDs: []uuid.UUID{a.ID, uuid.New()},
+	}, repository.WodFilter{Owner: "alice"})
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+	require.Len(t, repo.entries, 3, "nothing scheduled")
+
+	for name, p := range map[string]Program{
+		"name":  {Start: start, WodIDs: []uuid.UUID{a.ID}},
+		"wods":  {Name: "x", Start: start},
+		"every": {Name: "x", Start: start, EveryDays: MaxProgramEvery + 1, WodIDs: []uuid.UUID{a.ID}},
+		"start": {Name: "x", WodIDs: []uuid.UUID{a.ID}},
+	} {
+		_, err := calendar.ScheduleProgram(context.Background(), p, repository.WodFilter{Owner: "alice"})
+		require.ErrorIs(t, err, common.ErrInvalidCalendarEntry, name)
+	}
+}
+
+func TestCalendarUpdate(t *testing.T) {
+	wod, other := models.Wod{ID: uuid.New(), OwnerSub: "alice"}, uuid.New()
+	e := models.CalendarEntry{ID: uuid.New(), Subject: "alice", WodID: wod.ID, Date: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Program: "block-1", Note: "easy"}
+	repo := &mockCalendarRepo{entries: []models.CalendarEntry{e}}
+	calendar := NewCalendar(&mockWodRepo{saved: wod}, repo)
+
+	moved, empty := time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC), ""
+	got, err := calendar.Update(context.Background(), "alice", e.ID, EntryPatch{Date: &moved, Program: &empty},
+		repository.WodFilter{Owner: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, moved, got.Date)
+	require.Empty(t, got.Program)
+	require.Equal(t, "easy", got.Note, "the rest is kept")
+	require.Equal(t, got, repo.entries[0])
+
+	_, err = calendar.Update(context.Background(), "alice", e.ID, EntryPatch{WodID: &other}, repository.WodFilter{Owner: "alice"})
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+
+	_, err = calendar.Update(context.Background(), "bob", e.ID, EntryPatch{}, repository.WodFilter{Owner: "bob"})
+	require.ErrorIs(t, err, common.ErrCalendarEntryNotFound, "entries are per subject")
+}
+
+func TestCalendarDelete(t *testing.T) {
+	repo := &mockCalendarRepo{}
+	calendar := NewCalendar(&mockWodRepo{}, repo)
+	id := uuid.New()
+
+	require.ErrorIs(t, calendar.Delete(context.Background(), "alice", id), common.ErrCalendarEntryNotFound)
+	require.Equal(t, repository.CalendarFilter{Subject: "alice", ID: id}, repo.filter)
+
+	repo.deleted = 3
+	n, err := calendar.DeleteProgram(context.Background(), "alice", " block-1 ")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, repository.CalendarFilter{Subject: "alice", Program: "block-1"}, repo.filter)
+}
+
+func TestCalendarList(t *testing.T) {
+	repo := &mockCalendarRepo{}
+	calendar := NewCalendar(&mockWodRepo{}, repo)
+	from := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := calendar.List(context.Background(), repository.CalendarFilter{Subject: "alice", From: from, To: from.AddDate(0, 1, 0)}, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), repo.filter.From)
+
+	_, err = calendar.List(context.Background(), repository.CalendarFilter{Subject: "alice", From: from, To: from}, 20, 0)
+	require.ErrorIs(t, err, common.ErrInvalidCalendarEntry)
+}
+
+func TestCalendarFeed(t *testing.T) {
+	wod := models.Wod{ID: uuid.New(), OwnerSub: "alice"}
+	soon := models.CalendarEntry{ID: uuid.New(), Subject: "alice", WodID: wod.ID, Date: day(time.Now().AddDate(0, 0, 1))}
+	repo := &mockCalendarRepo{entries: []models.CalendarEntry{soon}}
+	wods := &mockWodRepo{wods: []models.Wod{wod}}
+	calendar := NewCalendar(wods, repo)
+
+	token, err := calendar.RotateFeed(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Len(t, repo.feeds, 1)
+	for h := range repo.feeds {
+		require.NotEqual(t, token, h, "only the hash is stored")
+	}
+
+	feed, err := calendar.Feed(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, "alice", feed.Subject)
+	require.Equal(t, []models.CalendarEntry{soon}, feed.Entries)
+	require.Equal(t, map[uuid.UUID]models.Wod{wod.ID: wod}, feed.Wods)
+	require.Equal(t, day(time.Now().UTC().Add(-FeedPast)), repo.filter.From)
+	require.True(t, wods.filter.AnyOwner)
+
+	again, err := calendar.RotateFeed(context.Background(), "alice")
+	require.NoError(t, err)
+	require.NotEqual(t, token, again)
+
+	require.NoError(t, calendar.RevokeFeed(context.Background(), "alice"))
+	_, err = calendar.Feed(context.Background(), again)
+	require.ErrorIs(t, err, common.ErrCalendarFeedNotFound)
+	_, err = calendar.Feed(context.Background(), "")
+	require.ErrorIs(t, err, common.ErrCalendarFeedNotFound)
+}
@@ -1,26 +1,169 @@
 package catalog
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"gopkg.in/yaml.v3"
 )
 
+// Levels a catalog can offer, a catalog without a levels list offers them all.
+const (
+	LevelBeginner     = "beginner"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+)
+
 type Rng [2]int
 
+// Movement patterns a move can declare.
+const (
+	PatternSquat      = "squat"
+	PatternHinge      = "hinge"
+	PatternLunge      = "lunge"
+	PatternPush       = "push"
+	PatternPull       = "pull"
+	PatternCarry      = "carry"
+	PatternCore       = "core"
+	PatternJump       = "jump"
+	PatternLocomotion = "locomotion"
+	PatternFullBody   = "full-body"
+)
+
+// Media types a move can reference.
+const (
+	MediaImage = "image"
+	MediaVideo = "video"
+	MediaGIF   = "gif"
+)
+
 type Move struct {
-	Name       string                    `yaml:"name"`
-	NeedsOneOf []string                  `yaml:"needs_one_of"`
-	Tags       []string                  `yaml:"tags"`
-	Weight     float64                   `yaml:"weight"`
-	Ranges     map[string]map[string]Rng `yaml:"ranges"` // level -> param -> [min,max]
+	// ID is the stable identifier of the move, a slug of its name by default.
+	ID          string                    `yaml:"id,omitempty"`
+	Name        string                    `yaml:"name"`
+	Description string                    `yaml:"description,omitempty"`
+	Cues        []string                  `yaml:"cues,omitempty"`    // coaching cues
+	Muscles     []string                  `yaml:"muscles,omitempty"` // primary muscle groups
+	Pattern     string                    `yaml:"pattern,omitempty"`
+	Units       map[string]string         `yaml:"units,omitempty"` // param -> unit, e.g. meters: m
+	Media       []Media                   `yaml:"media,omitempty"`
+	Locales     map[string]Translation    `yaml:"locales,omitempty"` // locale -> display texts
+	NeedsOneOf  []string                  `yaml:"needs_one_of,omitempty"`
+	Tags        []string                  `yaml:"tags,omitempty"`
+	Weight      float64                   `yaml:"weight,omitempty"`
+	Ranges      map[string]map[string]Rng `yaml:"ranges,omitempty"` // level -> param -> [min,max]
+}
+
+// Translation overrides the display texts of a move in one locale, empty
+// fields fall back to the canonical ones.
+type Translation struct {
+	Name        string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Cues        []string `yaml:"cues,omitempty" json:"cues,omitempty"`
+}
+
+// Media references a demo of the move hosted elsewhere.
+type Media struct {
+	Type  string `yaml:"type" json:"type"`
+	URL   string `yaml:"url" json:"url"`
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+}
+
+// Patterns lists the movement patterns a move can declare.
+func Patterns() []string {
+	return []string{
+		PatternSquat, PatternHinge, PatternLunge, PatternPush, PatternPull,
+		PatternCarry, PatternCore, PatternJump, PatternLocomotion, PatternFullBody,
+	}
+}
+
+// Key is the ID of the move, derived from its name when not set.
+func (m Move) Key() string {
+	if m.ID != "" {
+		return m.ID
+	}
+	return Slug(m.Name)
+}
+
+// DisplayName is the name of the move in locale.
+func (m Move) DisplayName(locale string) string {
+	if t, ok := m.Locales[locale]; ok && t.Name != "" {
+		return t.Name
+	}
+	return m.Name
+}
+
+// DisplayDescription is the description of the move in locale.
+func (m Move) DisplayDescription(locale string) string {
+	if t, ok := m.Locales[locale]; ok && t.Description != "" {
+		return t.Description
+	}
+	return m.Description
+}
+
+// DisplayCues are the coaching cues of the move in locale.
+func (m Move) DisplayCues(locale string) []string {
+	if t, ok := m.Locales[locale]; ok && len(t.Cues) > 0 {
+		return t.Cues
+	}
+	return m.Cues
+}
+
+// Slug lowercases s and replaces every run of other characters than letters
+// and digits with a dash: "Sled Push" becomes "sled-push".
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
 }
 
 type Catalog struct {
-	Moves []Move `yaml:"moves"`
+	// Name selects the catalog in requests, see Registry.
+	Name string `yaml:"name"`
+	// Levels offered, all of them when empty.
+	Levels []string `yaml:"levels,omitempty"`
+	// Equipment known by the catalog on top of what its moves need.
+	Equipment []string `yaml:"equipment,omitempty"`
+	Moves     []Move   `yaml:"moves,omitempty"`
+	// Version of the snapshot, declared in YAML and bumped on every write when
+	// served from the database.
+	Version int64 `yaml:"version,omitempty"`
+
+	// hash caches Hash once the catalog is served, see Store.
+	hash string
 }
 
 func NewCatalog(raw []byte) (*Catalog, error) {
+	c, err := parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parse(raw []byte) (*Catalog, error) {
 	var c Catalog
 	if err := yaml.Unmarshal(raw, &c); err != nil {
 		return nil, fmt.Errorf("parse catalog: %w", err)
@@ -29,7 +172,241 @@ func NewCatalog(raw []byte) (*Catalog, error) {
 		if c.Moves[i].Weight == 0 {
 			c.Moves[i].Weight = 1.0
 		}
+		c.Moves[i].ID = c.Moves[i].Key()
+	}
+
+	c.Name = strings.ToLower(strings.TrimSpace(c.Name))
+	c.Levels = lower(c.Levels)
+	c.Equipment = lower(c.Equipment)
+
+	return &c, nil
+}
+
+// Validate rejects catalogs the generator cannot safely pick from.
+func (c *Catalog) Validate() error {
+	if len(c.Moves) == 0 {
+		return common.ErrEmptyCatalog
+	}
+	for _, l := range c.Levels {
+		if l != LevelBeginner && l != LevelIntermediate && l != LevelAdvanced {
+			return fmt.Errorf("%w: unknown level %q", common.ErrInvalidCatalog, l)
+		}
 	}
 
-	return &Catalog{Moves: c.Moves}, nil
+	seen := make(map[string]struct{}, len(c.Moves))
+	ids := make(map[string]struct{}, len(c.Moves))
+	for _, m := range c.Moves {
+		name := strings.ToLower(strings.TrimSpace(m.Name))
+		if name == "" {
+			return fmt.Errorf("%w: move without name", common.ErrInvalidCatalog)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%w: duplicate move %q", common.ErrInvalidCatalog, m.Name)
+		}
+		seen[name] = struct{}{}
+		if _, ok := ids[m.Key()]; ok {
+			return fmt.Errorf("%w: duplicate move id %q", common.ErrInvalidCatalog, m.Key())
+		}
+		ids[m.Key()] = struct{}{}
+
+		if m.Weight < 0 {
+			return fmt.Errorf("%w: negative weight for %q", common.ErrInvalidCatalog, m.Name)
+		}
+		if err := validateMetadata(m); err != nil {
+			return err
+		}
+		if len(c.Equipment) > 0 {
+			for _, eq := range m.NeedsOneOf {
+				if !containsFold(c.Equipment, eq) {
+					return fmt.Errorf("%w: %q needs undeclared equipment %q", common.ErrInvalidCatalog, m.Name, eq)
+				}
+			}
+		}
+		for level, params := range m.Ranges {
+			if !c.HasLevel(level) {
+				return fmt.Errorf("%w: %q has ranges for level %q not offered by the catalog",
+					common.ErrInvalidCatalog, m.Name, level)
+			}
+			for param, r := range params {
+				if r[0] < 0 || r[1] < r[0] {
+					return fmt.Errorf("%w: bad range %v for %q %s.%s",
+						common.ErrInvalidCatalog, r, m.Name, level, param)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMetadata checks the descriptive fields of m, the generator ignores
+// them but clients rely on them.
+func validateMetadata(m Move) error {
+	if m.Pattern != "" && !containsFold(Patterns(), m.Pattern) {
+		return fmt.Errorf("%w: unknown pattern %q for %q", common.ErrInvalidCatalog, m.Pattern, m.Name)
+	}
+	for param := range m.Units {
+		if !hasParam(m, param) {
+			return fmt.Errorf("%w: unit for %q which has no %s range", common.ErrInvalidCatalog, m.Name, param)
+		}
+	}
+	for _, md := range m.Media {
+		if md.Type != MediaImage && md.Type != MediaVideo && md.Type != MediaGIF {
+			return fmt.Errorf("%w: unknown media type %q for %q", common.ErrInvalidCatalog, md.Type, m.Name)
+		}
+		u, err := url.Parse(md.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: media of %q needs an absolute http(s) URL", common.ErrInvalidCatalog, m.Name)
+		}
+	}
+	return nil
+}
+
+func hasParam(m Move, param string) bool {
+	for _, params := range m.Ranges {
+		if _, ok := params[param]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// LevelNames lists the levels offered by the catalog.
+func (c *Catalog) LevelNames() []string {
+	if len(c.Levels) == 0 {
+		return []string{LevelBeginner, LevelIntermediate, LevelAdvanced}
+	}
+	out := make([]string, len(c.Levels))
+	copy(out, c.Levels)
+	return out
+}
+
+func (c *Catalog) HasLevel(level string) bool {
+	return containsFold(c.LevelNames(), level)
+}
+
+// Hash is the sha256 of the catalog content: levels, equipment and moves. It
+// leaves out the name and version, so the same catalog served from a file or
+// the database hashes the same.
+func (c *Catalog) Hash() string {
+	if c.hash != "" {
+		return c.hash
+	}
+	return c.computeHash()
+}
+
+func (c *Catalog) computeHash() string {
+	moves := make([]Move, len(c.Moves))
+	for i, m := range c.Moves {
+		moves[i] = m.normalized()
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Name < moves[j].Name })
+
+	equipment := lower(c.Equipment)
+	sort.Strings(equipment)
+
+	// maps are marshaled with sorted keys, the output is stable.
+	raw, err := json.Marshal(struct {
+		Levels    []string `json:"levels"`
+		Equipment []string `json:"equipment"`
+		Moves     []Move   `json:"moves"`
+	}{c.LevelNames(), equipment, moves})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalized fills the nil slices and maps of m: the database hands back
+// empty ones where YAML leaves nil, both must hash the same.
+func (m Move) normalized() Move {
+	m.ID = m.Key()
+	if m.Cues == nil {
+		m.Cues = []string{}
+	}
+	if m.Muscles == nil {
+		m.Muscles = []string{}
+	}
+	if m.Units == nil {
+		m.Units = map[string]string{}
+	}
+	if m.Media == nil {
+		m.Media = []Media{}
+	}
+	if m.Locales == nil {
+		m.Locales = map[string]Translation{}
+	}
+	if m.NeedsOneOf == nil {
+		m.NeedsOneOf = []string{}
+	}
+	if m.Tags == nil {
+		m.Tags = []string{}
+	}
+	if m.Ranges == nil {
+		m.Ranges = map[string]map[string]Rng{}
+	}
+	return m
+}
+
+// Lookup finds a move by ID, or by name for blocks stored before moves had one.
+func (c *Catalog) Lookup(idOrName string) (Move, bool) {
+	for _, m := range c.Moves {
+		if m.Key() == idOrName {
+			return m, true
+		}
+	}
+	for _, m := range c.Moves {
+		if strings.EqualFold(m.Name, idOrName) {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// Tags lists the distinct tags used by the moves, sorted.
+func (c *Catalog) Tags() []string {
+	return distinct(c.Moves, func(m Move) []string { return m.Tags })
+}
+
+// AllEquipment lists the distinct equipment declared by the catalog or needed
+// by its moves, sorted.
+func (c *Catalog) AllEquipment() []string {
+	declared := Move{NeedsOneOf: c.Equipment}
+	return distinct(append([]Move{declared}, c.Moves...), func(m Move) []string { return m.NeedsOneOf })
+}
+
+func distinct(moves []Move, values func(Move) []string) []string {
+	set := map[string]struct{}{}
+	for _, m := range moves {
+		for _, v := range values(m) {
+			set[strings.ToLower(v)] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for v := range set {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func lower(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	out := make([]string, len(list))
+	for i, v := range list {
+		out[i] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
 }
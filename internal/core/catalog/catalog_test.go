@@ -0,0 +1,424 @@
+package catalog_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/stretchr/testify/require"
+)
+
+const rowFragment = `
+moves:
+  - name: Row
+    needs_one_of: ["rower"]
+    ranges:
+      beginner: { meters: [400, 900] }
+`
+
+const runFragment = `
+moves:
+  - name: Run
+    weight: 2
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestLoad_EmbeddedByDefault(t *testing.T) {
+	catalogs, err := catalog.Load("", "hyrox")
+	require.NoError(t, err)
+	require.Contains(t, catalogs, "hyrox")
+	require.Contains(t, catalogs, "crossfit")
+	require.Contains(t, catalogs, "running")
+	require.NotEmpty(t, catalogs["hyrox"].Moves)
+	require.Equal(t, []string{"beginner", "intermediate"}, catalogs["running"].LevelNames())
+}
+
+func TestLoad_File(t *testing.T) {
+	p := writeFile(t, t.TempDir(), "catalog.yml", rowFragment)
+
+	catalogs, err := catalog.Load(p, "hyrox")
+	require.NoError(t, err)
+	c := catalogs["hyrox"]
+	require.Len(t, c.Moves, 1)
+	require.Equal(t, "hyrox", c.Name, "unnamed fragments belong to the default catalog")
+	require.Equal(t, "Row", c.Moves[0].Name)
+	require.InDelta(t, 1.0, c.Moves[0].Weight, 0, "weight defaults to 1")
+}
+
+func TestLoad_DirectoryMergesFragments(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "20-run.yaml", runFragment)
+	writeFile(t, dir, "10-row.yml", rowFragment)
+	writeFile(t, dir, "README.md", "not a fragment")
+
+	catalogs, err := catalog.Load(dir, "hyrox")
+	require.NoError(t, err)
+	c := catalogs["hyrox"]
+	require.Len(t, c.Moves, 2)
+	require.Equal(t, "Row", c.Moves[0].Name)
+	require.Equal(t, "Run", c.Moves[1].Name)
+}
+
+func TestLoad_NamedCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "hyrox.yml", rowFragment)
+	writeFile(t, dir, "running.yml", "name: Running\nlevels: [beginner]\n"+runFragment)
+	writeFile(t, dir, "running-extra.yml", "name: running\nmoves:\n  - name: Strides\n")
+
+	catalogs, err := catalog.Load(dir, "hyrox")
+	require.NoError(t, err)
+	require.Len(t, catalogs, 2)
+	require.Len(t, catalogs["hyrox"].Moves, 1)
+	require.Len(t, catalogs["running"].Moves, 2)
+	require.True(t, catalogs["running"].HasLevel("beginner"))
+	require.False(t, catalogs["running"].HasLevel("advanced"))
+}
+
+func TestLoad_DuplicateAcrossFragments(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yml", runFragment)
+	writeFile(t, dir, "b.yml", runFragment)
+
+	_, err := catalog.Load(dir, "hyrox")
+	require.ErrorIs(t, err, common.ErrInvalidCatalog)
+}
+
+func TestValidate(t *testing.T) {
+	require.ErrorIs(t, (&catalog.Catalog{}).Validate(), common.ErrEmptyCatalog)
+
+	bad := &catalog.Catalog{Moves: []catalog.Move{{
+		Name:   "Row",
+		Weight: 1,
+		Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {900, 400}}},
+	}}}
+	require.ErrorIs(t, bad.Validate(), common.ErrInvalidCatalog)
+
+	notOffered := &catalog.Catalog{Levels: []string{"beginner"}, Moves: []catalog.Move{{
+		Name:   "Row",
+		Weight: 1,
+		Ranges: map[string]map[string]catalog.Rng{"advanced": {"meters": {400, 900}}},
+	}}}
+	require.ErrorIs(t, notOffered.Validate(), common.ErrInvalidCatalog)
+
+	undeclared := &catalog.Catalog{Equipment: []string{"rower"}, Moves: []catalog.Move{{
+		Name: "Sled Push", Weight: 1, NeedsOneOf: []string{"sled"},
+	}}}
+	require.ErrorIs(t, undeclared.Validate(), common.ErrInvalidCatalog)
+}
+
+func TestValidate_Metadata(t *testing.T) {
+	valid := catalog.Move{
+		Name: "Row", Weight: 1, Pattern: catalog.PatternFullBody,
+		Units:  map[string]string{"meters": "m"},
+		Media:  []catalog.Media{{Type: catalog.MediaVideo, URL: "https://example.com/row.mp4"}},
+		Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {400, 900}}},
+	}
+	require.NoError(t, (&catalog.Catalog{Moves: []catalog.Move{valid}}).Validate())
+
+	for name, edit := range map[string]func(m *catalog.Move){
+		"unknown pattern": func(m *catalog.Move) { m.Pattern = "twist" },
+		"unit no range":   func(m *catalog.Move) { m.Units = map[string]string{"reps": "reps"} },
+		"media type":      func(m *catalog.Move) { m.Media[0].Type = "audio" },
+		"relative url":    func(m *catalog.Move) { m.Media[0].URL = "/row.mp4" },
+	} {
+		m := valid
+		m.Media = []catalog.Media{valid.Media[0]}
+		edit(&m)
+		require.ErrorIs(t, (&catalog.Catalog{Moves: []catalog.Move{m}}).Validate(), common.ErrInvalidCatalog, name)
+	}
+}
+
+func TestReloader_KeepsPreviousOnInvalid(t *testing.T) {
+	p := writeFile(t, t.TempDir(), "catalog.yml", rowFragment)
+	catalogs, err := catalog.Load(p, "hyrox")
+	require.NoError(t, err)
+
+	registry, err := catalog.NewRegistry("hyrox", catalogs)
+	require.NoError(t, err)
+	r := catalog.NewReloader(registry, p, nil)
+
+	writeFile(t, filepath.Dir(p), "catalog.yml", "moves: []")
+	require.Error(t, r.Reload())
+	c, err := registry.Get("")
+	require.NoError(t, err)
+	require.Same(t, catalogs["hyrox"], c)
+
+	writeFile(t, filepath.Dir(p), "catalog.yml", runFragment)
+	require.NoError(t, r.Reload())
+	c, err = registry.Get("hyrox")
+	require.NoError(t, err)
+	require.Equal(t, "Run", c.Moves[0].Name)
+}
+
+func TestReloader_SwapsAllOrNone(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "hyrox.yml", rowFragment)
+	writeFile(t, dir, "running.yml", "name: running"+runFragment)
+	catalogs, err := catalog.Load(dir, "hyrox")
+	require.NoError(t, err)
+	registry, err := catalog.NewRegistry("hyrox", catalogs)
+	require.NoError(t, err)
+	r := catalog.NewReloader(registry, dir, nil)
+
+	writeFile(t, dir, "hyrox.yml", runFragment)
+	writeFile(t, dir, "running.yml", "name: running\nmoves: []")
+	require.Error(t, r.Reload())
+	for _, name := range []string{"hyrox", "running"} {
+		c, err := registry.Get(name)
+		require.NoError(t, err)
+		require.Same(t, catalogs[name], c, name)
+	}
+}
+
+func TestHash_IgnoresOrderAndNilSlices(t *testing.T) {
+	a := &catalog.Catalog{Moves: []catalog.Move{{Name: "Run", Weight: 1}, {Name: "Row", Weight: 1}}}
+	b := &catalog.Catalog{Version: 7, Moves: []catalog.Move{
+		{Name: "Row", Weight: 1, Tags: []string{}, NeedsOneOf: []string{}},
+		{Name: "Run", Weight: 1, Ranges: map[string]map[string]catalog.Rng{}},
+	}}
+	require.Equal(t, a.Hash(), b.Hash())
+
+	b.Moves[0].Weight = 2
+	require.NotEqual(t, a.Hash(), b.Hash())
+}
+
+func TestRegistry(t *testing.T) {
+	_, err := catalog.NewRegistry("hyrox", map[string]*catalog.Catalog{"running": {Name: "running"}})
+	require.ErrorIs(t, err, common.ErrUnknownCatalog, "default catalog must exist")
+
+	r, err := catalog.NewRegistry("hyrox", map[string]*catalog.Catalog{
+		"hyrox":   {Name: "hyrox"},
+		"running": {Name: "running"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"hyrox", "running"}, r.Names())
+
+	c, err := r.Get(" Running ")
+	require.NoError(t, err)
+	require.Equal(t, "running", c.Name)
+
+	_, err = r.Get("yoga")
+	require.ErrorIs(t, err, common.ErrUnknownCatalog)
+}
+
+func TestMove_IDAndTranslations(t *testing.T) {
+	m := catalog.Move{
+		Name:        "Sled Push",
+		Description: "Push the sled",
+		Locales:     map[string]catalog.Translation{"fr": {Name: "Poussée de traîneau"}},
+	}
+	require.Equal(t, "sled-push", m.Key())
+	require.Equal(t, "Poussée de traîneau", m.DisplayName("fr"))
+	require.Equal(t, "Push the sled", m.DisplayDescription("fr"), "falls back to the canonical description")
+	require.Equal(t, "Sled Push", m.DisplayName("de"))
+
+	c := &catalog.Catalog{Moves: []catalog.Move{m, {ID: "row", Name: "Row"}}}
+	found, ok := c.Lookup("sled-push")
+	require.True(t, ok)
+	require.Equal(t, "Sled Push", found.Name)
+	_, ok = c.Lookup("Row")
+	require.True(t, ok, "blocks stored before ids are looked up by name")
+
+	dup := &catalog.Catalog{Moves: []catalog.Move{{ID: "row", Name: "Row"}, {ID: "row", Name: "Rower"}}}
+	require.ErrorIs(t, dup.Validate(), common.ErrInvalidCatalog)
+}
+
+func TestImport_CSV(t *testing.T) {
+	csv := "Name,Needs one of,Tags,Weight,beginner.meters,advanced.meters,name.fr\n" +
+		"Row,rower,engine;mixed,1.2,400-900,700-1200,Rameur\n" +
+		"Burpees,,strength,,10,,\n"
+
+	c, err := catalog.Import(strings.NewReader(csv), catalog.FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	require.Len(t, c.Moves, 2)
+
+	row := c.Moves[0]
+	require.Equal(t, "row", row.ID)
+	require.Equal(t, []string{"rower"}, row.NeedsOneOf)
+	require.Equal(t, []string{"engine", "mixed"}, row.Tags)
+	require.InDelta(t, 1.2, row.Weight, 0.001)
+	require.Equal(t, catalog.Rng{700, 1200}, row.Ranges["advanced"]["meters"])
+	require.Equal(t, "Rameur", row.DisplayName("fr"))
+	require.Equal(t, catalog.Rng{10, 10}, c.Moves[1].Ranges["beginner"]["meters"])
+	require.InDelta(t, 1.0, c.Moves[1].Weight, 0.001)
+
+	_, err = catalog.Import(strings.NewReader("name,beginner.meters\nRow,far\n"), catalog.FormatCSV)
+	require.ErrorIs(t, err, common.ErrInvalidCatalog)
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestImport_JSON(t *testing.T) {
+	rows := `[{"name": "Row", "tags": ["engine"], "beginner.meters": "400-900", "weight": 2}]`
+	c, err := catalog.Import(strings.NewReader(rows), catalog.FormatJSON)
+	require.NoError(t, err)
+	require.Equal(t, []string{"engine"}, c.Moves[0].Tags)
+	require.InDelta(t, 2.0, c.Moves[0].Weight, 0.001)
+
+	doc := `{"name": "running", "levels": ["beginner"], "moves": [{"name": "Easy Run", "ranges": {"beginner": {"meters": [800, 1600]}}}]}`
+	c, err = catalog.Import(strings.NewReader(doc), catalog.FormatJSON)
+	require.NoError(t, err)
+	require.Equal(t, "running", c.Name)
+	require.Equal(t, []string{"beginner"}, c.Levels)
+}
+
+func TestCatalog_DiffAndYAMLRoundTrip(t *testing.T) {
+	base := &catalog.Catalog{Name: "hyrox", Equipment: []string{"rower"}, Moves: []catalog.Move{
+		{ID: "row", Name: "Row", Weight: 1, NeedsOneOf: []string{"rower"}},
+		{ID: "run", Name: "Run", Weight: 1},
+	}}
+	next := &catalog.Catalog{Moves: []catalog.Move{
+		{ID: "row", Name: "Row", Weight: 2, NeedsOneOf: []string{"rower"}},
+		{ID: "sled-push", Name: "Sled Push", Weight: 1, NeedsOneOf: []string{"sled"},
+			Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {10, 20}}}},
+	}}
+	next.Inherit(base)
+	require.Equal(t, "hyrox", next.Name)
+	require.Equal(t, []string{"rower", "sled"}, next.Equipment)
+	require.NoError(t, next.Validate())
+
+	d := base.Diff(next)
+	require.Equal(t, []string{"sled-push"}, d.Added)
+	require.Equal(t, []string{"run"}, d.Removed)
+	require.Equal(t, []string{"row"}, d.Changed)
+	require.True(t, d.EquipmentChanged)
+	require.False(t, d.LevelsChanged)
+	require.True(t, base.Diff(base).Empty())
+
+	out, err := next.YAML()
+	require.NoError(t, err)
+	loaded, err := catalog.Load(writeFile(t, t.TempDir(), "catalog.yml", string(out)), "other")
+	require.NoError(t, err)
+	require.Equal(t, next.Hash(), loaded["hyrox"].Hash())
+}
+
+const boxOverlay = `
+tenant: Box-Lyon
+disable: [run]
+weights: { Row: 3 }
+ranges:
+  row: { beginner: { meters: [100, 200] } }
+moves:
+  - name: Assault Bike
+    needs_one_of: ["bike"]
+    ranges:
+      beginner: { calories: [10, 15] }
+`
+
+func TestOverlay_Apply(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "row.yml", rowFragment)
+	writeFile(t, dir, "run.yml", runFragment)
+	catalogs, err := catalog.Load(dir, "hyrox")
+	require.NoError(t, err)
+	base := catalogs["hyrox"]
+
+	overlays, err := catalog.LoadOverlays(writeFile(t, t.TempDir(), "box.yml", boxOverlay))
+	require.NoError(t, err)
+	require.Len(t, overlays, 1)
+	require.Equal(t, "box-lyon", overlays[0].Tenant)
+
+	c, err := overlays[0].Apply(base, true)
+	require.NoError(t, err)
+	require.Len(t, c.Moves, 2)
+	row, ok := c.Lookup("row")
+	require.True(t, ok)
+	require.InDelta(t, 3.0, row.Weight, 0)
+	require.Equal(t, catalog.Rng{100, 200}, row.Ranges["beginner"]["meters"])
+	_, ok = c.Lookup("assault-bike")
+	require.True(t, ok)
+	_, ok = c.Lookup("run")
+	require.False(t, ok)
+	require.Len(t, base.Moves, 2, "base is left untouched")
+	require.Equal(t, catalog.Rng{400, 900}, base.Moves[0].Ranges["beginner"]["meters"])
+
+	strict := catalog.Overlay{Tenant: "box-lyon", Disable: []string{"sled-push"}}
+	_, err = strict.Apply(base, true)
+	require.ErrorIs(t, err, common.ErrInvalidCatalog)
+	_, err = strict.Apply(base, false)
+	require.NoError(t, err, "unknown moves are skipped once the overlay is loaded")
+}
+
+func TestLoadOverlays_DuplicateTenant(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yml", boxOverlay)
+	writeFile(t, dir, "b.yml", "tenant: box-lyon\n")
+	_, err := catalog.LoadOverlays(dir)
+	require.ErrorIs(t, err, common.ErrInvalidCatalog)
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	catalogs, err := catalog.Load(writeFile(t, t.TempDir(), "catalog.yml", rowFragment), "hyrox")
+	require.NoError(t, err)
+	r, err := catalog.NewRegistry("hyrox", catalogs)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, r.SetOverlays([]catalog.Overlay{{Tenant: "box", Catalog: "yoga"}}), common.ErrUnknownCatalog)
+	require.NoError(t, r.SetOverlays([]catalog.Overlay{{Tenant: "box", Weights: map[string]float64{"row": 5}}}))
+
+	base, err := r.Get("hyrox")
+	require.NoError(t, err)
+	c, err := r.Resolve("", "")
+	require.NoError(t, err)
+	require.Same(t, base, c, "no tenant, no overlay")
+	c, err = r.Resolve("hyrox", "other")
+	require.NoError(t, err)
+	require.Same(t, base, c)
+
+	c, err = r.Resolve("hyrox", "Box")
+	require.NoError(t, err)
+	require.InDelta(t, 5.0, c.Moves[0].Weight, 0)
+	require.NotEqual(t, base.Hash(), c.Hash())
+	again, err := r.Resolve("hyrox", "box")
+	require.NoError(t, err)
+	require.Same(t, c, again, "resolved until the catalog is swapped")
+}
+
+func TestReloader_ConfigMapUpdate(t *testing.T) {
+	// a ConfigMap mount: catalog.yml -> ..data/catalog.yml, ..data -> ..<version>
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0o700))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0o700))
+	writeFile(t, filepath.Join(dir, "..v1"), "catalog.yml", rowFragment)
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	p := filepath.Join(dir, "catalog.yml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "catalog.yml"), p))
+
+	catalogs, err := catalog.Load(p, "hyrox")
+	require.NoError(t, err)
+	registry, err := catalog.NewRegistry("hyrox", catalogs)
+	require.NoError(t, err)
+	r := catalog.NewReloader(registry, p, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- r.Run(ctx) }()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+	// let Run add its watch before the update.
+	time.Sleep(100 * time.Millisecond)
+
+	writeFile(t, filepath.Join(dir, "..v2"), "catalog.yml", runFragment)
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	require.Eventually(t, func() bool {
+		c, err := registry.Get("hyrox")
+		return err == nil && c.Moves[0].Name == "Run"
+	}, 2*time.Second, 20*time.Millisecond)
+}
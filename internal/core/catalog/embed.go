@@ -1,6 +1,8 @@
 package catalog
 
-import _ "embed"
+import "embed"
 
-//go:embed catalog.yml
-var Raw []byte
+// Embedded holds the catalogs shipped with the binary, one per file.
+//
+//go:embed *.yml
+var Embedded embed.FS
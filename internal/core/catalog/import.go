@@ -0,0 +1,365 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Import formats.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// listSep separates the values of list cells, commas are kept for the texts.
+const listSep = ";"
+
+// Import reads a catalog exported from a spreadsheet. A CSV export has one move
+// per row and a header naming the columns:
+//
+//	id, name, description, pattern, weight
+//	cues, muscles, tags, needs_one_of (or equipment)  values separated by ";"
+//	units                                             "meters=m; reps=reps"
+//	<level>.<param>                                   range "400-900", or "10" for a fixed value
+//	name.<locale>, description.<locale>, cues.<locale>
+//
+// A JSON export is either an array of such rows or a catalog document shaped
+// like the YAML files. Rows do not carry the catalog levels and equipment, see
+// Inherit. The result is not validated.
+func Import(r io.Reader, format string) (*Catalog, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return importCSV(raw)
+	case FormatJSON:
+		return importJSON(raw)
+	default:
+		return nil, fmt.Errorf("%w: unknown import format %q", common.ErrInvalidCatalog, format)
+	}
+}
+
+func importCSV(raw []byte) (*Catalog, error) {
+	r := csv.NewReader(bytes.NewReader(raw))
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidCatalog, err)
+	}
+	if len(records) < 2 {
+		return nil, common.ErrEmptyCatalog
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			if i < len(rec) {
+				row[col] = rec[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	// the header is line 1.
+	return fromRows(rows, 2)
+}
+
+func importJSON(raw []byte) (*Catalog, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		// YAML is a superset of JSON, the document parses like a catalog file.
+		return parse(trimmed)
+	}
+
+	var objects []map[string]any
+	if err := json.Unmarshal(trimmed, &objects); err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidCatalog, err)
+	}
+	rows := make([]map[string]string, len(objects))
+	for i, obj := range objects {
+		rows[i] = make(map[string]string, len(obj))
+		for col, v := range obj {
+			rows[i][col] = cell(v)
+		}
+	}
+	return fromRows(rows, 1)
+}
+
+// cell flattens a JSON value to its CSV form.
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = cell(e)
+		}
+		return strings.Join(parts, listSep)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// fromRows builds the moves of rows, first is the line number of the first
+// row in error messages.
+func fromRows(rows []map[string]string, first int) (*Catalog, error) {
+	if len(rows) == 0 {
+		return nil, common.ErrEmptyCatalog
+	}
+
+	c := &Catalog{}
+	for i, row := range rows {
+		m, err := moveFromRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", first+i, err)
+		}
+		c.Moves = append(c.Moves, m)
+	}
+	return c, nil
+}
+
+func moveFromRow(row map[string]string) (Move, error) {
+	m := Move{}
+	for col, value := range row {
+		col = column(col)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if field, sub, ok := strings.Cut(col, "."); ok {
+			if err := setDotted(&m, field, sub, value); err != nil {
+				return Move{}, err
+			}
+			continue
+		}
+
+		switch col {
+		case "id":
+			m.ID = value
+		case "name":
+			m.Name = value
+		case "description":
+			m.Description = value
+		case "pattern":
+			m.Pattern = strings.ToLower(value)
+		case "weight":
+			w, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return Move{}, fmt.Errorf("%w: weight %q is not a number", common.ErrInvalidCatalog, value)
+			}
+			m.Weight = w
+		case "cues":
+			m.Cues = list(value)
+		case "muscles":
+			m.Muscles = lower(list(value))
+		case "tags":
+			m.Tags = lower(list(value))
+		case "needs_one_of", "equipment":
+			m.NeedsOneOf = lower(list(value))
+		case "units":
+			units, err := unitsCell(value)
+			if err != nil {
+				return Move{}, err
+			}
+			m.Units = units
+		default:
+			return Move{}, fmt.Errorf("%w: unknown column %q", common.ErrInvalidCatalog, col)
+		}
+	}
+
+	if m.Name == "" {
+		return Move{}, fmt.Errorf("%w: move without name", common.ErrInvalidCatalog)
+	}
+	if m.Weight == 0 {
+		m.Weight = 1.0
+	}
+	m.ID = m.Key()
+	return m, nil
+}
+
+// setDotted fills the <level>.<param> range and <field>.<locale> text columns.
+func setDotted(m *Move, field, sub, value string) error {
+	if field == LevelBeginner || field == LevelIntermediate || field == LevelAdvanced {
+		r, err := rangeCell(value)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", field, sub, err)
+		}
+		if m.Ranges == nil {
+			m.Ranges = map[string]map[string]Rng{}
+		}
+		if m.Ranges[field] == nil {
+			m.Ranges[field] = map[string]Rng{}
+		}
+		m.Ranges[field][sub] = r
+		return nil
+	}
+
+	if m.Locales == nil {
+		m.Locales = map[string]Translation{}
+	}
+	t := m.Locales[sub]
+	switch field {
+	case "name":
+		t.Name = value
+	case "description":
+		t.Description = value
+	case "cues":
+		t.Cues = list(value)
+	default:
+		return fmt.Errorf("%w: unknown column %q", common.ErrInvalidCatalog, field+"."+sub)
+	}
+	m.Locales[sub] = t
+	return nil
+}
+
+// column normalizes a header: "Needs one of" becomes "needs_one_of".
+func column(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "_")
+}
+
+func list(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, listSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// rangeCell reads "400-900", "400;900" or a fixed "10".
+func rangeCell(s string) (Rng, error) {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		lo, hi, ok = strings.Cut(s, listSep)
+	}
+	if !ok {
+		hi = lo
+	}
+	minV, err1 := strconv.Atoi(strings.TrimSpace(lo))
+	maxV, err2 := strconv.Atoi(strings.TrimSpace(hi))
+	if err := errors.Join(err1, err2); err != nil {
+		return Rng{}, fmt.Errorf("%w: range %q is not min-max", common.ErrInvalidCatalog, s)
+	}
+	return Rng{minV, maxV}, nil
+}
+
+// unitsCell reads "meters=m; reps=reps".
+func unitsCell(s string) (map[string]string, error) {
+	units := map[string]string{}
+	for _, pair := range list(s) {
+		param, unit, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: unit %q is not param=unit", common.ErrInvalidCatalog, pair)
+		}
+		units[strings.TrimSpace(param)] = strings.TrimSpace(unit)
+	}
+	return units, nil
+}
+
+// Inherit completes an imported catalog with what base knows and the import
+// left out: its name, levels and declared equipment. Equipment the imported
+// moves need on top is declared along.
+func (c *Catalog) Inherit(base *Catalog) {
+	if c.Name == "" {
+		c.Name = base.Name
+	}
+	if len(c.Levels) == 0 {
+		c.Levels = base.Levels
+	}
+	if len(c.Equipment) == 0 && len(base.Equipment) > 0 {
+		c.Equipment = distinct(append([]Move{{NeedsOneOf: base.Equipment}}, c.Moves...),
+			func(m Move) []string { return m.NeedsOneOf })
+	}
+}
+
+// Diff lists what changes from one catalog to the next, moves by ID.
+type Diff struct {
+	Added            []string `json:"added"`
+	Removed          []string `json:"removed"`
+	Changed          []string `json:"changed"`
+	LevelsChanged    bool     `json:"levels_changed"`
+	EquipmentChanged bool     `json:"equipment_changed"`
+}
+
+// Empty reports whether next is the same catalog content.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		!d.LevelsChanged && !d.EquipmentChanged
+}
+
+// Diff compares c with next, both are left untouched.
+func (c *Catalog) Diff(next *Catalog) Diff {
+	d := Diff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+
+	cur := make(map[string]Move, len(c.Moves))
+	for _, m := range c.Moves {
+		cur[m.Key()] = m
+	}
+	for _, m := range next.Moves {
+		old, ok := cur[m.Key()]
+		delete(cur, m.Key())
+		switch {
+		case !ok:
+			d.Added = append(d.Added, m.Key())
+		case !sameMove(old, m):
+			d.Changed = append(d.Changed, m.Key())
+		}
+	}
+	for _, m := range c.Moves {
+		if _, ok := cur[m.Key()]; ok {
+			d.Removed = append(d.Removed, m.Key())
+		}
+	}
+
+	d.LevelsChanged = strings.Join(c.LevelNames(), ",") != strings.Join(next.LevelNames(), ",")
+	d.EquipmentChanged = strings.Join(c.AllEquipment(), ",") != strings.Join(next.AllEquipment(), ",")
+	return d
+}
+
+func sameMove(a, b Move) bool {
+	ra, errA := json.Marshal(a.normalized())
+	rb, errB := json.Marshal(b.normalized())
+	return errA == nil && errB == nil && bytes.Equal(ra, rb)
+}
+
+// YAML renders c as a catalog file Load reads back.
+func (c *Catalog) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return nil, fmt.Errorf("yaml.Encode: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("yaml.Close: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML keeps ranges on one line, as in the hand-written files.
+func (r Rng) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(r[0])},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(r[1])},
+	}}, nil
+}
@@ -0,0 +1,111 @@
+package catalog
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Load reads the catalogs from path, which is either a single YAML file or a
+// directory of YAML fragments merged in lexical order. Fragments are grouped
+// by their name, the ones without a name belong to defaultName. An empty path
+// falls back to the embedded catalogs.
+func Load(path, defaultName string) (map[string]*Catalog, error) {
+	if path == "" {
+		return load(Embedded, ".", defaultName)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Stat(%s): %w", path, err)
+	}
+	if !info.IsDir() {
+		return load(os.DirFS(filepath.Dir(path)), filepath.Base(path), defaultName)
+	}
+	return load(os.DirFS(path), ".", defaultName)
+}
+
+func load(fsys fs.FS, root, defaultName string) (map[string]*Catalog, error) {
+	files, err := catalogFiles(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]*Catalog{}
+	for _, f := range files {
+		raw, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, fmt.Errorf("fs.ReadFile(%s): %w", f, err)
+		}
+		c, err := parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		if c.Name == "" {
+			c.Name = defaultName
+		}
+		merge(merged, c)
+	}
+
+	for name, c := range merged {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("catalog %q: %w", name, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// merge folds fragment c into the catalog of the same name.
+func merge(merged map[string]*Catalog, c *Catalog) {
+	cur, ok := merged[c.Name]
+	if !ok {
+		merged[c.Name] = c
+		return
+	}
+	cur.Moves = append(cur.Moves, c.Moves...)
+	cur.Levels = union(cur.Levels, c.Levels)
+	cur.Equipment = union(cur.Equipment, c.Equipment)
+	cur.Version = max(cur.Version, c.Version)
+}
+
+func union(a, b []string) []string {
+	for _, v := range b {
+		if !containsFold(a, v) {
+			a = append(a, v)
+		}
+	}
+	return a
+}
+
+func catalogFiles(fsys fs.FS, root string) ([]string, error) {
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		return nil, fmt.Errorf("fs.Stat(%s): %w", root, err)
+	}
+	if !info.IsDir() {
+		return []string{root}, nil
+	}
+
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return nil, fmt.Errorf("fs.ReadDir(%s): %w", root, err)
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && isYAML(e.Name()) {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func isYAML(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yml" || ext == ".yaml"
+}
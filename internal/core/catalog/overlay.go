@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Overlay tweaks a catalog for one tenant: moves are referenced by ID or name.
+//
+//	tenant: box-lyon
+//	catalog: hyrox                 # the default catalog when omitted
+//	disable: [burpees-broad-jump]
+//	weights: { row: 2 }
+//	ranges:
+//	  sled-push: { beginner: { meters: [5, 10] } }
+//	moves:                         # custom moves, as in a catalog file
+//	  - name: Assault Bike
+type Overlay struct {
+	Tenant  string                               `yaml:"tenant"`
+	Catalog string                               `yaml:"catalog"`
+	Disable []string                             `yaml:"disable"`
+	Weights map[string]float64                   `yaml:"weights"`
+	Ranges  map[string]map[string]map[string]Rng `yaml:"ranges"` // move -> level -> param -> [min,max]
+	Moves   []Move                               `yaml:"moves"`
+}
+
+// LoadOverlays reads the overlays at path, a single YAML file or a directory
+// of them, one tenant per file.
+func LoadOverlays(path string) ([]Overlay, error) {
+	fsys, root := os.DirFS(path), "."
+	if info, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("os.Stat(%s): %w", path, err)
+	} else if !info.IsDir() {
+		fsys, root = os.DirFS(filepath.Dir(path)), filepath.Base(path)
+	}
+
+	files, err := catalogFiles(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+	overlays := make([]Overlay, 0, len(files))
+	seen := map[string]string{}
+	for _, f := range files {
+		raw, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, fmt.Errorf("fs.ReadFile(%s): %w", f, err)
+		}
+		var o Overlay
+		if err := yaml.Unmarshal(raw, &o); err != nil {
+			return nil, fmt.Errorf("%s: parse overlay: %w", f, err)
+		}
+		o.Tenant = normalizeName(o.Tenant)
+		o.Catalog = normalizeName(o.Catalog)
+		if o.Tenant == "" {
+			return nil, fmt.Errorf("%s: %w: overlay without tenant", f, common.ErrInvalidCatalog)
+		}
+		key := o.Tenant + "/" + o.Catalog
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%s: %w: tenant %q already overlaid in %s", f, common.ErrInvalidCatalog, o.Tenant, prev)
+		}
+		seen[key] = f
+		overlays = append(overlays, o)
+	}
+	return overlays, nil
+}
+
+// Apply returns base as the tenant sees it, base is left untouched. With
+// strict, references to moves base does not have are errors; otherwise they
+// are skipped, base may have changed since the overlay was written.
+func (o Overlay) Apply(base *Catalog, strict bool) (*Catalog, error) {
+	if strict {
+		for _, ref := range o.references() {
+			if !hasMove(base.Moves, ref) {
+				return nil, fmt.Errorf("%w: overlay of %q references unknown move %q", common.ErrInvalidCatalog, o.Tenant, ref)
+			}
+		}
+	}
+
+	moves := make([]Move, 0, len(base.Moves)+len(o.Moves))
+	for _, m := range base.Moves {
+		if !o.disables(m) {
+			moves = append(moves, o.tweak(m))
+		}
+	}
+	for _, m := range o.Moves {
+		if m.Weight == 0 {
+			m.Weight = 1.0
+		}
+		m.ID = m.Key()
+		moves = append(moves, m)
+	}
+
+	out := *base
+	out.hash = ""
+	out.Moves = moves
+	if len(base.Equipment) > 0 {
+		// custom moves may bring equipment the base catalog does not declare.
+		out.Equipment = distinct(append([]Move{{NeedsOneOf: base.Equipment}}, o.Moves...),
+			func(m Move) []string { return m.NeedsOneOf })
+	}
+	if err := out.Validate(); err != nil {
+		return nil, fmt.Errorf("overlay of %q: %w", o.Tenant, err)
+	}
+	return &out, nil
+}
+
+// references lists the moves the overlay points at.
+func (o Overlay) references() []string {
+	refs := append([]string{}, o.Disable...)
+	for ref := range o.Weights {
+		refs = append(refs, ref)
+	}
+	for ref := range o.Ranges {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+func (o Overlay) disables(m Move) bool {
+	for _, ref := range o.Disable {
+		if matches(m, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// tweak applies the weight and ranges overrides to a copy of m.
+func (o Overlay) tweak(m Move) Move {
+	for ref, w := range o.Weights {
+		if matches(m, ref) {
+			m.Weight = w
+		}
+	}
+	for ref, levels := range o.Ranges {
+		if !matches(m, ref) {
+			continue
+		}
+		ranges := make(map[string]map[string]Rng, len(m.Ranges)+len(levels))
+		for level, params := range m.Ranges {
+			ranges[level] = params
+		}
+		for level, params := range levels {
+			ranges[strings.ToLower(level)] = params
+		}
+		m.Ranges = ranges
+	}
+	return m
+}
+
+func hasMove(moves []Move, ref string) bool {
+	for _, m := range moves {
+		if matches(m, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(m Move, ref string) bool {
+	return strings.EqualFold(m.Key(), ref) || strings.EqualFold(m.Name, ref)
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+)
+
+// Registry holds the named catalogs served, each in its own Store so one can
+// be swapped without touching the others. The set of names is fixed at startup.
+type Registry struct {
+	stores      map[string]*Store
+	defaultName string
+
+	mu       sync.Mutex
+	overlays map[string]Overlay // by catalog/tenant
+	resolved map[string]resolved
+}
+
+// resolved caches an overlay applied to the snapshot base, a reload gives a
+// new snapshot and the overlay is applied again.
+type resolved struct {
+	base, out *Catalog
+}
+
+func NewRegistry(defaultName string, catalogs map[string]*Catalog) (*Registry, error) {
+	defaultName = normalizeName(defaultName)
+	if _, ok := catalogs[defaultName]; !ok {
+		return nil, fmt.Errorf("%w: default catalog %q", common.ErrUnknownCatalog, defaultName)
+	}
+
+	r := &Registry{stores: make(map[string]*Store, len(catalogs)), defaultName: defaultName}
+	for name, c := range catalogs {
+		r.stores[normalizeName(name)] = NewStore(c)
+	}
+	return r, nil
+}
+
+// Default is the name of the catalog used when a request does not pick one.
+func (r *Registry) Default() string {
+	return r.defaultName
+}
+
+// Names lists the catalogs served, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.stores))
+	for name := range r.stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Store returns the store of the named catalog, the default one for an empty name.
+func (r *Registry) Store(name string) (*Store, error) {
+	name = normalizeName(name)
+	if name == "" {
+		name = r.defaultName
+	}
+	s, ok := r.stores[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", common.ErrUnknownCatalog, name)
+	}
+	return s, nil
+}
+
+// Get returns the current snapshot of the named catalog.
+func (r *Registry) Get(name string) (*Catalog, error) {
+	s, err := r.Store(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(), nil
+}
+
+// SetOverlays replaces the tenant overlays. Each must target a served catalog,
+// the default one when it names none, and apply cleanly to its current content.
+func (r *Registry) SetOverlays(overlays []Overlay) error {
+	set := make(map[string]Overlay, len(overlays))
+	for _, o := range overlays {
+		if o.Catalog == "" {
+			o.Catalog = r.defaultName
+		}
+		base, err := r.Get(o.Catalog)
+		if err != nil {
+			return fmt.Errorf("overlay of %q: %w", o.Tenant, err)
+		}
+		if _, err := o.Apply(base, true); err != nil {
+			return err
+		}
+		set[overlayKey(o.Catalog, o.Tenant)] = o
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overlays = set
+	r.resolved = map[string]resolved{}
+	return nil
+}
+
+// Resolve returns the named catalog as tenant sees it: the current snapshot
+// with the tenant overlay applied, or the snapshot itself without overlay.
+func (r *Registry) Resolve(name, tenant string) (*Catalog, error) {
+	s, err := r.Store(name)
+	if err != nil {
+		return nil, err
+	}
+	base := s.Get()
+	tenant = normalizeName(tenant)
+	if tenant == "" {
+		return base, nil
+	}
+
+	if name = normalizeName(name); name == "" {
+		name = r.defaultName
+	}
+	key := overlayKey(name, tenant)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.overlays[key]
+	if !ok {
+		return base, nil
+	}
+	if cached, ok := r.resolved[key]; ok && cached.base == base {
+		return cached.out, nil
+	}
+	out, err := o.Apply(base, false)
+	if err != nil {
+		return nil, err
+	}
+	out = seal(out)
+	r.resolved[key] = resolved{base: base, out: out}
+	return out, nil
+}
+
+func overlayKey(catalog, tenant string) string {
+	return normalizeName(catalog) + "/" + tenant
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounce groups the burst of events editors emit on a single save.
+const debounce = 250 * time.Millisecond
+
+// configMapData is the symlink a Kubernetes ConfigMap mount swaps to publish
+// an update. The mounted files link through it, so only it changes.
+const configMapData = "..data"
+
+// Reloader re-reads the catalogs at path on SIGHUP or whenever the file (or
+// one of the fragments in the directory) changes. Catalogs that fail to load
+// or validate are logged and the previous ones keep being served.
+type Reloader struct {
+	registry *Registry
+	path     string
+	logger   *slog.Logger
+}
+
+func NewReloader(registry *Registry, path string, logger *slog.Logger) *Reloader {
+	return &Reloader{registry: registry, path: path, logger: logger}
+}
+
+// Reload swaps every served catalog, or none of them: every catalog is loaded,
+// validated and matched to its store before the first swap. Catalogs added to
+// the files since startup are ignored until the next restart.
+func (r *Reloader) Reload() error {
+	loaded, err := Load(r.path, r.registry.Default())
+	if err != nil {
+		return fmt.Errorf("catalog.Load(%s): %w", r.path, err)
+	}
+
+	names := r.registry.Names()
+	stores := make([]*Store, len(names))
+	for i, name := range names {
+		if _, ok := loaded[name]; !ok {
+			return fmt.Errorf("catalog %q: %w", name, common.ErrEmptyCatalog)
+		}
+		if stores[i], err = r.registry.Store(name); err != nil {
+			return err
+		}
+	}
+	for i, name := range names {
+		stores[i].Swap(loaded[name])
+	}
+
+	return nil
+}
+
+// Run blocks until ctx is done.
+func (r *Reloader) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("fsnotify.NewWatcher: %w", err)
+	}
+	defer func() {
+		if err = watcher.Close(); err != nil {
+			r.logger.Warn("watcher.Close", slog.Any("err", err))
+		}
+	}()
+
+	// Watch the parent directory of a single file: editors replace files by
+	// rename, which drops a watch on the file itself, and ConfigMap mounts
+	// swap the ..data symlink next to it.
+	dir, target := r.path, ""
+	if info, err := os.Stat(r.path); err == nil && !info.IsDir() {
+		dir, target = filepath.Dir(r.path), filepath.Clean(r.path)
+	}
+	if err = watcher.Add(dir); err != nil {
+		return fmt.Errorf("watcher.Add(%s): %w", dir, err)
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			r.reload("sighup")
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watched(ev.Name, target) {
+				continue
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			r.reload("file_change")
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.logger.Warn("catalog watcher", slog.Any("err", err))
+		}
+	}
+}
+
+// watched reports whether a change to name reloads the catalogs: target, or
+// any YAML file of the directory without one, or a ConfigMap update.
+func watched(name, target string) bool {
+	switch {
+	case filepath.Base(name) == configMapData:
+		return true
+	case target != "":
+		return filepath.Clean(name) == target
+	default:
+		return isYAML(name)
+	}
+}
+
+func (r *Reloader) reload(trigger string) {
+	if err := r.Reload(); err != nil {
+		r.logger.Error("catalog reload failed, keeping previous catalog",
+			slog.String("trigger", trigger),
+			slog.Any("err", err),
+		)
+		return
+	}
+	r.logger.Info("catalog reloaded",
+		slog.String("trigger", trigger),
+		slog.Any("catalogs", r.registry.Names()),
+	)
+}
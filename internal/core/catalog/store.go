@@ -0,0 +1,33 @@
+package catalog
+
+import "sync/atomic"
+
+// Store holds the catalog currently served. Readers grab a snapshot with Get
+// and keep using it for the whole request, so a concurrent Swap never changes
+// the moves under an in-flight generation. Catalogs must not be modified once
+// stored, their content hash is computed when they get in.
+type Store struct {
+	current atomic.Pointer[Catalog]
+}
+
+func NewStore(c *Catalog) *Store {
+	s := &Store{}
+	s.current.Store(seal(c))
+	return s
+}
+
+func (s *Store) Get() *Catalog {
+	return s.current.Load()
+}
+
+// Swap replaces the served catalog and returns the previous one.
+func (s *Store) Swap(c *Catalog) *Catalog {
+	return s.current.Swap(seal(c))
+}
+
+func seal(c *Catalog) *Catalog {
+	if c != nil {
+		c.hash = c.computeHash()
+	}
+	return c
+}
@@ -0,0 +1,369 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+type CatalogManagerInterface interface {
+	Names(ctx context.Context) []string
+	Snapshot(ctx context.Context, catalogName string) (*catalog.Catalog, error)
+	Resolve(ctx context.Context, catalogName, tenant string) (*catalog.Catalog, error)
+	Find(ctx context.Context, f CatalogFilter) (*catalog.Catalog, []catalog.Move, error)
+	Move(ctx context.Context, catalogName, tenant, name string) (*catalog.Catalog, catalog.Move, error)
+	CreateMove(ctx context.Context, catalogName string, m catalog.Move) (catalog.Move, error)
+	UpdateMove(ctx context.Context, catalogName, name string, m catalog.Move) (catalog.Move, error)
+	DeleteMove(ctx context.Context, catalogName, name string) error
+	Import(ctx context.Context, catalogName string, imported *catalog.Catalog, dryRun bool) (*catalog.Catalog, catalog.Diff, error)
+}
+
+// CatalogFilter narrows the moves returned by Find, zero values match
+// everything in the default catalog. Tenant applies its overlay first.
+type CatalogFilter struct {
+	Catalog   string
+	Tenant    string
+	Tag       string
+	Equipment []string
+	Level     string
+}
+
+// CatalogManager keeps the catalog registry in sync with the database. Without
+// a repository the catalogs come from files and every write is refused.
+type CatalogManager struct {
+	catalogRepository repository.CatalogRepositoryInterface
+	catalogs          *catalog.Registry
+}
+
+func NewCatalogManager(catalogs *catalog.Registry, catalogRepository repository.CatalogRepositoryInterface) *CatalogManager {
+	return &CatalogManager{catalogs: catalogs, catalogRepository: catalogRepository}
+}
+
+func (m *CatalogManager) Names(_ context.Context) []string {
+	return m.catalogs.Names()
+}
+
+func (m *CatalogManager) Snapshot(_ context.Context, catalogName string) (*catalog.Catalog, error) {
+	return m.catalogs.Get(catalogName)
+}
+
+// Resolve returns the catalog with the overlay of tenant applied, see
+// catalog.Registry.Resolve.
+func (m *CatalogManager) Resolve(_ context.Context, catalogName, tenant string) (*catalog.Catalog, error) {
+	return m.catalogs.Resolve(catalogName, tenant)
+}
+
+// Find returns the catalog served along with its moves matching f. With a
+// level, the ranges of the other levels are left out.
+func (m *CatalogManager) Find(_ context.Context, f CatalogFilter) (*catalog.Catalog, []catalog.Move, error) {
+	c, err := m.catalogs.Resolve(f.Catalog, f.Tenant)
+	if err != nil {
+		return nil, nil, err
+	}
+	level := strings.ToLower(f.Level)
+	if level != "" && !isLevel(level) {
+		return nil, nil, common.InvalidDataError{DataType: "level", Data: level}
+	}
+	if level != "" && !c.HasLevel(level) {
+		return nil, nil, fmt.Errorf("%w: %s has no %s level", common.ErrLevelUnavailable, c.Name, level)
+	}
+
+	moves := c.Moves
+	if len(f.Equipment) > 0 {
+		moves = filterByEquipment(moves, f.Equipment)
+	}
+
+	out := make([]catalog.Move, 0, len(moves))
+	for _, mv := range moves {
+		if f.Tag != "" && !containsFold(mv.Tags, f.Tag) {
+			continue
+		}
+		if level != "" {
+			r, ok := mv.Ranges[level]
+			if !ok {
+				continue
+			}
+			mv.Ranges = map[string]map[string]catalog.Rng{level: r}
+		}
+		out = append(out, mv)
+	}
+
+	return c, out, nil
+}
+
+// Move looks name up in the catalog served to tenant, its overlay applied.
+func (m *CatalogManager) Move(_ context.Context, catalogName, tenant, name string) (*catalog.Catalog, catalog.Move, error) {
+	c, err := m.catalogs.Resolve(catalogName, tenant)
+	if err != nil {
+		return nil, catalog.Move{}, err
+	}
+	i := findMove(c.Moves, name)
+	if i < 0 {
+		return c, catalog.Move{}, common.ErrMoveNotFound
+	}
+	return c, c.Moves[i], nil
+}
+
+// Seed stores every catalog of seeds missing from the database, then loads
+// the stored catalogs whatever their version.
+func (m *CatalogManager) Seed(ctx context.Context, seeds map[string]*catalog.Catalog) error {
+	if m.catalogRepository == nil {
+		return common.ErrCatalogReadOnly
+	}
+	for _, name := range m.catalogs.Names() {
+		c, ok := seeds[name]
+		if !ok {
+			continue
+		}
+		if _, err := m.catalogRepository.SeedCatalog(ctx, c); err != nil {
+			return fmt.Errorf("catalogRepository.SeedCatalog(%s): %w", name, err)
+		}
+	}
+	return m.refresh(ctx, true, m.catalogs.Names())
+}
+
+// Refresh swaps in the stored catalogs whose version moved.
+func (m *CatalogManager) Refresh(ctx context.Context) error {
+	return m.refresh(ctx, false, m.catalogs.Names())
+}
+
+// refreshWritten swaps in the catalog name after a committed write. A failure
+// is only logged: the write happened, and Run retries on its next poll.
+func (m *CatalogManager) refreshWritten(ctx context.Context, name string) {
+	if err := m.refresh(ctx, false, []string{name}); err != nil {
+		slog.Error("catalog refresh after write failed, keeping previous catalog",
+			slog.String("catalog", name), slog.Any("err", err))
+	}
+}
+
+func (m *CatalogManager) refresh(ctx context.Context, force bool, names []string) error {
+	if m.catalogRepository == nil {
+		return nil
+	}
+
+	versions, err := m.catalogRepository.CatalogVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("catalogRepository.CatalogVersions(): %w", err)
+	}
+
+	var errs []error
+	for _, name := range names {
+		v, ok := versions[name]
+		if !ok {
+			continue
+		}
+		store, err := m.catalogs.Store(name)
+		if err != nil {
+			return err
+		}
+		if cur := store.Get(); !force && cur != nil && cur.Version == v {
+			continue
+		}
+
+		c, err := m.catalogRepository.LoadCatalog(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("catalogRepository.LoadCatalog(%s): %w", name, err))
+			continue
+		}
+		if err := c.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("stored catalog %s v%d: %w", name, c.Version, err))
+			continue
+		}
+		store.Swap(c)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run polls the catalog versions so replicas pick up writes made elsewhere.
+func (m *CatalogManager) Run(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil {
+				logger.Error("catalog refresh failed, keeping previous catalog", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (m *CatalogManager) CreateMove(ctx context.Context, catalogName string, mv catalog.Move) (catalog.Move, error) {
+	if m.catalogRepository == nil {
+		return catalog.Move{}, common.ErrCatalogReadOnly
+	}
+	cur, err := m.catalogs.Get(catalogName)
+	if err != nil {
+		return catalog.Move{}, err
+	}
+	mv = normalizeMove(mv)
+	if findMove(cur.Moves, mv.Name) >= 0 {
+		return catalog.Move{}, common.ErrMoveExists
+	}
+	if err := checkMoves(cur, append(cloneMoves(cur.Moves), mv)); err != nil {
+		return catalog.Move{}, err
+	}
+
+	if err := m.catalogRepository.CreateMove(ctx, cur.Name, mv); err != nil {
+		return catalog.Move{}, fmt.Errorf("catalogRepository.CreateMove(): %w", err)
+	}
+	m.refreshWritten(ctx, cur.Name)
+	return mv, nil
+}
+
+func (m *CatalogManager) UpdateMove(ctx context.Context, catalogName, name string, mv catalog.Move) (catalog.Move, error) {
+	if m.catalogRepository == nil {
+		return catalog.Move{}, common.ErrCatalogReadOnly
+	}
+	cur, err := m.catalogs.Get(catalogName)
+	if err != nil {
+		return catalog.Move{}, err
+	}
+	mv = normalizeMove(mv)
+	i := findMove(cur.Moves, name)
+	if i < 0 {
+		return catalog.Move{}, common.ErrMoveNotFound
+	}
+	if j := findMove(cur.Moves, mv.Name); j >= 0 && j != i {
+		return catalog.Move{}, common.ErrMoveExists
+	}
+	candidate := cloneMoves(cur.Moves)
+	candidate[i] = mv
+	if err := checkMoves(cur, candidate); err != nil {
+		return catalog.Move{}, err
+	}
+
+	if err := m.catalogRepository.UpdateMove(ctx, cur.Name, cur.Moves[i].Name, mv); err != nil {
+		return catalog.Move{}, fmt.Errorf("catalogRepository.UpdateMove(): %w", err)
+	}
+	m.refreshWritten(ctx, cur.Name)
+	return mv, nil
+}
+
+func (m *CatalogManager) DeleteMove(ctx context.Context, catalogName, name string) error {
+	if m.catalogRepository == nil {
+		return common.ErrCatalogReadOnly
+	}
+	cur, err := m.catalogs.Get(catalogName)
+	if err != nil {
+		return err
+	}
+	i := findMove(cur.Moves, name)
+	if i < 0 {
+		return common.ErrMoveNotFound
+	}
+	if err := checkMoves(cur, append(cloneMoves(cur.Moves[:i]), cur.Moves[i+1:]...)); err != nil {
+		return err
+	}
+
+	if err := m.catalogRepository.DeleteMove(ctx, cur.Name, cur.Moves[i].Name); err != nil {
+		return fmt.Errorf("catalogRepository.DeleteMove(): %w", err)
+	}
+	m.refreshWritten(ctx, cur.Name)
+	return nil
+}
+
+// Import replaces the content of a catalog with imported, completed with what
+// the current catalog knows, and returns the catalog served afterwards. A dry
+// run only validates and diffs, it works on read-only catalogs too.
+func (m *CatalogManager) Import(ctx context.Context, catalogName string, imported *catalog.Catalog,
+	dryRun bool,
+) (*catalog.Catalog, catalog.Diff, error) {
+	cur, err := m.catalogs.Get(catalogName)
+	if err != nil {
+		return nil, catalog.Diff{}, err
+	}
+
+	next := *imported
+	next.Name = cur.Name
+	next.Moves = make([]catalog.Move, len(imported.Moves))
+	for i, mv := range imported.Moves {
+		next.Moves[i] = normalizeMove(mv)
+	}
+	next.Inherit(cur)
+	if err := checkMoves(&next, next.Moves); err != nil {
+		return nil, catalog.Diff{}, err
+	}
+
+	diff := cur.Diff(&next)
+	if dryRun {
+		next.Version = cur.Version
+		return &next, diff, nil
+	}
+	if m.catalogRepository == nil {
+		return nil, catalog.Diff{}, common.ErrCatalogReadOnly
+	}
+
+	if err := m.catalogRepository.ReplaceCatalog(ctx, &next); err != nil {
+		return nil, catalog.Diff{}, fmt.Errorf("catalogRepository.ReplaceCatalog(): %w", err)
+	}
+	m.refreshWritten(ctx, cur.Name)
+	served, err := m.catalogs.Get(cur.Name)
+	if err != nil {
+		return nil, catalog.Diff{}, err
+	}
+	return served, diff, nil
+}
+
+// checkMoves refuses writes that would leave catalog c in a state the
+// generator cannot use.
+func checkMoves(c *catalog.Catalog, moves []catalog.Move) error {
+	for _, mv := range moves {
+		for level := range mv.Ranges {
+			if !isLevel(level) {
+				return common.InvalidDataError{DataType: "level", Data: level}
+			}
+		}
+	}
+	candidate := *c
+	candidate.Moves = moves
+	return candidate.Validate()
+}
+
+func normalizeMove(mv catalog.Move) catalog.Move {
+	mv.Name = strings.TrimSpace(mv.Name)
+	mv.ID = mv.Key()
+	if mv.Weight == 0 {
+		mv.Weight = 1.0
+	}
+	return mv
+}
+
+func findMove(moves []catalog.Move, name string) int {
+	for i, mv := range moves {
+		if strings.EqualFold(mv.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneMoves(moves []catalog.Move) []catalog.Move {
+	out := make([]catalog.Move, len(moves))
+	copy(out, moves)
+	return out
+}
+
+func isLevel(level string) bool {
+	return level == Beginner || level == Intermediate || level == Advanced
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCatalogRepo struct {
+	stored  map[string]catalog.Catalog
+	created []catalog.Move
+	deleted []string
+	loadErr error
+}
+
+func (m *mockCatalogRepo) LoadCatalog(ctx context.Context, name string) (*catalog.Catalog, error) {
+	if m.loadErr != nil {
+		return nil, m.loadErr
+	}
+	c, ok := m.stored[name]
+	if !ok {
+		return nil, common.ErrUnknownCatalog
+	}
+	return &c, nil
+}
+
+func (m *mockCatalogRepo) CatalogVersions(ctx context.Context) (map[string]int64, error) {
+	out := make(map[string]int64, len(m.stored))
+	for name, c := range m.stored {
+		out[name] = c.Version
+	}
+	return out, nil
+}
+
+func (m *mockCatalogRepo) SeedCatalog(ctx context.Context, c *catalog.Catalog) (bool, error) {
+	if _, ok := m.stored[c.Name]; ok {
+		return false, nil
+	}
+	seeded := *c
+	seeded.Version++
+	m.stored[c.Name] = seeded
+	return true, nil
+}
+
+func (m *mockCatalogRepo) CreateMove(ctx context.Context, catalogName string, mv catalog.Move) error {
+	m.created = append(m.created, mv)
+	c := m.stored[catalogName]
+	c.Moves = append(cloneMoves(c.Moves), mv)
+	c.Version++
+	m.stored[catalogName] = c
+	return nil
+}
+
+func (m *mockCatalogRepo) UpdateMove(ctx context.Context, catalogName, name string, mv catalog.Move) error {
+	return nil
+}
+
+func (m *mockCatalogRepo) DeleteMove(ctx context.Context, catalogName, name string) error {
+	m.deleted = append(m.deleted, name)
+	return nil
+}
+
+func (m *mockCatalogRepo) ReplaceCatalog(ctx context.Context, c *catalog.Catalog) error {
+	replaced := *c
+	replaced.Version = m.stored[c.Name].Version + 1
+	m.stored[c.Name] = replaced
+	return nil
+}
+
+func seededManager(t *testing.T, moves ...catalog.Move) (*CatalogManager, *mockCatalogRepo) {
+	t.Helper()
+	repo := &mockCatalogRepo{stored: map[string]catalog.Catalog{}}
+	seeds := map[string]*catalog.Catalog{"hyrox": {Name: "hyrox", Moves: moves}}
+	registry, err := catalog.NewRegistry("hyrox", seeds)
+	require.NoError(t, err)
+	m := NewCatalogManager(registry, repo)
+	require.NoError(t, m.Seed(context.Background(), seeds))
+	return m, repo
+}
+
+func snapshot(t *testing.T, m *CatalogManager, name string) *catalog.Catalog {
+	t.Helper()
+	c, err := m.Snapshot(context.Background(), name)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCatalogManager_SeedLoadsStoredVersion(t *testing.T) {
+	m, _ := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+	require.Equal(t, int64(1), snapshot(t, m, "").Version)
+}
+
+func TestCatalogManager_CreateMove(t *testing.T) {
+	m, repo := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+
+	mv, err := m.CreateMove(context.Background(), "", catalog.Move{Name: " Row "})
+	require.NoError(t, err)
+	require.Equal(t, "Row", mv.Name)
+	require.InDelta(t, 1.0, mv.Weight, 0)
+	require.Len(t, repo.created, 1)
+
+	snap := snapshot(t, m, "hyrox")
+	require.Equal(t, int64(2), snap.Version)
+	require.Len(t, snap.Moves, 2)
+}
+
+func TestCatalogManager_CreateMove_RefreshFails(t *testing.T) {
+	m, repo := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+	repo.loadErr = errors.New("db fail")
+
+	_, err := m.CreateMove(context.Background(), "", catalog.Move{Name: "Row"})
+	require.NoError(t, err, "the move is stored, only the refresh failed")
+	require.Len(t, repo.created, 1)
+	require.Equal(t, int64(1), snapshot(t, m, "hyrox").Version)
+
+	repo.loadErr = nil
+	require.NoError(t, m.Refresh(context.Background()))
+	require.Equal(t, int64(2), snapshot(t, m, "hyrox").Version)
+}
+
+func TestCatalogManager_CreateMove_Duplicate(t *testing.T) {
+	m, _ := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+
+	_, err := m.CreateMove(context.Background(), "", catalog.Move{Name: "run"})
+	require.ErrorIs(t, err, common.ErrMoveExists)
+}
+
+func TestCatalogManager_CreateMove_UnknownLevel(t *testing.T) {
+	m, _ := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+
+	_, err := m.CreateMove(context.Background(), "", catalog.Move{
+		Name:   "Row",
+		Ranges: map[string]map[string]catalog.Rng{"elite": {"meters": {1, 2}}},
+	})
+	var invalid common.InvalidDataError
+	require.ErrorAs(t, err, &invalid)
+}
+
+func TestCatalogManager_DeleteLastMove(t *testing.T) {
+	m, repo := seededManager(t, catalog.Move{Name: "Run", Weight: 1})
+
+	err := m.DeleteMove(context.Background(), "", "Run")
+	require.ErrorIs(t, err, common.ErrEmptyCatalog)
+	require.Empty(t, repo.deleted)
+}
+
+func TestCatalogManager_ReadOnly(t *testing.T) {
+	registry, err := catalog.NewRegistry("hyrox", map[string]*catalog.Catalog{
+		"hyrox": {Name: "hyrox", Moves: []catalog.Move{{Name: "Run"}}},
+	})
+	require.NoError(t, err)
+	m := NewCatalogManager(registry, nil)
+
+	_, err = m.CreateMove(context.Background(), "", catalog.Move{Name: "Row"})
+	require.ErrorIs(t, err, common.ErrCatalogReadOnly)
+	require.ErrorIs(t, m.DeleteMove(context.Background(), "", "Run"), common.ErrCatalogReadOnly)
+}
+
+func TestCatalogManager_Find(t *testing.T) {
+	m, _ := seededManager(t,
+		catalog.Move{Name: "Row", NeedsOneOf: []string{"rower"}, Tags: []string{"engine"}, Weight: 1,
+			Ranges: map[string]map[string]catalog.Rng{Beginner: {"meters": {400, 900}}, Advanced: {"meters": {700, 1200}}}},
+		catalog.Move{Name: "Sled Push", NeedsOneOf: []string{"sled"}, Tags: []string{"strength"}, Weight: 1,
+			Ranges: map[string]map[string]catalog.Rng{Advanced: {"meters": {20, 50}}}},
+		catalog.Move{Name: "Run", Tags: []string{"engine"}, Weight: 1},
+	)
+
+	_, moves, err := m.Find(context.Background(), CatalogFilter{Equipment: []string{"rower"}})
+	require.NoError(t, err)
+	require.Len(t, moves, 2, "rower moves plus bodyweight ones")
+
+	_, moves, err = m.Find(context.Background(), CatalogFilter{Tag: "ENGINE", Level: Beginner})
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	require.Equal(t, "Row", moves[0].Name)
+	require.Len(t, moves[0].Ranges, 1, "other levels are left out")
+
+	_, _, err = m.Find(context.Background(), CatalogFilter{Level: "elite"})
+	var invalid common.InvalidDataError
+	require.ErrorAs(t, err, &invalid)
+}
+
+func TestCatalogManager_Move_Overlay(t *testing.T) {
+	m, _ := seededManager(t, catalog.Move{Name: "Row", Weight: 1})
+	require.NoError(t, m.catalogs.SetOverlays([]catalog.Overlay{{Tenant: "box", Weights: map[string]float64{"row": 5}}}))
+
+	_, mv, err := m.Move(context.Background(), "", "box", "row")
+	require.NoError(t, err)
+	require.InDelta(t, 5.0, mv.Weight, 0)
+
+	_, mv, err = m.Move(context.Background(), "", "", "row")
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, mv.Weight, 0)
+}
+
+func TestCatalogManager_NamedCatalogs(t *testing.T) {
+	repo := &mockCatalogRepo{stored: map[string]catalog.Catalog{}}
+	seeds := map[string]*catalog.Catalog{
+		"hyrox":   {Name: "hyrox", Moves: []catalog.Move{{Name: "Sled Push", Weight: 1}}},
+		"running": {Name: "running", Levels: []string{Beginner}, Moves: []catalog.Move{{Name: "Easy Run", Weight: 1}}},
+	}
+	registry, err := catalog.NewRegistry("hyrox", seeds)
+	require.NoError(t, err)
+	m := NewCatalogManager(registry, repo)
+	require.NoError(t, m.Seed(context.Background(), seeds))
+	require.Equal(t, []string{"hyrox", "running"}, m.Names(context.Background()))
+
+	_, err = m.CreateMove(context.Background(), "running", catalog.Move{Name: "Tempo Run"})
+	require.NoError(t, err)
+	require.Len(t, snapshot(t, m, "running").Moves, 2)
+	require.Len(t, snapshot(t, m, "hyrox").Moves, 1, "other catalogs are untouched")
+
+	_, err = m.CreateMove(context.Background(), "running", catalog.Move{
+		Name:   "Intervals",
+		Ranges: map[string]map[string]catalog.Rng{Advanced: {"meters": {400, 800}}},
+	})
+	require.ErrorIs(t, err, common.ErrInvalidCatalog, "running has no advanced level")
+
+	_, _, err = m.Find(context.Background(), CatalogFilter{Catalog: "running", Level: Advanced})
+	require.ErrorIs(t, err, common.ErrLevelUnavailable)
+
+	_, err = m.Snapshot(context.Background(), "yoga")
+	require.ErrorIs(t, err, common.ErrUnknownCatalog)
+}
+
+func TestCatalogManager_Import(t *testing.T) {
+	m, repo := seededManager(t,
+		catalog.Move{Name: "Row", Weight: 1, NeedsOneOf: []string{"rower"}},
+		catalog.Move{Name: "Run", Weight: 1},
+	)
+	imported := &catalog.Catalog{Moves: []catalog.Move{
+		{Name: "Row", Weight: 2, NeedsOneOf: []string{"rower"}},
+		{Name: "Burpees"},
+	}}
+
+	c, diff, err := m.Import(context.Background(), "", imported, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"burpees"}, diff.Added)
+	require.Equal(t, []string{"run"}, diff.Removed)
+	require.Equal(t, []string{"row"}, diff.Changed)
+	require.Equal(t, int64(1), c.Version, "dry run keeps the served version")
+	require.Len(t, snapshot(t, m, "hyrox").Moves, 2)
+
+	c, _, err = m.Import(context.Background(), "", imported, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), c.Version)
+	require.Equal(t, int64(2), repo.stored["hyrox"].Version)
+	require.Equal(t, "Burpees", snapshot(t, m, "hyrox").Moves[1].Name)
+}
+
+func TestCatalogManager_ImportReadOnly(t *testing.T) {
+	registry, err := catalog.NewRegistry("hyrox", map[string]*catalog.Catalog{
+		"hyrox": {Name: "hyrox", Moves: []catalog.Move{{Name: "Run", Weight: 1}}},
+	})
+	require.NoError(t, err)
+	m := NewCatalogManager(registry, nil)
+	imported := &catalog.Catalog{Moves: []catalog.Move{{Name: "Burpees", Weight: 1}}}
+
+	_, diff, err := m.Import(context.Background(), "", imported, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"burpees"}, diff.Added)
+
+	_, _, err = m.Import(context.Background(), "", imported, false)
+	require.ErrorIs(t, err, common.ErrCatalogReadOnly)
+}
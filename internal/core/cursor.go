@@ -0,0 +1,62 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+// cursor is the opaque position handed to clients to page through a listing:
+// the key of the wod the page starts after, or ends before with Prev. Filter
+// fingerprints the listing so a cursor is not replayed on another one.
+type cursor struct {
+	Prev      bool      `json:"p,omitempty"`
+	Filter    string    `json:"f"`
+	CreatedAt time.Time `json:"t"`
+	Duration  int       `json:"d,omitempty"`
+	ID        uuid.UUID `json:"i"`
+}
+
+func encodeCursor(fingerprint string, key repository.WodKey, prev bool) string {
+	raw, _ := json.Marshal(cursor{ //nolint:errchkjson // plain fields
+		Prev:      prev,
+		Filter:    fingerprint,
+		CreatedAt: key.CreatedAt,
+		Duration:  key.DurationMin,
+		ID:        key.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s, fingerprint string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", common.ErrWodFilter)
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", common.ErrWodFilter)
+	}
+	if c.Filter != fingerprint {
+		return cursor{}, fmt.Errorf("%w: cursor of another listing, the filters or sort changed", common.ErrWodFilter)
+	}
+	return c, nil
+}
+
+func (c cursor) key() repository.WodKey {
+	return repository.WodKey{CreatedAt: c.CreatedAt, DurationMin: c.Duration, ID: c.ID}
+}
+
+// fingerprint identifies the listing of f, filters and sort.
+func fingerprint(f repository.WodFilter) string {
+	raw, _ := json.Marshal(f) //nolint:errchkjson // plain fields
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
@@ -2,8 +2,10 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,65 +22,178 @@ const (
 	Advanced     string = "advanced"
 	MinDuration         = 15
 	MaxDuration         = 120
+
+	// MaxIdempotencyKey is the longest Idempotency-Key accepted.
+	MaxIdempotencyKey = 255
+	// replayCandidates bounds the stored wods compared with a request.
+	replayCandidates = 20
 )
 
+// Params of a generation. An empty Catalog picks the default catalog and an
+// empty Seed a random one. The overlay of Tenant, if any, is applied to the
+// catalog before the moves are picked. Owner is stored with the wod.
+//
+// A request with an IdempotencyKey, or with a Seed, replays the wod Owner
+// already stored for the same parameters instead of generating a new one.
 type Params struct {
-	Level       string
-	DurationMin int
-	Equipment   []string
-	Seed        string
+	Catalog        string
+	Tenant         string
+	Owner          string
+	Level          string
+	DurationMin    int
+	Equipment      []string
+	Seed           string
+	IdempotencyKey string
 }
 
 type WodGeneratorInterface interface {
-	Generate(ctx context.Context, level string, durationMin int, equipment []string, seed *string) (models.Wod, error)
+	// Generate returns the wod and whether it was replayed from a previous
+	// request rather than newly created.
+	Generate(ctx context.Context, p Params) (models.Wod, bool, error)
+	// GenerateBatch generates a wod for each of ps, see BatchItem.
+	GenerateBatch(ctx context.Context, ps []Params) ([]BatchItem, error)
 }
 
 type WodGenerator struct {
 	wodRepository repository.WodRepositoryInterface
-	catalog       *catalog.Catalog
+	catalogs      *catalog.Registry
 }
 
-func NewWodGenerator(catalog *catalog.Catalog, wodRepository repository.WodRepositoryInterface) *WodGenerator {
-	return &WodGenerator{catalog: catalog, wodRepository: wodRepository}
+func NewWodGenerator(catalogs *catalog.Registry, wodRepository repository.WodRepositoryInterface) *WodGenerator {
+	return &WodGenerator{catalogs: catalogs, wodRepository: wodRepository}
 }
 
-func (w *WodGenerator) Generate(ctx context.Context, level string, durationMin int, equipment []string, seed *string) (models.Wod, error) {
-	lv, parsedSeed, err := validateInfo(level, durationMin, seed, w.catalog.Moves)
+func (w *WodGenerator) Generate(ctx context.Context, p Params) (models.Wod, bool, error) {
+	d, err := w.draft(ctx, p)
+	if err != nil || d.replayed {
+		return d.wod, d.replayed, err
+	}
+
+	savedWod, err := w.wodRepository.SaveWod(ctx, d.wod)
+	if errors.Is(err, common.ErrWodExists) && p.IdempotencyKey != "" {
+		// a concurrent retry stored it first.
+		stored, found, err := w.replay(ctx, d.p, d.c)
+		if err != nil || found {
+			return stored, found, err
+		}
+	}
 	if err != nil {
-		return models.Wod{}, fmt.Errorf("%w", err)
+		return models.Wod{}, false, fmt.Errorf("wodRepository.SaveWod(): %w", err)
 	}
 
-	wod, err := buildWod(lv, durationMin, equipment, parsedSeed, w.catalog.Moves)
+	return savedWod, false, nil
+}
+
+// draft is the wod generated for p, validated, from catalog c. A replayed
+// wod was stored by a previous request, the others are not stored yet.
+type draft struct {
+	p        Params
+	c        *catalog.Catalog
+	wod      models.Wod
+	replayed bool
+}
+
+func (w *WodGenerator) draft(ctx context.Context, p Params) (draft, error) {
+	if len(p.IdempotencyKey) > MaxIdempotencyKey {
+		return draft{}, common.ErrIdempotencyKey
+	}
+
+	// one snapshot for the whole request, a reload may swap the store meanwhile.
+	c, err := w.catalogs.Resolve(p.Catalog, p.Tenant)
 	if err != nil {
-		return models.Wod{}, fmt.Errorf("buildWod(): %w", err)
+		return draft{}, err
 	}
 
-	savedWod, err := w.wodRepository.SaveWod(ctx, wod)
+	lv, parsedSeed, err := validateInfo(p.Level, p.DurationMin, p.Seed, c)
 	if err != nil {
-		return models.Wod{}, fmt.Errorf("wodRepository.SaveWod(): %w", err)
+		return draft{}, fmt.Errorf("%w", err)
+	}
+	p.Level = lv
+
+	stored, found, err := w.replay(ctx, p, c)
+	if err != nil {
+		return draft{}, err
+	}
+	if found {
+		return draft{p: p, c: c, wod: stored, replayed: true}, nil
 	}
 
-	return savedWod, nil
+	wod, err := buildWod(lv, p.DurationMin, p.Equipment, parsedSeed, c.Moves)
+	if err != nil {
+		return draft{}, fmt.Errorf("buildWod(): %w", err)
+	}
+	wod.Catalog = c.Name
+	wod.CatalogVersion = c.Version
+	wod.CatalogHash = c.Hash()
+	wod.OwnerSub = p.Owner
+	wod.IdempotencyKey = p.IdempotencyKey
+	return draft{p: p, c: c, wod: wod}, nil
+}
+
+// replay finds the wod a previous request with the same parameters stored:
+// the one generated with the idempotency key, else the one with the seed.
+// A key already used with other parameters is common.ErrIdempotencyConflict.
+func (w *WodGenerator) replay(ctx context.Context, p Params, c *catalog.Catalog) (models.Wod, bool, error) {
+	f := repository.WodFilter{Owner: p.Owner}
+	switch {
+	case p.IdempotencyKey != "":
+		f.IdempotencyKey = p.IdempotencyKey
+	case p.Seed != "":
+		f.Seed = p.Seed
+		f.Level = p.Level
+		f.MinDuration = p.DurationMin
+		f.MaxDuration = p.DurationMin
+		f.Catalog = c.Name
+		f.CatalogHash = c.Hash()
+		f.Equipment = p.Equipment
+	default:
+		return models.Wod{}, false, nil
+	}
+
+	wods, err := w.wodRepository.ListWods(ctx, f, repository.WodPage{Limit: replayCandidates})
+	if err != nil {
+		return models.Wod{}, false, fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	for _, wod := range wods {
+		if sameParams(wod, p, c) {
+			return wod, true, nil
+		}
+	}
+	if p.IdempotencyKey != "" && len(wods) > 0 {
+		return models.Wod{}, false, common.ErrIdempotencyConflict
+	}
+	return models.Wod{}, false, nil
 }
 
-func validateInfo(level string, durationMin int, seed *string, moves []catalog.Move) (string, string, error) {
+// sameParams reports whether wod was generated from p. The order of the
+// equipment matters, it is part of the seed.
+func sameParams(wod models.Wod, p Params, c *catalog.Catalog) bool {
+	return wod.Level == p.Level &&
+		wod.DurationMin == p.DurationMin &&
+		wod.Catalog == c.Name &&
+		(p.Seed == "" || wod.Seed == p.Seed) &&
+		slices.Equal(wod.Equipment, p.Equipment)
+}
+
+func validateInfo(level string, durationMin int, seed string, c *catalog.Catalog) (string, string, error) {
 	lv := strings.ToLower(level)
-	if lv != Beginner && lv != Intermediate && lv != Advanced {
+	if !isLevel(lv) {
 		return "", "", common.InvalidDataError{DataType: "level", Data: lv}
 	}
+	if !c.HasLevel(lv) {
+		return "", "", fmt.Errorf("%w: %s has no %s level", common.ErrLevelUnavailable, c.Name, lv)
+	}
 
 	if durationMin < MinDuration || durationMin > MaxDuration {
 		return "", "", common.ErrDuration
 	}
 
-	if len(moves) == 0 {
+	if len(c.Moves) == 0 {
 		return "", "", common.ErrEmptyCatalog
 	}
 
-	var parsedSeed string
-	if seed != nil && *seed != "" {
-		parsedSeed = *seed
-	} else {
+	parsedSeed := seed
+	if parsedSeed == "" {
 		parsedSeed = uuid.NewString()
 	}
 
@@ -105,7 +220,7 @@ func buildWod(level string, durationMin int, equipment []string, parsedSeed stri
 		m := weightedPick(rnd, avail, last)
 		last = m.Name
 		params := pickParams(rnd, m.Ranges[level])
-		blocks = append(blocks, models.Block{Name: m.Name, Params: params})
+		blocks = append(blocks, models.Block{ID: m.Key(), Name: m.Name, Params: params})
 	}
 
 	return models.Wod{
@@ -3,17 +3,31 @@ package core
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
 	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 type mockWodRepo struct {
+	// mu guards filter and page, the drafts of a batch list concurrently.
+	mu    sync.Mutex
 	saved models.Wod
-	err   error
+	wods  []models.Wod
+	// batches are the wods of each SaveWods call.
+	batches [][]models.Wod
+	filter  repository.WodFilter
+	page    repository.WodPage
+	err     error
+	// annotations of the subject the test annotates as.
+	annotations map[uuid.UUID]models.Annotations
 }
 
 func (m *mockWodRepo) SaveWod(ctx context.Context, w models.Wod) (models.Wod, error) {
@@ -24,22 +38,90 @@ func (m *mockWodRepo) SaveWod(ctx context.Context, w models.Wod) (models.Wod, er
 	return w, nil
 }
 
-func (m *mockWodRepo) ListWods(ctx context.Context, limit, offset int) ([]models.Wod, error) {
-	return []models.Wod{m.saved}, nil
+func (m *mockWodRepo) SaveWods(ctx context.Context, wods []models.Wod) ([]models.Wod, error) {
+	m.batches = append(m.batches, wods)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return wods, nil
+}
+
+func (m *mockWodRepo) UpsertWods(ctx context.Context, wods []models.Wod) error {
+	m.batches = append(m.batches, wods)
+	return m.err
+}
+
+func (m *mockWodRepo) ListWods(ctx context.Context, f repository.WodFilter, p repository.WodPage) ([]models.Wod, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.filter, m.page = f, p
+	if m.wods == nil {
+		return []models.Wod{m.saved}, nil
+	}
+	return m.wods[:min(p.Limit, len(m.wods))], nil
+}
+
+func (m *mockWodRepo) CountWods(ctx context.Context, f repository.WodFilter) (int, error) {
+	return len(m.wods), nil
+}
+
+func (m *mockWodRepo) GetWod(ctx context.Context, id uuid.UUID) (models.Wod, error) {
+	if m.err != nil {
+		return models.Wod{}, m.err
+	}
+	if m.saved.ID != id {
+		return models.Wod{}, common.ErrWodNotFound
+	}
+	return m.saved, nil
+}
+
+func (m *mockWodRepo) GetAnnotations(ctx context.Context, subject string, ids []uuid.UUID) (map[uuid.UUID]models.Annotations, error) {
+	out := map[uuid.UUID]models.Annotations{}
+	for _, id := range ids {
+		if a, ok := m.annotations[id]; ok {
+			out[id] = a
+		}
+	}
+	return out, nil
+}
+
+func (m *mockWodRepo) SaveAnnotations(ctx context.Context, subject string, id uuid.UUID, a models.Annotations) error {
+	if m.annotations == nil {
+		m.annotations = map[uuid.UUID]models.Annotations{}
+	}
+	m.annotations[id] = a
+	return nil
+}
+
+func newRegistry(t *testing.T, catalogs ...*catalog.Catalog) *catalog.Registry {
+	t.Helper()
+	set := make(map[string]*catalog.Catalog, len(catalogs))
+	for _, c := range catalogs {
+		set[c.Name] = c
+	}
+	r, err := catalog.NewRegistry(catalogs[0].Name, set)
+	require.NoError(t, err)
+	return r
 }
 
 func TestValidateInfo_InvalidLevel(t *testing.T) {
-	_, _, err := validateInfo("expert", 30, nil, []catalog.Move{{Name: "Run"}})
+	_, _, err := validateInfo("expert", 30, "", &catalog.Catalog{Moves: []catalog.Move{{Name: "Run"}}})
 	require.Error(t, err)
 }
 
+func TestValidateInfo_LevelNotOffered(t *testing.T) {
+	c := &catalog.Catalog{Name: "running", Levels: []string{"beginner"}, Moves: []catalog.Move{{Name: "Run"}}}
+	_, _, err := validateInfo("advanced", 30, "", c)
+	require.ErrorIs(t, err, common.ErrLevelUnavailable)
+}
+
 func TestValidateInfo_InvalidDuration(t *testing.T) {
-	_, _, err := validateInfo("beginner", 5, nil, []catalog.Move{{Name: "Run"}})
+	_, _, err := validateInfo("beginner", 5, "", &catalog.Catalog{Moves: []catalog.Move{{Name: "Run"}}})
 	require.Error(t, err)
 }
 
 func TestValidateInfo_EmptyCatalog(t *testing.T) {
-	_, _, err := validateInfo("beginner", 30, nil, []catalog.Move{})
+	_, _, err := validateInfo("beginner", 30, "", &catalog.Catalog{})
 	require.Error(t, err)
 }
 
@@ -55,13 +137,58 @@ func TestGenerate_Success(t *testing.T) {
 	}
 
 	repo := &mockWodRepo{}
-	gen := NewWodGenerator(&catalog.Catalog{Moves: moves}, repo)
+	gen := NewWodGenerator(newRegistry(t, &catalog.Catalog{Name: "hyrox", Moves: moves}), repo)
 
-	wod, err := gen.Generate(context.Background(), "beginner", 30, []string{}, nil)
+	wod, _, err := gen.Generate(context.Background(), Params{Owner: "alice", Level: "beginner", DurationMin: 30, Equipment: []string{}})
 	require.NoError(t, err)
 	require.Equal(t, "beginner", wod.Level)
+	require.Equal(t, "alice", repo.saved.OwnerSub)
 	require.NotEmpty(t, wod.Blocks)
 	require.Equal(t, repo.saved.ID, wod.ID)
+	require.Equal(t, "hyrox", wod.Catalog)
+}
+
+func TestGenerate_NamedCatalog(t *testing.T) {
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{
+		Name: "Sled Push", Weight: 1,
+		Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {10, 20}}},
+	}}}
+	running := &catalog.Catalog{Name: "running", Version: 3, Moves: []catalog.Move{{
+		Name: "Easy Run", Weight: 1,
+		Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {800, 1600}}},
+	}}}
+
+	repo := &mockWodRepo{}
+	gen := NewWodGenerator(newRegistry(t, hyrox, running), repo)
+
+	wod, _, err := gen.Generate(context.Background(), Params{Catalog: "Running", Level: "beginner", DurationMin: 30})
+	require.NoError(t, err)
+	require.Equal(t, "running", wod.Catalog)
+	require.Equal(t, int64(3), wod.CatalogVersion)
+	require.Equal(t, running.Hash(), wod.CatalogHash)
+	for _, b := range wod.Blocks {
+		require.Equal(t, "Easy Run", b.Name)
+	}
+
+	_, _, err = gen.Generate(context.Background(), Params{Catalog: "yoga", Level: "beginner", DurationMin: 30})
+	require.ErrorIs(t, err, common.ErrUnknownCatalog)
+}
+
+func TestGenerate_TenantOverlay(t *testing.T) {
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{Name: "Sled Push", Weight: 1, Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {10, 20}}}},
+		{Name: "Row", Weight: 1, Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {100, 200}}}},
+	}}
+	registry := newRegistry(t, hyrox)
+	require.NoError(t, registry.SetOverlays([]catalog.Overlay{{Tenant: "box", Disable: []string{"sled push"}}}))
+
+	gen := NewWodGenerator(registry, &mockWodRepo{})
+	wod, _, err := gen.Generate(context.Background(), Params{Tenant: "box", Level: "beginner", DurationMin: 60})
+	require.NoError(t, err)
+	for _, b := range wod.Blocks {
+		require.Equal(t, "Row", b.Name)
+	}
+	require.NotEqual(t, hyrox.Hash(), wod.CatalogHash, "the hash is the one of the catalog the tenant sees")
 }
 
 func TestGenerate_SaveError(t *testing.T) {
@@ -76,14 +203,217 @@ func TestGenerate_SaveError(t *testing.T) {
 	}
 
 	repo := &mockWodRepo{err: errors.New("db down")}
-	gen := NewWodGenerator(&catalog.Catalog{Moves: moves}, repo)
+	gen := NewWodGenerator(newRegistry(t, &catalog.Catalog{Name: "hyrox", Moves: moves}), repo)
 
-	_, err := gen.Generate(context.Background(), "beginner", 30, []string{}, nil)
+	_, _, err := gen.Generate(context.Background(), Params{Level: "beginner", DurationMin: 30, Equipment: []string{}})
 	require.Error(t, err)
 }
 
+func TestGenerate_IdempotencyKey(t *testing.T) {
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{Name: "Run", Weight: 1, Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {100, 200}}}},
+	}}
+	repo := &mockWodRepo{wods: []models.Wod{}}
+	gen := NewWodGenerator(newRegistry(t, hyrox), repo)
+	p := Params{Owner: "alice", Level: "Beginner", DurationMin: 30, IdempotencyKey: "retry-1"}
+
+	first, replayed, err := gen.Generate(context.Background(), p)
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.Equal(t, "retry-1", repo.saved.IdempotencyKey)
+
+	repo.wods = []models.Wod{first}
+	again, replayed, err := gen.Generate(context.Background(), p)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, first.ID, again.ID)
+	require.Equal(t, repository.WodFilter{Owner: "alice", IdempotencyKey: "retry-1"}, repo.filter)
+
+	p.DurationMin = 45
+	_, _, err = gen.Generate(context.Background(), p)
+	require.ErrorIs(t, err, common.ErrIdempotencyConflict)
+
+	p.IdempotencyKey = strings.Repeat("k", MaxIdempotencyKey+1)
+	_, _, err = gen.Generate(context.Background(), p)
+	require.ErrorIs(t, err, common.ErrIdempotencyKey)
+}
+
+func TestGenerate_SeededReplay(t *testing.T) {
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{Name: "Run", Weight: 1, Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {100, 200}}}},
+	}}
+	stored := models.Wod{ID: uuid.New(), Seed: "s1", Level: "beginner", DurationMin: 30,
+		Equipment: []string{"rower", "sled"}, Catalog: "hyrox", CatalogHash: hyrox.Hash(), OwnerSub: "alice"}
+	repo := &mockWodRepo{wods: []models.Wod{stored}}
+	gen := NewWodGenerator(newRegistry(t, hyrox), repo)
+
+	p := Params{Owner: "alice", Level: "beginner", DurationMin: 30, Equipment: []string{"rower", "sled"}, Seed: "s1"}
+	wod, replayed, err := gen.Generate(context.Background(), p)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, stored.ID, wod.ID)
+	require.Equal(t, repository.WodFilter{
+		Owner: "alice", Seed: "s1", Level: "beginner", MinDuration: 30, MaxDuration: 30,
+		Catalog: "hyrox", CatalogHash: hyrox.Hash(), Equipment: []string{"rower", "sled"},
+	}, repo.filter)
+
+	// the equipment order is part of the seed, another order is another wod.
+	p.Equipment = []string{"sled", "rower"}
+	wod, replayed, err = gen.Generate(context.Background(), p)
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.NotEqual(t, stored.ID, wod.ID)
+}
+
 func TestBuildWod_ErrNoMoves(t *testing.T) {
 	moves := []catalog.Move{}
 	_, err := buildWod("beginner", 20, []string{}, "seed", moves)
 	require.ErrorIs(t, err, common.ErrNoMoves)
 }
+
+func TestList_CatalogVersionDefaultsToDefaultCatalog(t *testing.T) {
+	repo := &mockWodRepo{}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+
+	_, err := list.List(context.Background(), repository.WodFilter{CatalogVersion: 3}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Catalog: "hyrox", CatalogVersion: 3}, repo.filter)
+
+	_, err = list.List(context.Background(), repository.WodFilter{Catalog: " Running ", CatalogHash: "ABC"}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Catalog: "running", CatalogHash: "abc"}, repo.filter)
+}
+
+func TestGet_Ownership(t *testing.T) {
+	repo := &mockWodRepo{saved: models.Wod{ID: uuid.New(), OwnerSub: "alice"}}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+
+	wod, err := list.Get(context.Background(), repo.saved.ID, repository.WodFilter{Owner: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, repo.saved.ID, wod.ID)
+
+	_, err = list.Get(context.Background(), repo.saved.ID, repository.WodFilter{Owner: "bob"})
+	require.ErrorIs(t, err, common.ErrWodNotFound, "other owners' wods are not disclosed")
+
+	_, err = list.Get(context.Background(), repo.saved.ID, repository.WodFilter{AnyOwner: true})
+	require.NoError(t, err)
+
+	_, err = list.Get(context.Background(), uuid.New(), repository.WodFilter{AnyOwner: true})
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+}
+
+func TestList_Filters(t *testing.T) {
+	repo := &mockWodRepo{}
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{ID: "sled-push", Name: "Sled Push"}}}
+	list := NewWodList(newRegistry(t, hyrox), repo, repo)
+
+	_, err := list.List(context.Background(), repository.WodFilter{Level: "Beginner", MoveName: "sled push"}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, "beginner", repo.filter.Level)
+	require.Equal(t, "sled-push", repo.filter.MoveID)
+	require.Equal(t, "Sled Push", repo.filter.MoveName)
+
+	_, err = list.List(context.Background(), repository.WodFilter{MoveName: "Assault Bike"}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, "Assault Bike", repo.filter.MoveID, "moves missing from the catalog match as given")
+
+	_, err = list.List(context.Background(), repository.WodFilter{Level: "expert"}, Page{Limit: 10})
+	require.ErrorAs(t, err, &common.InvalidDataError{})
+	_, err = list.List(context.Background(), repository.WodFilter{MinDuration: 40, MaxDuration: 20}, Page{Limit: 10})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+	now := time.Now()
+	_, err = list.List(context.Background(), repository.WodFilter{CreatedAfter: now, CreatedBefore: now}, Page{Limit: 10})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+	_, err = list.List(context.Background(), repository.WodFilter{Sort: "level"}, Page{Limit: 10})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+}
+
+func TestList_SearchMoves(t *testing.T) {
+	repo := &mockWodRepo{}
+	hyrox := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{ID: "sled-push", Name: "Sled Push"}, {ID: "row", Name: "Row"}}}
+	list := NewWodList(newRegistry(t, hyrox), repo, repo)
+	meters := 2000.0
+
+	all := []repository.MoveMatch{
+		{Name: " sled push "},
+		{Name: "row", Params: map[string]repository.ParamRange{" Meters ": {Min: &meters}}},
+	}
+	_, err := list.List(context.Background(), repository.WodFilter{
+		AllMoves: all,
+		NoMoves:  []repository.MoveMatch{{Name: "Assault Bike"}},
+	}, Page{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []repository.MoveMatch{
+		{ID: "sled-push", Name: "Sled Push", Params: map[string]repository.ParamRange{}},
+		{ID: "row", Name: "Row", Params: map[string]repository.ParamRange{"meters": {Min: &meters}}},
+	}, repo.filter.AllMoves)
+	require.Equal(t, "Assault Bike", repo.filter.NoMoves[0].ID, "moves missing from the catalog match as given")
+	require.Equal(t, " sled push ", all[0].Name, "the moves of the caller are left as they are")
+}
+
+func TestList_SearchMoves_Invalid(t *testing.T) {
+	repo := &mockWodRepo{}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+	low, high := 10.0, 5.0
+
+	for name, m := range map[string]repository.MoveMatch{
+		"empty move":    {Name: " "},
+		"bad param":     {Name: "Row", Params: map[string]repository.ParamRange{"params.meters": {Min: &low}}},
+		"no bound":      {Name: "Row", Params: map[string]repository.ParamRange{"meters": {}}},
+		"min above max": {Name: "Row", Params: map[string]repository.ParamRange{"meters": {Min: &low, Max: &high}}},
+	} {
+		_, err := list.List(context.Background(), repository.WodFilter{AnyMoves: []repository.MoveMatch{m}}, Page{Limit: 10})
+		require.ErrorIs(t, err, common.ErrWodFilter, name)
+	}
+
+	_, err := list.List(context.Background(), repository.WodFilter{
+		AllMoves: make([]repository.MoveMatch, MaxSearchMoves+1),
+	}, Page{Limit: 10})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+}
+
+func TestList_Cursors(t *testing.T) {
+	wods := make([]models.Wod, 3)
+	for i := range wods {
+		wods[i] = models.Wod{ID: uuid.New(), CreatedAt: time.Now().UTC().Add(-time.Duration(i) * time.Minute)}
+	}
+	repo := &mockWodRepo{wods: wods}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+	f := repository.WodFilter{Owner: "alice"}
+
+	first, err := list.List(context.Background(), f, Page{Limit: 2, Total: true})
+	require.NoError(t, err)
+	require.Len(t, first.Wods, 2)
+	require.Equal(t, 3, repo.page.Limit, "one more to know whether a page follows")
+	require.NotEmpty(t, first.NextCursor)
+	require.Empty(t, first.PrevCursor)
+	require.Equal(t, 3, *first.Total)
+
+	next, err := list.List(context.Background(), f, Page{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, wods[1].ID, repo.page.After.ID)
+	require.True(t, wods[1].CreatedAt.Equal(repo.page.After.CreatedAt))
+	require.NotEmpty(t, next.PrevCursor)
+	require.Nil(t, next.Total)
+
+	_, err = list.List(context.Background(), f, Page{Limit: 2, Cursor: next.PrevCursor})
+	require.NoError(t, err)
+	require.Equal(t, wods[0].ID, repo.page.Before.ID)
+
+	_, err = list.List(context.Background(), repository.WodFilter{Owner: "bob"}, Page{Limit: 2, Cursor: first.NextCursor})
+	require.ErrorIs(t, err, common.ErrWodFilter, "cursors are bound to their listing")
+	_, err = list.List(context.Background(), f, Page{Limit: 2, Cursor: "%%%"})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+	_, err = list.List(context.Background(), f, Page{Limit: 2, Offset: 2, Cursor: first.NextCursor})
+	require.ErrorIs(t, err, common.ErrWodFilter)
+}
+
+func TestList_PageBounds(t *testing.T) {
+	repo := &mockWodRepo{}
+	list := NewWodList(newRegistry(t, &catalog.Catalog{Name: "hyrox"}), repo, repo)
+
+	for _, p := range []Page{{Limit: 0}, {Limit: -1}, {Limit: MaxListLimit + 1}, {Limit: 10, Offset: -1}} {
+		_, err := list.List(context.Background(), repository.WodFilter{}, p)
+		require.ErrorIs(t, err, common.ErrWodFilter, "%+v", p)
+	}
+}
@@ -0,0 +1,332 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Export and import formats.
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+const (
+	// exportPage is the rows an export reads from storage at once.
+	exportPage = 500
+	// importBatch is the rows an import stores in a transaction.
+	importBatch = 500
+	// maxRowErrors bounds the row errors an import reports.
+	maxRowErrors = 100
+)
+
+type HistoryInterface interface {
+	ExportWods(ctx context.Context, f repository.WodFilter, q ExportQuery) (Exporter, error)
+	ExportResults(ctx context.Context, f repository.WodFilter, q ExportQuery) (Exporter, error)
+	ImportWods(ctx context.Context, r io.Reader, q ImportQuery) (ImportReport, error)
+}
+
+// ExportQuery is the format of an export and its span: the wods created,
+// or the results completed, from From (inclusive) to To (exclusive).
+type ExportQuery struct {
+	Format string
+	From   time.Time
+	To     time.Time
+}
+
+// Exporter writes the rows of an export to w as they are read from storage,
+// newest first, and returns how many it wrote.
+type Exporter func(w io.Writer) (int, error)
+
+// ImportQuery is the format of an import and whose wods it may hold: the
+// rows without an owner get Owner, the rows of another owner are refused
+// unless AnyOwner. With DryRun the rows are validated, none is stored.
+type ImportQuery struct {
+	Format   string
+	Owner    string
+	AnyOwner bool
+	DryRun   bool
+}
+
+// ImportReport counts the rows read, stored and refused by an import, with
+// the errors of the first refused rows.
+type ImportReport struct {
+	Rows     int
+	Imported int
+	Failed   int
+	Errors   []RowError
+}
+
+// RowError is why the row on Line was refused, an ErrInvalidRow or
+// ErrWodExists.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+// History moves the wods and results of subjects in and out as JSON lines
+// or CSV.
+type History struct {
+	wodRepository    repository.WodRepositoryInterface
+	resultRepository repository.ResultRepositoryInterface
+}
+
+func NewHistory(wods repository.WodRepositoryInterface, results repository.ResultRepositoryInterface) *History {
+	return &History{wodRepository: wods, resultRepository: results}
+}
+
+func (q ExportQuery) validate() error {
+	if q.Format != FormatJSONL && q.Format != FormatCSV {
+		return fmt.Errorf("%w: format %q, jsonl or csv", common.ErrExportFilter, q.Format)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return fmt.Errorf("%w: from must be before to", common.ErrExportFilter)
+	}
+	return nil
+}
+
+// ExportWods exports the wods of f created in the span of q.
+func (h *History) ExportWods(ctx context.Context, f repository.WodFilter, q ExportQuery) (Exporter, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	f.CreatedAfter, f.CreatedBefore = q.From, q.To
+	f.Sort, f.Ascending = repository.WodSortCreatedAt, false
+
+	return func(w io.Writer) (int, error) {
+		rw, err := newRowWriter(w, q.Format, wodHeader)
+		if err != nil {
+			return 0, err
+		}
+		page := repository.WodPage{Limit: exportPage}
+		n := 0
+		for {
+			wods, err := h.wodRepository.ListWods(ctx, f, page)
+			if err != nil {
+				return n, fmt.Errorf("wodRepository.ListWods(): %w", err)
+			}
+			for _, wod := range wods {
+				if err := rw.write(wod, func() ([]string, error) { return wodRecord(wod) }); err != nil {
+					return n, err
+				}
+				n++
+			}
+			if err := rw.flush(); err != nil || len(wods) < exportPage {
+				return n, err
+			}
+			key := repository.KeyOf(wods[len(wods)-1])
+			page.After = &key
+		}
+	}, nil
+}
+
+// ExportResults exports the results logged by the owner of f, or by anyone
+// with AnyOwner, completed in the span of q.
+func (h *History) ExportResults(ctx context.Context, f repository.WodFilter, q ExportQuery) (Exporter, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	rf := repository.ResultFilter{CompletedAfter: q.From, CompletedBefore: q.To}
+	if !f.AnyOwner {
+		rf.Subject = f.Owner
+	}
+
+	return func(w io.Writer) (int, error) {
+		rw, err := newRowWriter(w, q.Format, resultHeader)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		for {
+			results, err := h.resultRepository.ListResults(ctx, rf, exportPage, 0)
+			if err != nil {
+				return n, fmt.Errorf("resultRepository.ListResults(): %w", err)
+			}
+			for _, res := range results {
+				if err := rw.write(res, func() ([]string, error) { return resultRecord(res) }); err != nil {
+					return n, err
+				}
+				n++
+			}
+			if err := rw.flush(); err != nil || len(results) < exportPage {
+				return n, err
+			}
+			last := results[len(results)-1]
+			rf.After = &repository.ResultKey{CompletedAt: last.CompletedAt, ID: last.ID}
+		}
+	}, nil
+}
+
+// ImportWods validates the rows of r and stores them by batches, replacing
+// the wods stored with their IDs. The invalid rows are reported and
+// skipped; a batch failing to store stops the import, the batches before it
+// stay stored and the import can run again.
+func (h *History) ImportWods(ctx context.Context, r io.Reader, q ImportQuery) (ImportReport, error) {
+	rows, err := newRowReader(r, q.Format)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	report, err := h.importRows(ctx, rows, q)
+	// the rows refused storing come after the invalid ones.
+	slices.SortStableFunc(report.Errors, func(a, b RowError) int { return cmp.Compare(a.Line, b.Line) })
+	return report, err
+}
+
+func (h *History) importRows(ctx context.Context, rows rowReader, q ImportQuery) (ImportReport, error) {
+	var report ImportReport
+	refuse := func(line int, err error) {
+		report.Failed++
+		if len(report.Errors) < maxRowErrors {
+			report.Errors = append(report.Errors, RowError{Line: line, Err: err})
+		}
+	}
+	batch := make([]importRow, 0, importBatch)
+	for {
+		w, line, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, common.ErrInvalidRow) {
+			return report, err
+		}
+		report.Rows++
+		if err == nil {
+			err = checkRow(&w, q)
+		}
+		if err != nil {
+			refuse(line, err)
+			continue
+		}
+		if batch = append(batch, importRow{line: line, wod: w}); len(batch) == importBatch {
+			if err := h.storeRows(ctx, batch, q.DryRun, &report, refuse); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+	return report, h.storeRows(ctx, batch, q.DryRun, &report, refuse)
+}
+
+// importRow is a valid row of an import and its line.
+type importRow struct {
+	line int
+	wod  models.Wod
+}
+
+// storeRows refuses the rows of batch whose ID is another owner's or whose
+// idempotency key another wod has, and stores the others unless dryRun.
+func (h *History) storeRows(ctx context.Context, batch []importRow, dryRun bool, report *ImportReport,
+	refuse func(line int, err error),
+) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(batch))
+	for i, row := range batch {
+		ids[i] = row.wod.ID
+	}
+	stored, err := h.wodRepository.ListWods(ctx, repository.WodFilter{AnyOwner: true, IDs: ids},
+		repository.WodPage{Limit: len(ids)})
+	if err != nil {
+		return fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	owners := make(map[uuid.UUID]string, len(stored))
+	for _, w := range stored {
+		owners[w.ID] = w.OwnerSub
+	}
+
+	wods := make([]models.Wod, 0, len(batch))
+	for _, row := range batch {
+		if owner, ok := owners[row.wod.ID]; ok && owner != row.wod.OwnerSub {
+			refuse(row.line, fmt.Errorf("%w: %s belongs to another owner", common.ErrWodExists, row.wod.ID))
+			continue
+		}
+		if err := h.keyTaken(ctx, row.wod); err != nil {
+			if !errors.Is(err, common.ErrWodExists) {
+				return err
+			}
+			refuse(row.line, err)
+			continue
+		}
+		wods = append(wods, row.wod)
+	}
+	if dryRun {
+		return nil
+	}
+	if err := h.wodRepository.UpsertWods(ctx, wods); err != nil {
+		return fmt.Errorf("lines %d to %d: wodRepository.UpsertWods(): %w", batch[0].line, batch[len(batch)-1].line, err)
+	}
+	report.Imported += len(wods)
+	return nil
+}
+
+// keyTaken is ErrWodExists when another wod of the owner of w has its
+// idempotency key.
+func (h *History) keyTaken(ctx context.Context, w models.Wod) error {
+	if w.IdempotencyKey == "" {
+		return nil
+	}
+	wods, err := h.wodRepository.ListWods(ctx, repository.WodFilter{Owner: w.OwnerSub, IdempotencyKey: w.IdempotencyKey},
+		repository.WodPage{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	if len(wods) > 0 && wods[0].ID != w.ID {
+		return fmt.Errorf("%w: idempotency key %q is wod %s's", common.ErrWodExists, w.IdempotencyKey, wods[0].ID)
+	}
+	return nil
+}
+
+// checkRow validates w as a stored wod and settles its owner. Annotations
+// are the reader's, they are not imported.
+func checkRow(w *models.Wod, q ImportQuery) error {
+	if err := checkWod(*w); err != nil {
+		return err
+	}
+	if w.OwnerSub == "" {
+		w.OwnerSub = q.Owner
+	}
+	switch {
+	case w.OwnerSub == "":
+		return fmt.Errorf("%w: owner_sub is missing", common.ErrInvalidRow)
+	case !q.AnyOwner && w.OwnerSub != q.Owner:
+		return fmt.Errorf("%w: owner_sub %q, only your own wods are imported", common.ErrInvalidRow, w.OwnerSub)
+	}
+	w.Annotations = nil
+	return nil
+}
+
+func checkWod(w models.Wod) error {
+	switch {
+	case w.ID == uuid.Nil:
+		return fmt.Errorf("%w: id is missing", common.ErrInvalidRow)
+	case w.CreatedAt.IsZero():
+		return fmt.Errorf("%w: created_at is missing", common.ErrInvalidRow)
+	case !isLevel(w.Level):
+		return fmt.Errorf("%w: level %q", common.ErrInvalidRow, w.Level)
+	case w.DurationMin < MinDuration || w.DurationMin > MaxDuration:
+		return fmt.Errorf("%w: duration_min %d, %d to %d", common.ErrInvalidRow, w.DurationMin, MinDuration, MaxDuration)
+	case w.Catalog == "":
+		return fmt.Errorf("%w: catalog is missing", common.ErrInvalidRow)
+	case len(w.Blocks) == 0:
+		return fmt.Errorf("%w: no blocks", common.ErrInvalidRow)
+	case len(w.IdempotencyKey) > MaxIdempotencyKey:
+		return fmt.Errorf("%w: idempotency_key over %d characters", common.ErrInvalidRow, MaxIdempotencyKey)
+	}
+	for i, b := range w.Blocks {
+		if b.Name == "" {
+			return fmt.Errorf("%w: block %d has no name", common.ErrInvalidRow, i+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// equipmentSep separates the equipment in a CSV cell, as in catalog imports.
+const equipmentSep = ";"
+
+// maxLine bounds a JSON line of an import.
+const maxLine = 1 << 20
+
+// wodHeader are the CSV columns of a wod, the fields of models.Wod. The
+// blocks are JSON.
+var wodHeader = []string{
+	"id", "created_at", "owner_sub", "level", "duration_min", "equipment", "seed",
+	"catalog", "catalog_version", "catalog_hash", "idempotency_key", "blocks",
+}
+
+// wodRequired are the columns a CSV import must have.
+var wodRequired = []string{"id", "created_at", "level", "duration_min", "catalog", "blocks"}
+
+// resultHeader are the CSV columns of a result, the fields of models.Result.
+// The splits are JSON.
+var resultHeader = []string{
+	"id", "wod_id", "subject", "completed_at", "time_sec", "rounds", "reps", "load_kg",
+	"division", "rpe", "scaling", "splits", "created_at",
+}
+
+// rowWriter writes the rows of an export as JSON lines, or as CSV records
+// after a header.
+type rowWriter struct {
+	json *json.Encoder
+	csv  *csv.Writer
+}
+
+func newRowWriter(w io.Writer, format string, header []string) (*rowWriter, error) {
+	if format == FormatJSONL {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &rowWriter{json: enc}, nil
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return nil, fmt.Errorf("csv.Write: %w", err)
+	}
+	return &rowWriter{csv: cw}, nil
+}
+
+// write writes v as a JSON line, or the CSV record of v.
+func (rw *rowWriter) write(v any, record func() ([]string, error)) error {
+	if rw.json != nil {
+		if err := rw.json.Encode(v); err != nil {
+			return fmt.Errorf("json.Encode: %w", err)
+		}
+		return nil
+	}
+	rec, err := record()
+	if err != nil {
+		return err
+	}
+	if err := rw.csv.Write(rec); err != nil {
+		return fmt.Errorf("csv.Write: %w", err)
+	}
+	return nil
+}
+
+// flush sends the CSV records buffered, a page at a time.
+func (rw *rowWriter) flush() error {
+	if rw.csv == nil {
+		return nil
+	}
+	rw.csv.Flush()
+	if err := rw.csv.Error(); err != nil {
+		return fmt.Errorf("csv.Flush: %w", err)
+	}
+	return nil
+}
+
+func wodRecord(w models.Wod) ([]string, error) {
+	blocks, err := json.Marshal(w.Blocks)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+	return []string{
+		w.ID.String(), w.CreatedAt.UTC().Format(time.RFC3339Nano), w.OwnerSub, w.Level,
+		strconv.Itoa(w.DurationMin), strings.Join(w.Equipment, equipmentSep), w.Seed,
+		w.Catalog, strconv.FormatInt(w.CatalogVersion, 10), w.CatalogHash, w.IdempotencyKey, string(blocks),
+	}, nil
+}
+
+func resultRecord(r models.Result) ([]string, error) {
+	var splits []byte
+	if len(r.Splits) > 0 {
+		var err error
+		if splits, err = json.Marshal(r.Splits); err != nil {
+			return nil, fmt.Errorf("json.Marshal: %w", err)
+		}
+	}
+	loadKg := ""
+	if r.LoadKg != nil {
+		loadKg = strconv.FormatFloat(*r.LoadKg, 'f', -1, 64)
+	}
+	return []string{
+		r.ID.String(), r.WodID.String(), r.Subject, r.CompletedAt.UTC().Format(time.RFC3339Nano),
+		optionalInt(r.TimeSec), optionalInt(r.Rounds), optionalInt(r.Reps), loadKg,
+		r.Division, optionalInt(r.RPE), r.Scaling, string(splits), r.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}, nil
+}
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// rowReader reads the wods of an import row by row.
+type rowReader interface {
+	// next returns the wod of the next row and its line, io.EOF after the
+	// last row. An ErrInvalidRow only fails the row, other errors the import.
+	next() (models.Wod, int, error)
+}
+
+func newRowReader(r io.Reader, format string) (rowReader, error) {
+	switch format {
+	case FormatJSONL:
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), maxLine)
+		return &jsonlReader{sc: sc}, nil
+	case FormatCSV:
+		return newCSVReader(r)
+	default:
+		return nil, fmt.Errorf("%w: format %q, jsonl or csv", common.ErrInvalidImport, format)
+	}
+}
+
+type jsonlReader struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+func (r *jsonlReader) next() (models.Wod, int, error) {
+	for r.sc.Scan() {
+		r.line++
+		raw := bytes.TrimSpace(r.sc.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var w models.Wod
+		if err := decodeStrict(raw, &w); err != nil {
+			return models.Wod{}, r.line, fmt.Errorf("%w: %w", common.ErrInvalidRow, err)
+		}
+		return w, r.line, nil
+	}
+	if err := r.sc.Err(); err != nil {
+		return models.Wod{}, r.line + 1, fmt.Errorf("%w: line %d: %w", common.ErrInvalidImport, r.line+1, err)
+	}
+	return models.Wod{}, r.line, io.EOF
+}
+
+// decodeStrict decodes the JSON value raw into v, refusing the fields v has
+// not.
+func decodeStrict(raw []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("one JSON value a line")
+	}
+	return nil
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+// newCSVReader reads the header of r, the columns of wodHeader in any order.
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return &csvReader{r: cr}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidImport, err)
+	}
+	for _, col := range header {
+		if !slices.Contains(wodHeader, col) {
+			return nil, fmt.Errorf("%w: unknown column %q", common.ErrInvalidImport, col)
+		}
+	}
+	for _, col := range wodRequired {
+		if !slices.Contains(header, col) {
+			return nil, fmt.Errorf("%w: column %q is missing", common.ErrInvalidImport, col)
+		}
+	}
+	return &csvReader{r: cr, header: header}, nil
+}
+
+func (c *csvReader) next() (models.Wod, int, error) {
+	if c.header == nil {
+		return models.Wod{}, 0, io.EOF
+	}
+	rec, err := c.r.Read()
+	var parseErr *csv.ParseError
+	switch {
+	case errors.Is(err, io.EOF):
+		return models.Wod{}, 0, io.EOF
+	case errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount):
+		return models.Wod{}, parseErr.StartLine, fmt.Errorf("%w: %d columns, the header has %d",
+			common.ErrInvalidRow, len(rec), len(c.header))
+	case err != nil:
+		return models.Wod{}, 0, fmt.Errorf("%w: %w", common.ErrInvalidImport, err)
+	}
+	line, _ := c.r.FieldPos(0)
+
+	row := make(map[string]string, len(c.header))
+	for i, col := range c.header {
+		row[col] = rec[i]
+	}
+	w, err := wodFromRow(row)
+	return w, line, err
+}
+
+// wodFromRow parses the cells of a CSV row, checkRow validates the wod.
+func wodFromRow(row map[string]string) (models.Wod, error) {
+	w := models.Wod{
+		OwnerSub:       row["owner_sub"],
+		Level:          row["level"],
+		Seed:           row["seed"],
+		Catalog:        row["catalog"],
+		CatalogHash:    row["catalog_hash"],
+		IdempotencyKey: row["idempotency_key"],
+	}
+	var err error
+	if w.ID, err = uuid.Parse(row["id"]); err != nil {
+		return models.Wod{}, fmt.Errorf("%w: id %q", common.ErrInvalidRow, row["id"])
+	}
+	if w.CreatedAt, err = time.Parse(time.RFC3339Nano, row["created_at"]); err != nil {
+		return models.Wod{}, fmt.Errorf("%w: created_at %q, an RFC 3339 time", common.ErrInvalidRow, row["created_at"])
+	}
+	if w.DurationMin, err = strconv.Atoi(row["duration_min"]); err != nil {
+		return models.Wod{}, fmt.Errorf("%w: duration_min %q", common.ErrInvalidRow, row["duration_min"])
+	}
+	if v := row["catalog_version"]; v != "" {
+		if w.CatalogVersion, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return models.Wod{}, fmt.Errorf("%w: catalog_version %q", common.ErrInvalidRow, v)
+		}
+	}
+	for _, e := range strings.Split(row["equipment"], equipmentSep) {
+		if e = strings.TrimSpace(e); e != "" {
+			w.Equipment = append(w.Equipment, e)
+		}
+	}
+	if err := decodeStrict([]byte(row["blocks"]), &w.Blocks); err != nil {
+		return models.Wod{}, fmt.Errorf("%w: blocks: %w", common.ErrInvalidRow, err)
+	}
+	return w, nil
+}
@@ -0,0 +1,253 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var historyT0 = time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+
+func historyWod(owner string, hours int) models.Wod {
+	return models.Wod{
+		ID:          uuid.New(),
+		CreatedAt:   historyT0.Add(time.Duration(hours) * time.Hour),
+		Level:       "beginner",
+		DurationMin: 30,
+		Equipment:   []string{"rower", "sled"},
+		Seed:        fmt.Sprintf("seed-%d", hours),
+		Blocks:      []models.Block{{ID: "row", Name: "Row", Params: map[string]any{"meters": float64(500)}}},
+		Catalog:     "hyrox",
+		OwnerSub:    owner,
+	}
+}
+
+func export(t *testing.T, exporter Exporter, err error) (string, int) {
+	t.Helper()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	n, err := exporter(&buf)
+	require.NoError(t, err)
+	return buf.String(), n
+}
+
+func TestHistory_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSONL, FormatCSV} {
+		wods := repository.NewMemoryWodRepository()
+		alice := []models.Wod{historyWod("alice", 0), historyWod("alice", 1), historyWod("alice", 2)}
+		alice[1].Equipment = nil
+		alice[2].IdempotencyKey = "retry-1"
+		alice[2].CatalogVersion = 3
+		_, err := wods.SaveWods(context.Background(), append(alice, historyWod("bob", 3)))
+		require.NoError(t, err)
+
+		exporter, err := NewHistory(wods, nil).ExportWods(context.Background(),
+			repository.WodFilter{Owner: "alice"}, ExportQuery{Format: format})
+		out, n := export(t, exporter, err)
+		require.Equal(t, 3, n, format)
+
+		target := repository.NewMemoryWodRepository()
+		report, err := NewHistory(target, nil).ImportWods(context.Background(), strings.NewReader(out),
+			ImportQuery{Format: format, Owner: "alice"})
+		require.NoError(t, err, format)
+		require.Equal(t, ImportReport{Rows: 3, Imported: 3}, report, format)
+
+		got, err := target.ListWods(context.Background(), repository.WodFilter{Owner: "alice", Ascending: true},
+			repository.WodPage{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, alice, got, format)
+	}
+}
+
+func TestHistory_ExportWods(t *testing.T) {
+	wods := repository.NewMemoryWodRepository()
+	var all []models.Wod
+	for i := range exportPage + 20 {
+		all = append(all, historyWod("alice", i))
+	}
+	_, err := wods.SaveWods(context.Background(), all)
+	require.NoError(t, err)
+	h := NewHistory(wods, nil)
+
+	exporter, err := h.ExportWods(context.Background(), repository.WodFilter{Owner: "alice"}, ExportQuery{Format: FormatCSV})
+	out, n := export(t, exporter, err)
+	require.Equal(t, len(all), n, "every page is exported")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, len(all)+1)
+	require.Equal(t, strings.Join(wodHeader, ","), lines[0])
+	require.True(t, strings.HasPrefix(lines[1], all[len(all)-1].ID.String()), "newest first")
+
+	exporter, err = h.ExportWods(context.Background(), repository.WodFilter{Owner: "alice"}, ExportQuery{
+		Format: FormatJSONL, From: historyT0.Add(2 * time.Hour), To: historyT0.Add(4 * time.Hour),
+	})
+	out, n = export(t, exporter, err)
+	require.Equal(t, 2, n)
+	require.Contains(t, out, all[3].ID.String())
+	require.Contains(t, out, all[2].ID.String())
+
+	exporter, err = h.ExportWods(context.Background(), repository.WodFilter{Owner: "bob"}, ExportQuery{Format: FormatCSV})
+	out, n = export(t, exporter, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, strings.Join(wodHeader, ",")+"\n", out, "an empty CSV export keeps its header")
+}
+
+func TestHistory_ExportResults(t *testing.T) {
+	results := repository.NewMemoryResultRepository()
+	wodID := uuid.New()
+	timeSec := 900
+	// results completed at the same time are paged by ID.
+	for i := range exportPage + 10 {
+		_, err := results.SaveResult(context.Background(), models.Result{
+			ID: uuid.New(), WodID: wodID, Subject: "alice", TimeSec: &timeSec,
+			CompletedAt: historyT0.Add(time.Duration(i%3) * time.Hour), CreatedAt: historyT0,
+		})
+		require.NoError(t, err)
+	}
+	load := 80.5
+	_, err := results.SaveResult(context.Background(), models.Result{
+		ID: uuid.New(), WodID: wodID, Subject: "bob", LoadKg: &load, Splits: []models.Split{{Block: 1, TimeSec: 60}},
+		CompletedAt: historyT0, CreatedAt: historyT0,
+	})
+	require.NoError(t, err)
+	h := NewHistory(nil, results)
+
+	exporter, err := h.ExportResults(context.Background(), repository.WodFilter{Owner: "alice"}, ExportQuery{Format: FormatJSONL})
+	out, n := export(t, exporter, err)
+	require.Equal(t, exportPage+10, n)
+	seen := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		require.False(t, seen[line], "no result twice")
+		seen[line] = true
+	}
+
+	exporter, err = h.ExportResults(context.Background(), repository.WodFilter{AnyOwner: true},
+		ExportQuery{Format: FormatCSV, To: historyT0.Add(time.Hour)})
+	out, n = export(t, exporter, err)
+	require.Equal(t, (exportPage+10)/3+1, n)
+	require.Contains(t, out, `,bob,2025-09-01T08:00:00Z,,,,80.5,,,,"[{""block"":1,""time_sec"":60}]",2025-09-01T08:00:00Z`)
+}
+
+func TestHistory_ExportInvalid(t *testing.T) {
+	h := NewHistory(repository.NewMemoryWodRepository(), repository.NewMemoryResultRepository())
+	for name, q := range map[string]ExportQuery{
+		"format":   {Format: "xml"},
+		"reversed": {Format: FormatJSONL, From: historyT0, To: historyT0},
+	} {
+		_, err := h.ExportWods(context.Background(), repository.WodFilter{Owner: "alice"}, q)
+		require.ErrorIs(t, err, common.ErrExportFilter, name)
+		_, err = h.ExportResults(context.Background(), repository.WodFilter{Owner: "alice"}, q)
+		require.ErrorIs(t, err, common.ErrExportFilter, name)
+	}
+}
+
+func TestHistory_ImportWods(t *testing.T) {
+	wods := repository.NewMemoryWodRepository()
+	mine, bobs, keyed := historyWod("alice", 0), historyWod("bob", 1), historyWod("alice", 2)
+	keyed.IdempotencyKey = "retry-1"
+	_, err := wods.SaveWods(context.Background(), []models.Wod{mine, bobs, keyed})
+	require.NoError(t, err)
+
+	updated := mine
+	updated.DurationMin = 45
+	updated.OwnerSub = ""
+	stolen := bobs
+	stolen.OwnerSub = "alice"
+	taken := historyWod("alice", 3)
+	taken.IdempotencyKey = "retry-1"
+	fresh := historyWod("", 4)
+	var in bytes.Buffer
+	for _, w := range []models.Wod{updated, stolen, taken, fresh, historyWod("bob", 5)} {
+		require.NoError(t, json.NewEncoder(&in).Encode(w))
+	}
+	in.WriteString("\n" + `{"id": "` + uuid.NewString() + `", "level": "expert"}` + "\n")
+	in.WriteString(`{"id": "` + uuid.NewString() + `", "mood": "great"}` + "\n")
+
+	report, err := NewHistory(wods, nil).ImportWods(context.Background(), &in, ImportQuery{Format: FormatJSONL, Owner: "alice"})
+
+	require.NoError(t, err)
+	require.Equal(t, 7, report.Rows)
+	require.Equal(t, 2, report.Imported)
+	require.Equal(t, 5, report.Failed)
+	lines := make([]int, len(report.Errors))
+	for i, e := range report.Errors {
+		lines[i] = e.Line
+	}
+	require.Equal(t, []int{2, 3, 5, 7, 8}, lines, "the blank line 6 is skipped")
+	require.ErrorIs(t, report.Errors[0].Err, common.ErrWodExists, "a wod of bob stays his")
+	require.ErrorIs(t, report.Errors[1].Err, common.ErrWodExists, "the idempotency key is another wod's")
+	require.ErrorContains(t, report.Errors[2].Err, `owner_sub "bob", only your own wods are imported`)
+	require.ErrorContains(t, report.Errors[3].Err, `invalid row: created_at is missing`)
+	require.ErrorContains(t, report.Errors[4].Err, `unknown field "mood"`)
+
+	got, err := wods.GetWod(context.Background(), mine.ID)
+	require.NoError(t, err)
+	require.Equal(t, 45, got.DurationMin, "the wod with the ID is replaced")
+	got, err = wods.GetWod(context.Background(), fresh.ID)
+	require.NoError(t, err)
+	require.Equal(t, "alice", got.OwnerSub, "rows without an owner get the importer's")
+	got, err = wods.GetWod(context.Background(), bobs.ID)
+	require.NoError(t, err)
+	require.Equal(t, "bob", got.OwnerSub)
+}
+
+func TestHistory_ImportWods_DryRun(t *testing.T) {
+	wods := repository.NewMemoryWodRepository()
+	csv := strings.Join(wodHeader, ",") + "\n" +
+		uuid.NewString() + `,2025-09-01T08:00:00Z,bob,beginner,30,rower;sled,s1,hyrox,2,abc,,"[{""name"":""Row""}]"` + "\n" +
+		uuid.NewString() + `,2025-09-01T08:00:00Z,bob,beginner,thirty,,s1,hyrox,2,abc,,"[{""name"":""Row""}]"` + "\n" +
+		`x,y` + "\n"
+
+	report, err := NewHistory(wods, nil).ImportWods(context.Background(), strings.NewReader(csv),
+		ImportQuery{Format: FormatCSV, AnyOwner: true, DryRun: true})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Rows)
+	require.Equal(t, 0, report.Imported)
+	require.Equal(t, []int{3, 4}, []int{report.Errors[0].Line, report.Errors[1].Line})
+	require.ErrorContains(t, report.Errors[0].Err, `duration_min "thirty"`)
+	require.ErrorContains(t, report.Errors[1].Err, "2 columns, the header has 12")
+	n, err := wods.CountWods(context.Background(), repository.WodFilter{AnyOwner: true})
+	require.NoError(t, err)
+	require.Zero(t, n, "a dry run stores nothing")
+}
+
+func TestHistory_ImportWods_Invalid(t *testing.T) {
+	h := NewHistory(repository.NewMemoryWodRepository(), nil)
+	for name, tc := range map[string]struct {
+		format, in string
+	}{
+		"format":         {"xml", ""},
+		"unknown column": {FormatCSV, "id,created_at,mood\n"},
+		"missing column": {FormatCSV, "id,created_at,level\n"},
+		"long line":      {FormatJSONL, strings.Repeat("x", maxLine+1)},
+	} {
+		_, err := h.ImportWods(context.Background(), strings.NewReader(tc.in), ImportQuery{Format: tc.format, Owner: "alice"})
+		require.ErrorIs(t, err, common.ErrInvalidImport, name)
+	}
+
+	report, err := h.ImportWods(context.Background(), strings.NewReader(""), ImportQuery{Format: FormatCSV, Owner: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, ImportReport{}, report, "an empty file imports nothing")
+}
+
+func TestHistory_ImportWods_StoreError(t *testing.T) {
+	repo := &mockWodRepo{wods: []models.Wod{}, err: errors.New("db down")}
+	in, err := json.Marshal(historyWod("alice", 0))
+	require.NoError(t, err)
+
+	report, err := NewHistory(repo, nil).ImportWods(context.Background(), bytes.NewReader(in), ImportQuery{Format: FormatJSONL, Owner: "alice"})
+
+	require.ErrorContains(t, err, "lines 1 to 1: wodRepository.UpsertWods(): db down")
+	require.Zero(t, report.Imported)
+}
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Scoring rules of a leaderboard.
+const (
+	ScoreTime   = "time"
+	ScoreRounds = "rounds"
+	ScoreLoad   = "load"
+)
+
+const (
+	// boardWods and boardResults cap what a leaderboard ranks.
+	boardWods    = 500
+	boardResults = 10000
+)
+
+// BoardQuery selects the results a leaderboard ranks.
+type BoardQuery struct {
+	// Seed ranks every wod generated from the seed and parameters of the
+	// wod, by any owner.
+	Seed bool
+	// Scoring is one of the Score rules, the one most results were logged
+	// with when empty.
+	Scoring string
+	// Level of the wods ranked, the level of the wod when empty.
+	Level           string
+	Division        string
+	CompletedAfter  time.Time
+	CompletedBefore time.Time
+	Limit           int
+	Offset          int
+}
+
+type BoardEntry struct {
+	// Rank from 1, shared by the results tied on score and completion time.
+	Rank   int
+	Result models.Result
+}
+
+type Board struct {
+	Scoring string
+	// Wods is the count of wods ranked together.
+	Wods    int
+	Entries []BoardEntry
+}
+
+func (s *Results) Leaderboard(ctx context.Context, id uuid.UUID, f repository.WodFilter, q BoardQuery) (Board, error) {
+	switch {
+	case q.Scoring != "" && q.Scoring != ScoreTime && q.Scoring != ScoreRounds && q.Scoring != ScoreLoad:
+		return Board{}, fmt.Errorf("%w: unknown scoring %q", common.ErrResultFilter, q.Scoring)
+	case q.Level != "" && !isLevel(q.Level):
+		return Board{}, fmt.Errorf("%w: unknown level %q", common.ErrResultFilter, q.Level)
+	case !q.CompletedAfter.IsZero() && !q.CompletedBefore.IsZero() && !q.CompletedAfter.Before(q.CompletedBefore):
+		return Board{}, fmt.Errorf("%w: completed_after must be before completed_before", common.ErrResultFilter)
+	case q.Limit < 1 || q.Limit > MaxResultLimit:
+		return Board{}, fmt.Errorf("%w: limit is 1 to %d", common.ErrResultFilter, MaxResultLimit)
+	case q.Offset < 0:
+		return Board{}, fmt.Errorf("%w: offset cannot be negative", common.ErrResultFilter)
+	}
+	wod, err := getWod(ctx, s.wodRepository, id, f)
+	if err != nil {
+		return Board{}, err
+	}
+
+	wods, err := s.boardWods(ctx, wod, q)
+	if err != nil {
+		return Board{}, err
+	}
+	board := Board{Scoring: q.Scoring, Wods: len(wods)}
+	if len(wods) == 0 {
+		if board.Scoring == "" {
+			board.Scoring = ScoreTime
+		}
+		return board, nil
+	}
+
+	rf := repository.ResultFilter{
+		WodIDs:          wods,
+		Division:        strings.ToLower(strings.TrimSpace(q.Division)),
+		CompletedAfter:  q.CompletedAfter,
+		CompletedBefore: q.CompletedBefore,
+	}
+	results, err := s.resultRepository.ListResults(ctx, rf, boardResults, 0)
+	if err != nil {
+		return Board{}, fmt.Errorf("resultRepository.ListResults(): %w", err)
+	}
+	if board.Scoring == "" {
+		board.Scoring = prevailingScoring(results)
+	}
+
+	entries := rank(board.Scoring, results)
+	entries = entries[min(q.Offset, len(entries)):]
+	board.Entries = entries[:min(q.Limit, len(entries))]
+	return board, nil
+}
+
+// boardWods lists the ids of the wods ranked together: wod, or with q.Seed
+// the wods generated from its seed, level, duration, equipment and catalog.
+func (s *Results) boardWods(ctx context.Context, wod models.Wod, q BoardQuery) ([]uuid.UUID, error) {
+	level := cmp.Or(q.Level, wod.Level)
+	if !q.Seed {
+		if level != wod.Level {
+			return nil, nil
+		}
+		return []uuid.UUID{wod.ID}, nil
+	}
+
+	wods, err := s.wodRepository.ListWods(ctx, repository.WodFilter{
+		AnyOwner:    true,
+		Seed:        wod.Seed,
+		Level:       level,
+		MinDuration: wod.DurationMin,
+		MaxDuration: wod.DurationMin,
+		Equipment:   wod.Equipment,
+		Catalog:     wod.Catalog,
+		CatalogHash: wod.CatalogHash,
+	}, repository.WodPage{Limit: boardWods})
+	if err != nil {
+		return nil, fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+	var ids []uuid.UUID
+	for _, w := range wods {
+		// the order of the equipment is part of the seed.
+		if slices.Equal(w.Equipment, wod.Equipment) {
+			ids = append(ids, w.ID)
+		}
+	}
+	return ids, nil
+}
+
+// scoringOf is the rule r was scored with.
+func scoringOf(r models.Result) string {
+	switch {
+	case r.Rounds != nil:
+		return ScoreRounds
+	case r.LoadKg != nil:
+		return ScoreLoad
+	default:
+		return ScoreTime
+	}
+}
+
+// prevailingScoring is the rule most results were scored with, wods have
+// no format telling it. Ties go to time, then rounds.
+func prevailingScoring(results []models.Result) string {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[scoringOf(r)]++
+	}
+	best := ScoreTime
+	for _, s := range []string{ScoreRounds, ScoreLoad} {
+		if counts[s] > counts[best] {
+			best = s
+		}
+	}
+	return best
+}
+
+// compareScores orders a before b when a scored better under scoring.
+func compareScores(scoring string, a, b models.Result) int {
+	switch scoring {
+	case ScoreRounds:
+		if c := cmp.Compare(*b.Rounds, *a.Rounds); c != 0 {
+			return c
+		}
+		return cmp.Compare(intOr0(b.Reps), intOr0(a.Reps))
+	case ScoreLoad:
+		return cmp.Compare(*b.LoadKg, *a.LoadKg)
+	default:
+		return cmp.Compare(*a.TimeSec, *b.TimeSec)
+	}
+}
+
+// compareEntries breaks the ties on score by the earliest completion, then
+// by id so the order is stable.
+func compareEntries(scoring string, a, b models.Result) int {
+	if c := compareScores(scoring, a, b); c != 0 {
+		return c
+	}
+	if c := a.CompletedAt.Compare(b.CompletedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// rank keeps the best result of every athlete scored under scoring and
+// ranks them.
+func rank(scoring string, results []models.Result) []BoardEntry {
+	best := map[string]models.Result{}
+	for _, r := range results {
+		if scoringOf(r) != scoring {
+			continue
+		}
+		if b, ok := best[r.Subject]; !ok || compareEntries(scoring, r, b) < 0 {
+			best[r.Subject] = r
+		}
+	}
+
+	entries := make([]BoardEntry, 0, len(best))
+	for _, r := range best {
+		entries = append(entries, BoardEntry{Result: r})
+	}
+	slices.SortFunc(entries, func(a, b BoardEntry) int { return compareEntries(scoring, a.Result, b.Result) })
+	for i := range entries {
+		prev := i - 1
+		if i > 0 && compareScores(scoring, entries[prev].Result, entries[i].Result) == 0 &&
+			entries[prev].Result.CompletedAt.Equal(entries[i].Result.CompletedAt) {
+			entries[i].Rank = entries[prev].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	return entries
+}
+
+func intOr0(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
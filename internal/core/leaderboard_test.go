@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func boardResult(subject string, completed time.Time, score func(r *models.Result)) models.Result {
+	r := models.Result{ID: uuid.New(), Subject: subject, CompletedAt: completed}
+	score(&r)
+	return r
+}
+
+func timed(sec int) func(r *models.Result) { return func(r *models.Result) { r.TimeSec = &sec } }
+
+func roundsReps(rounds, reps int) func(r *models.Result) {
+	return func(r *models.Result) { r.Rounds, r.Reps = &rounds, &reps }
+}
+
+func loaded(kg float64) func(r *models.Result) { return func(r *models.Result) { r.LoadKg = &kg } }
+
+func entrySubjects(board Board) ([]string, []int) {
+	subjects := make([]string, len(board.Entries))
+	ranks := make([]int, len(board.Entries))
+	for i, e := range board.Entries {
+		subjects[i], ranks[i] = e.Result.Subject, e.Rank
+	}
+	return subjects, ranks
+}
+
+func TestLeaderboard_Time(t *testing.T) {
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	wods := resultsWod()
+	results := &mockResultRepo{results: []models.Result{
+		boardResult("alice", t0, timed(1200)),
+		boardResult("alice", t0.Add(time.Hour), timed(1100)),
+		boardResult("carol", t0.Add(time.Hour), timed(1100)),
+		boardResult("bob", t0.Add(2*time.Hour), timed(1100)),
+		boardResult("dave", t0, timed(1300)),
+		boardResult("erin", t0, roundsReps(5, 3)),
+	}}
+	s := NewResults(wods, results)
+
+	board, err := s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Division: " RX ", Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, ScoreTime, board.Scoring, "most results are timed")
+	require.Equal(t, 1, board.Wods)
+	subjects, ranks := entrySubjects(board)
+	require.ElementsMatch(t, []string{"alice", "carol"}, subjects[:2])
+	require.Equal(t, []string{"bob", "dave"}, subjects[2:], "one entry per athlete, the rounds result left out")
+	require.Equal(t, []int{1, 1, 3, 4}, ranks, "same time and completion share a rank")
+	require.Equal(t, 1100, *board.Entries[0].Result.TimeSec, "the best result of alice")
+	require.Equal(t, repository.ResultFilter{WodIDs: []uuid.UUID{wods.saved.ID}, Division: "rx"}, results.filter)
+
+	board, err = s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Limit: 1, Offset: 2})
+	require.NoError(t, err)
+	subjects, ranks = entrySubjects(board)
+	require.Equal(t, []string{"bob"}, subjects)
+	require.Equal(t, []int{3}, ranks)
+}
+
+func TestLeaderboard_RoundsAndLoad(t *testing.T) {
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	wods := resultsWod()
+	results := &mockResultRepo{results: []models.Result{
+		boardResult("alice", t0, roundsReps(5, 3)),
+		boardResult("bob", t0, roundsReps(5, 10)),
+		boardResult("carol", t0, roundsReps(6, 0)),
+		boardResult("dave", t0, loaded(80)),
+		boardResult("erin", t0, loaded(100)),
+	}}
+	s := NewResults(wods, results)
+
+	board, err := s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"}, BoardQuery{Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, ScoreRounds, board.Scoring)
+	subjects, _ := entrySubjects(board)
+	require.Equal(t, []string{"carol", "bob", "alice"}, subjects)
+
+	board, err = s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Scoring: ScoreLoad, Limit: 20})
+	require.NoError(t, err)
+	subjects, _ = entrySubjects(board)
+	require.Equal(t, []string{"erin", "dave"}, subjects)
+}
+
+func TestLeaderboard_Seed(t *testing.T) {
+	wods := resultsWod()
+	wods.saved.Seed, wods.saved.Level, wods.saved.DurationMin = "class-42", "beginner", 30
+	wods.saved.Equipment = []string{"rower", "sled"}
+	wods.saved.Catalog, wods.saved.CatalogHash = "hyrox", "abc123"
+	same := wods.saved
+	same.ID, same.OwnerSub = uuid.New(), "bob"
+	reordered := same
+	reordered.ID, reordered.Equipment = uuid.New(), []string{"sled", "rower"}
+	wods.wods = []models.Wod{wods.saved, same, reordered}
+	results := &mockResultRepo{results: []models.Result{}}
+	s := NewResults(wods, results)
+
+	board, err := s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Seed: true, Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, 2, board.Wods, "the equipment order is part of the seed")
+	require.Equal(t, repository.WodFilter{
+		AnyOwner:    true,
+		Seed:        "class-42",
+		Level:       "beginner",
+		MinDuration: 30,
+		MaxDuration: 30,
+		Equipment:   []string{"rower", "sled"},
+		Catalog:     "hyrox",
+		CatalogHash: "abc123",
+	}, wods.filter)
+	require.Equal(t, []uuid.UUID{wods.saved.ID, same.ID}, results.filter.WodIDs)
+
+	_, err = s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Seed: true, Level: "advanced", Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, "advanced", wods.filter.Level)
+}
+
+func TestLeaderboard_Errors(t *testing.T) {
+	wods := resultsWod()
+	results := &mockResultRepo{}
+	s := NewResults(wods, results)
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+
+	_, err := s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "bob"}, BoardQuery{Limit: 20})
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+
+	_, err = s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{CompletedAfter: t0, CompletedBefore: t0, Limit: 20})
+	require.ErrorIs(t, err, common.ErrResultFilter)
+
+	for _, q := range []BoardQuery{
+		{Scoring: "reps", Limit: 20}, {Level: "elite", Limit: 20},
+		{Limit: 0}, {Limit: MaxResultLimit + 1}, {Limit: 20, Offset: -1},
+	} {
+		_, err = s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"}, q)
+		require.ErrorIs(t, err, common.ErrResultFilter)
+	}
+
+	board, err := s.Leaderboard(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"},
+		BoardQuery{Level: "advanced", Limit: 20})
+	require.NoError(t, err)
+	require.Zero(t, board.Wods, "the wod is of another level")
+	require.Empty(t, board.Entries)
+	require.Equal(t, repository.ResultFilter{}, results.filter, "no results listed")
+}
@@ -3,29 +3,257 @@ package core
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
 	"github.com/LinaKACI-pro/wod-gen/internal/models"
 	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
 )
 
+const (
+	// MaxSearchMoves bounds each of the all, any and none moves of a search.
+	MaxSearchMoves = 10
+	// MaxSearchParams bounds the params thresholds of a move searched.
+	MaxSearchParams = 5
+)
+
+// searchParam is the form of the params names, as in the catalogs.
+var searchParam = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
 type WodListInterface interface {
-	List(ctx context.Context, limit, offset int) ([]models.Wod, error)
+	List(ctx context.Context, f repository.WodFilter, p Page) (Listing, error)
+	Get(ctx context.Context, id uuid.UUID, f repository.WodFilter) (models.Wod, error)
+	// Annotate applies patch to the annotations of subject on their wod id.
+	Annotate(ctx context.Context, id uuid.UUID, subject string, patch AnnotationPatch) (models.Annotations, error)
+}
+
+// Page asks for Limit wods from Cursor, the next or previous cursor of a
+// listing page, or from Offset without one. Offsets are kept for older
+// clients, pages shift under them while wods are inserted. Total asks for the
+// count of every wod matching.
+type Page struct {
+	Limit  int
+	Offset int
+	Cursor string
+	Total  bool
+}
+
+// MaxListLimit caps the wods of a listing page.
+const MaxListLimit = 100
+
+// Listing is a page of wods with the cursors of the pages around it, empty
+// when there is none, and the total count when asked.
+type Listing struct {
+	Wods       []models.Wod
+	NextCursor string
+	PrevCursor string
+	Total      *int
 }
 
 type WodList struct {
-	wodRepository repository.WodRepositoryInterface
-	catalog       *catalog.Catalog
+	wodRepository        repository.WodRepositoryInterface
+	annotationRepository repository.AnnotationRepositoryInterface
+	catalogs             *catalog.Registry
+}
+
+func NewWodList(catalogs *catalog.Registry, wodRepository repository.WodRepositoryInterface,
+	annotationRepository repository.AnnotationRepositoryInterface,
+) *WodList {
+	return &WodList{catalogs: catalogs, wodRepository: wodRepository, annotationRepository: annotationRepository}
+}
+
+// List returns the page p of the stored wods matching f, newest first by
+// default. The wods of f.Annotator come with their annotations.
+func (w *WodList) List(ctx context.Context, f repository.WodFilter, p Page) (Listing, error) {
+	f, err := w.normalize(f)
+	if err != nil {
+		return Listing{}, err
+	}
+
+	if p.Limit < 1 || p.Limit > MaxListLimit {
+		return Listing{}, fmt.Errorf("%w: limit is 1 to %d", common.ErrWodFilter, MaxListLimit)
+	}
+	if p.Offset < 0 {
+		return Listing{}, fmt.Errorf("%w: offset cannot be negative", common.ErrWodFilter)
+	}
+	if p.Cursor != "" && p.Offset != 0 {
+		return Listing{}, fmt.Errorf("%w: offset cannot be combined with a cursor", common.ErrWodFilter)
+	}
+
+	// one more wod than asked tells whether a page follows.
+	fp := fingerprint(f)
+	page := repository.WodPage{Limit: p.Limit + 1, Offset: p.Offset}
+	if p.Cursor != "" {
+		c, err := decodeCursor(p.Cursor, fp)
+		if err != nil {
+			return Listing{}, err
+		}
+		key := c.key()
+		if c.Prev {
+			page.Before = &key
+		} else {
+			page.After = &key
+		}
+	}
+
+	wods, err := w.wodRepository.ListWods(ctx, f, page)
+	if err != nil {
+		return Listing{}, fmt.Errorf("wodRepository.ListWods(): %w", err)
+	}
+
+	more := len(wods) > p.Limit
+	hasNext, hasPrev := more, p.Offset > 0 || page.After != nil
+	if page.Before != nil {
+		// seeking backwards, the extra wod is the farthest from the cursor.
+		hasNext, hasPrev = true, more
+		if more {
+			wods = wods[1:]
+		}
+	} else if more {
+		wods = wods[:p.Limit]
+	}
+
+	if err := w.annotate(ctx, f.Annotator, wods); err != nil {
+		return Listing{}, err
+	}
+
+	out := Listing{Wods: wods}
+	if len(wods) > 0 {
+		if hasNext {
+			out.NextCursor = encodeCursor(fp, repository.KeyOf(wods[len(wods)-1]), false)
+		}
+		if hasPrev {
+			out.PrevCursor = encodeCursor(fp, repository.KeyOf(wods[0]), true)
+		}
+	}
+
+	if p.Total {
+		n, err := w.wodRepository.CountWods(ctx, f)
+		if err != nil {
+			return Listing{}, fmt.Errorf("wodRepository.CountWods(): %w", err)
+		}
+		out.Total = &n
+	}
+	return out, nil
+}
+
+// normalize checks f and puts it in the form stored. Catalog versions are per
+// catalog, a version without a catalog name refers to the default one. The
+// move asked for in MoveName is looked up in the catalog filtered on, the
+// default one otherwise, to match blocks by ID and by name, and so are the
+// moves searched, asked for in their Name.
+func (w *WodList) normalize(f repository.WodFilter) (repository.WodFilter, error) {
+	f.Catalog = strings.ToLower(strings.TrimSpace(f.Catalog))
+	if f.CatalogVersion != 0 && f.Catalog == "" {
+		f.Catalog = w.catalogs.Default()
+	}
+	f.CatalogHash = strings.ToLower(strings.TrimSpace(f.CatalogHash))
+	f.Seed = strings.TrimSpace(f.Seed)
+
+	f.Level = strings.ToLower(strings.TrimSpace(f.Level))
+	if f.Level != "" && !isLevel(f.Level) {
+		return f, common.InvalidDataError{DataType: "level", Data: f.Level}
+	}
+	if f.MinDuration < 0 || f.MaxDuration < 0 || (f.MaxDuration > 0 && f.MinDuration > f.MaxDuration) {
+		return f, fmt.Errorf("%w: duration range %d-%d", common.ErrWodFilter, f.MinDuration, f.MaxDuration)
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return f, fmt.Errorf("%w: created_after must be before created_before", common.ErrWodFilter)
+	}
+	if f.Sort != "" && f.Sort != repository.WodSortCreatedAt && f.Sort != repository.WodSortDuration {
+		return f, fmt.Errorf("%w: unknown sort %q", common.ErrWodFilter, f.Sort)
+	}
+
+	labels, err := normalizeLabels(f.Labels)
+	if err != nil {
+		return f, fmt.Errorf("%w: %w", common.ErrWodFilter, err)
+	}
+	f.Labels = labels
+	f.Note = strings.TrimSpace(f.Note)
+
+	if move := strings.TrimSpace(f.MoveName); move != "" {
+		f.MoveID, f.MoveName = w.lookupMove(f.Catalog, move)
+	}
+	for _, moves := range []*[]repository.MoveMatch{&f.AllMoves, &f.AnyMoves, &f.NoMoves} {
+		*moves = slices.Clone(*moves)
+		if err := w.normalizeMoves(*moves, f.Catalog); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// lookupMove returns the ID and name of move in the catalog named, the
+// default one when empty, to match blocks by both. An unknown move is
+// matched on what was asked.
+func (w *WodList) lookupMove(catalogName, move string) (string, string) {
+	if c, err := w.catalogs.Get(catalogName); err == nil {
+		if m, ok := c.Lookup(move); ok {
+			return m.Key(), m.Name
+		}
+	}
+	return move, move
+}
+
+// normalizeMoves checks the moves of a search and looks them up, in place.
+func (w *WodList) normalizeMoves(moves []repository.MoveMatch, catalogName string) error {
+	if len(moves) > MaxSearchMoves {
+		return fmt.Errorf("%w: at most %d moves in all, any and none", common.ErrWodFilter, MaxSearchMoves)
+	}
+	for i, m := range moves {
+		move := strings.TrimSpace(m.Name)
+		if move == "" {
+			return fmt.Errorf("%w: empty move", common.ErrWodFilter)
+		}
+		if len(m.Params) > MaxSearchParams {
+			return fmt.Errorf("%w: at most %d params on %s", common.ErrWodFilter, MaxSearchParams, move)
+		}
+		params := make(map[string]repository.ParamRange, len(m.Params))
+		for k, r := range m.Params {
+			k = strings.ToLower(strings.TrimSpace(k))
+			switch {
+			case !searchParam.MatchString(k):
+				return fmt.Errorf("%w: invalid param %q on %s", common.ErrWodFilter, k, move)
+			case r.Min == nil && r.Max == nil:
+				return fmt.Errorf("%w: %s of %s has neither min nor max", common.ErrWodFilter, k, move)
+			case r.Min != nil && r.Max != nil && *r.Min > *r.Max:
+				return fmt.Errorf("%w: %s of %s has min above max", common.ErrWodFilter, k, move)
+			}
+			params[k] = r
+		}
+		moves[i].ID, moves[i].Name = w.lookupMove(catalogName, move)
+		moves[i].Params = params
+	}
+	return nil
 }
 
-func NewWodList(catalog *catalog.Catalog, wodRepository repository.WodRepositoryInterface) *WodList {
-	return &WodList{catalog: catalog, wodRepository: wodRepository}
+// Get returns the stored wod id if its owner matches f, only the owner fields
+// and Annotator of f are used. Other owners' wods are common.ErrWodNotFound
+// too, their IDs are not disclosed.
+func (w *WodList) Get(ctx context.Context, id uuid.UUID, f repository.WodFilter) (models.Wod, error) {
+	wod, err := getWod(ctx, w.wodRepository, id, f)
+	if err != nil {
+		return models.Wod{}, err
+	}
+	wods := []models.Wod{wod}
+	if err := w.annotate(ctx, f.Annotator, wods); err != nil {
+		return models.Wod{}, err
+	}
+	return wods[0], nil
 }
 
-func (w *WodList) List(ctx context.Context, limit, offset int) ([]models.Wod, error) {
-	wods, err := w.wodRepository.ListWods(ctx, limit, offset)
+// getWod is Get on repo, for the other services reading wods.
+func getWod(ctx context.Context, repo repository.WodRepositoryInterface, id uuid.UUID, f repository.WodFilter) (models.Wod, error) {
+	wod, err := repo.GetWod(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("wodRepository.ListWods(): %w", err)
+		return models.Wod{}, fmt.Errorf("wodRepository.GetWod(): %w", err)
+	}
+	if !f.AnyOwner && wod.OwnerSub != f.Owner {
+		return models.Wod{}, fmt.Errorf("wod %s: %w", id, common.ErrWodNotFound)
 	}
-	return wods, nil
+	return wod, nil
 }
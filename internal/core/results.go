@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxRPE      = 10
+	MaxScaling  = 1000
+	MaxDivision = 32
+	// MaxResultLimit caps the results of a page.
+	MaxResultLimit = 100
+	// clockSkew tolerates completion dates slightly ahead of the server clock.
+	clockSkew = time.Minute
+)
+
+type ResultsInterface interface {
+	// Log records r on the wod id, which the owner of f must see.
+	Log(ctx context.Context, id uuid.UUID, r models.Result, f repository.WodFilter) (models.Result, error)
+	// ListByWod lists the results on the wod id, which the owner of f must see.
+	ListByWod(ctx context.Context, id uuid.UUID, f repository.WodFilter, limit, offset int) ([]models.Result, error)
+	// ListByAthlete lists the results of subject across wods.
+	ListByAthlete(ctx context.Context, subject string, limit, offset int) ([]models.Result, error)
+	// Leaderboard ranks the results on the wod id, which the owner of f
+	// must see, or on every wod sharing its parameters.
+	Leaderboard(ctx context.Context, id uuid.UUID, f repository.WodFilter, q BoardQuery) (Board, error)
+}
+
+type Results struct {
+	wodRepository    repository.WodRepositoryInterface
+	resultRepository repository.ResultRepositoryInterface
+}
+
+func NewResults(wodRepository repository.WodRepositoryInterface, resultRepository repository.ResultRepositoryInterface) *Results {
+	return &Results{wodRepository: wodRepository, resultRepository: resultRepository}
+}
+
+func (s *Results) Log(ctx context.Context, id uuid.UUID, r models.Result, f repository.WodFilter) (models.Result, error) {
+	wod, err := getWod(ctx, s.wodRepository, id, f)
+	if err != nil {
+		return models.Result{}, err
+	}
+
+	now := time.Now().UTC()
+	r.Division = strings.ToLower(strings.TrimSpace(r.Division))
+	if r.CompletedAt.IsZero() {
+		r.CompletedAt = now
+	}
+	if err := validateResult(r, wod, now); err != nil {
+		return models.Result{}, err
+	}
+	r.ID = uuid.New()
+	r.WodID = wod.ID
+	r.CreatedAt = now
+
+	saved, err := s.resultRepository.SaveResult(ctx, r)
+	if err != nil {
+		return models.Result{}, fmt.Errorf("resultRepository.SaveResult(): %w", err)
+	}
+	return saved, nil
+}
+
+func (s *Results) ListByWod(ctx context.Context, id uuid.UUID, f repository.WodFilter, limit, offset int) ([]models.Result, error) {
+	if _, err := getWod(ctx, s.wodRepository, id, f); err != nil {
+		return nil, err
+	}
+	results, err := s.resultRepository.ListResults(ctx, repository.ResultFilter{WodID: id}, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("resultRepository.ListResults(): %w", err)
+	}
+	return results, nil
+}
+
+func (s *Results) ListByAthlete(ctx context.Context, subject string, limit, offset int) ([]models.Result, error) {
+	results, err := s.resultRepository.ListResults(ctx, repository.ResultFilter{Subject: subject}, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("resultRepository.ListResults(): %w", err)
+	}
+	return results, nil
+}
+
+// validateResult checks r is scored one way and its splits are blocks of wod.
+func validateResult(r models.Result, wod models.Wod, now time.Time) error {
+	scores := 0
+	for _, scored := range []bool{r.TimeSec != nil, r.Rounds != nil, r.LoadKg != nil} {
+		if scored {
+			scores++
+		}
+	}
+
+	switch {
+	case scores != 1:
+		return fmt.Errorf("%w: score one of time_sec, rounds or load_kg", common.ErrInvalidResult)
+	case r.TimeSec != nil && *r.TimeSec <= 0:
+		return fmt.Errorf("%w: time_sec must be positive", common.ErrInvalidResult)
+	case r.Rounds != nil && *r.Rounds < 0:
+		return fmt.Errorf("%w: rounds cannot be negative", common.ErrInvalidResult)
+	case r.Reps != nil && r.Rounds == nil:
+		return fmt.Errorf("%w: reps go with rounds", common.ErrInvalidResult)
+	case r.Reps != nil && *r.Reps < 0:
+		return fmt.Errorf("%w: reps cannot be negative", common.ErrInvalidResult)
+	case r.LoadKg != nil && !(*r.LoadKg > 0):
+		return fmt.Errorf("%w: load_kg must be positive", common.ErrInvalidResult)
+	case len(r.Division) > MaxDivision:
+		return fmt.Errorf("%w: division is limited to %d characters", common.ErrInvalidResult, MaxDivision)
+	case r.RPE != nil && (*r.RPE < 1 || *r.RPE > MaxRPE):
+		return fmt.Errorf("%w: rpe must be between 1 and %d", common.ErrInvalidResult, MaxRPE)
+	case len(r.Scaling) > MaxScaling:
+		return fmt.Errorf("%w: scaling is limited to %d characters", common.ErrInvalidResult, MaxScaling)
+	case r.CompletedAt.After(now.Add(clockSkew)):
+		return fmt.Errorf("%w: completed_at is in the future", common.ErrInvalidResult)
+	}
+
+	seen := make(map[int]bool, len(r.Splits))
+	for _, sp := range r.Splits {
+		if sp.Block < 1 || sp.Block > len(wod.Blocks) {
+			return fmt.Errorf("%w: split block %d is not between 1 and %d", common.ErrInvalidResult, sp.Block, len(wod.Blocks))
+		}
+		if seen[sp.Block] {
+			return fmt.Errorf("%w: block %d is split twice", common.ErrInvalidResult, sp.Block)
+		}
+		if sp.TimeSec <= 0 {
+			return fmt.Errorf("%w: split time_sec must be positive", common.ErrInvalidResult)
+		}
+		seen[sp.Block] = true
+	}
+	return nil
+}
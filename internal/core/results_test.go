@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type mockResultRepo struct {
+	saved   models.Result
+	results []models.Result
+	filter  repository.ResultFilter
+}
+
+func (m *mockResultRepo) SaveResult(ctx context.Context, r models.Result) (models.Result, error) {
+	m.saved = r
+	return r, nil
+}
+
+func (m *mockResultRepo) ListResults(ctx context.Context, f repository.ResultFilter, limit, offset int) ([]models.Result, error) {
+	m.filter = f
+	if m.results != nil {
+		return m.results, nil
+	}
+	return []models.Result{m.saved}, nil
+}
+
+func resultsWod() *mockWodRepo {
+	return &mockWodRepo{saved: models.Wod{
+		ID:       uuid.New(),
+		OwnerSub: "alice",
+		Blocks:   []models.Block{{Name: "Row"}, {Name: "Run"}},
+	}}
+}
+
+func TestResults_Log(t *testing.T) {
+	wods, results := resultsWod(), &mockResultRepo{}
+	s := NewResults(wods, results)
+
+	timeSec := 1260
+	r, err := s.Log(context.Background(), wods.saved.ID, models.Result{
+		Subject: "alice",
+		TimeSec: &timeSec,
+		Splits:  []models.Split{{Block: 1, TimeSec: 300}, {Block: 2, TimeSec: 960}},
+	}, repository.WodFilter{Owner: "alice"})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, r.ID)
+	require.Equal(t, wods.saved.ID, r.WodID)
+	require.False(t, r.CompletedAt.IsZero(), "completed now when omitted")
+	require.Equal(t, r, results.saved)
+}
+
+func TestResults_LogOthersWod(t *testing.T) {
+	wods := resultsWod()
+	s := NewResults(wods, &mockResultRepo{})
+	timeSec := 600
+
+	_, err := s.Log(context.Background(), wods.saved.ID, models.Result{Subject: "bob", TimeSec: &timeSec},
+		repository.WodFilter{Owner: "bob"})
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+
+	_, err = s.Log(context.Background(), wods.saved.ID, models.Result{Subject: "root", TimeSec: &timeSec},
+		repository.WodFilter{Owner: "root", AnyOwner: true})
+	require.NoError(t, err, "admins log on any wod")
+}
+
+func TestResults_LogInvalid(t *testing.T) {
+	wods := resultsWod()
+	s := NewResults(wods, &mockResultRepo{})
+	n := func(v int) *int { return &v }
+	kg := func(v float64) *float64 { return &v }
+
+	for name, r := range map[string]models.Result{
+		"no score":            {},
+		"time and rounds":     {TimeSec: n(600), Rounds: n(5)},
+		"zero time":           {TimeSec: n(0)},
+		"reps without rounds": {TimeSec: n(600), Reps: n(3)},
+		"rpe":                 {Rounds: n(5), RPE: n(11)},
+		"rounds and load":     {Rounds: n(5), LoadKg: kg(100)},
+		"zero load":           {LoadKg: kg(0)},
+		"division":            {LoadKg: kg(100), Division: strings.Repeat("x", MaxDivision+1)},
+		"split block":         {TimeSec: n(600), Splits: []models.Split{{Block: 3, TimeSec: 60}}},
+		"split twice":         {TimeSec: n(600), Splits: []models.Split{{Block: 1, TimeSec: 60}, {Block: 1, TimeSec: 60}}},
+		"split time":          {TimeSec: n(600), Splits: []models.Split{{Block: 1}}},
+		"future":              {TimeSec: n(600), CompletedAt: time.Now().Add(time.Hour)},
+	} {
+		_, err := s.Log(context.Background(), wods.saved.ID, r, repository.WodFilter{Owner: "alice"})
+		require.ErrorIs(t, err, common.ErrInvalidResult, name)
+	}
+}
+
+func TestResults_List(t *testing.T) {
+	wods, results := resultsWod(), &mockResultRepo{}
+	s := NewResults(wods, results)
+
+	_, err := s.ListByWod(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "alice"}, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, repository.ResultFilter{WodID: wods.saved.ID}, results.filter)
+
+	_, err = s.ListByWod(context.Background(), wods.saved.ID, repository.WodFilter{Owner: "bob"}, 20, 0)
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+
+	_, err = s.ListByAthlete(context.Background(), "bob", 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, repository.ResultFilter{Subject: "bob"}, results.filter)
+}
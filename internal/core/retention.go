@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+// Retention deletes the wods generated and never used again: older than the
+// retention days, with no result, favorite, note, label or calendar entry.
+type Retention struct {
+	retentionRepository repository.RetentionRepositoryInterface
+	days                int
+	batchSize           int
+	// purged records the wods deleted, for the metrics.
+	purged func(ctx context.Context, n int)
+}
+
+// NewRetention keeps the wods for days, and deletes batchSize of them a
+// statement. purged, when set, is told how many each purge deleted.
+func NewRetention(retentionRepository repository.RetentionRepositoryInterface, days, batchSize int,
+	purged func(ctx context.Context, n int),
+) *Retention {
+	return &Retention{retentionRepository: retentionRepository, days: days, batchSize: batchSize, purged: purged}
+}
+
+// Cutoff is when the wods purged now were created before.
+func (s *Retention) Cutoff(now time.Time) time.Time {
+	return now.UTC().AddDate(0, 0, -s.days)
+}
+
+// Purge deletes the wods past retention, a batch at a time, and returns how
+// many. With dryRun, it only counts them. A purge already running elsewhere
+// is common.ErrPurgeLocked.
+func (s *Retention) Purge(ctx context.Context, dryRun bool) (int, error) {
+	cutoff := s.Cutoff(time.Now())
+	if dryRun {
+		n, err := s.retentionRepository.CountPurgeable(ctx, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("retentionRepository.CountPurgeable(): %w", err)
+		}
+		return n, nil
+	}
+
+	unlock, ok, err := s.retentionRepository.TryLock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("retentionRepository.TryLock(): %w", err)
+	}
+	if !ok {
+		return 0, common.ErrPurgeLocked
+	}
+	defer unlock()
+
+	total := 0
+	defer func() {
+		if s.purged != nil && total > 0 {
+			s.purged(ctx, total)
+		}
+	}()
+	for {
+		n, err := s.retentionRepository.PurgeWods(ctx, cutoff, s.batchSize)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("retentionRepository.PurgeWods(): %w", err)
+		}
+		if n < s.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// Run purges every interval until ctx is done, on the replica holding the
+// purge lock, the others skip their turn.
+func (s *Retention) Run(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Purge(ctx, false)
+			switch {
+			case errors.Is(err, common.ErrPurgeLocked):
+				logger.Debug("retention purge running on another replica")
+			case err != nil:
+				logger.Error("retention purge failed", slog.Int("purged", n), slog.Any("err", err))
+			case n > 0:
+				logger.Info("retention purge", slog.Int("purged", n))
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRetentionRepo struct {
+	// purgeable is how many wods are left to purge.
+	purgeable int
+	err       error
+	locked    bool
+	unlocked  bool
+	cutoff    time.Time
+	limits    []int
+}
+
+func (m *mockRetentionRepo) CountPurgeable(ctx context.Context, cutoff time.Time) (int, error) {
+	m.cutoff = cutoff
+	return m.purgeable, m.err
+}
+
+func (m *mockRetentionRepo) PurgeWods(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	m.cutoff = cutoff
+	m.limits = append(m.limits, limit)
+	if m.err != nil && len(m.limits) > 1 {
+		return 0, m.err
+	}
+	n := min(limit, m.purgeable)
+	m.purgeable -= n
+	return n, nil
+}
+
+func (m *mockRetentionRepo) TryLock(ctx context.Context) (func(), bool, error) {
+	if m.locked {
+		return nil, false, nil
+	}
+	return func() { m.unlocked = true }, true, nil
+}
+
+func TestPurge_Batches(t *testing.T) {
+	repo := &mockRetentionRepo{purgeable: 5}
+	var recorded int
+	s := NewRetention(repo, 30, 2, func(ctx context.Context, n int) { recorded += n })
+
+	before := time.Now().UTC().AddDate(0, 0, -30)
+	n, err := s.Purge(context.Background(), false)
+
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, []int{2, 2, 2}, repo.limits)
+	require.Equal(t, 5, recorded)
+	require.True(t, repo.unlocked)
+	require.WithinRange(t, repo.cutoff, before, time.Now().UTC().AddDate(0, 0, -30))
+}
+
+func TestPurge_FullLastBatch(t *testing.T) {
+	repo := &mockRetentionRepo{purgeable: 4}
+	s := NewRetention(repo, 30, 2, nil)
+
+	n, err := s.Purge(context.Background(), false)
+
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	// the last batch is full, one more finds nothing left.
+	require.Equal(t, []int{2, 2, 2}, repo.limits)
+}
+
+func TestPurge_DryRun(t *testing.T) {
+	repo := &mockRetentionRepo{purgeable: 5, locked: true}
+	var recorded int
+	s := NewRetention(repo, 30, 2, func(ctx context.Context, n int) { recorded += n })
+
+	n, err := s.Purge(context.Background(), true)
+
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Empty(t, repo.limits)
+	require.Zero(t, recorded)
+}
+
+func TestPurge_Locked(t *testing.T) {
+	repo := &mockRetentionRepo{purgeable: 5, locked: true}
+	s := NewRetention(repo, 30, 2, nil)
+
+	n, err := s.Purge(context.Background(), false)
+
+	require.ErrorIs(t, err, common.ErrPurgeLocked)
+	require.Zero(t, n)
+	require.Empty(t, repo.limits)
+}
+
+func TestPurge_ErrorMidway(t *testing.T) {
+	repo := &mockRetentionRepo{purgeable: 5, err: errors.New("db fail")}
+	var recorded int
+	s := NewRetention(repo, 30, 2, func(ctx context.Context, n int) { recorded += n })
+
+	n, err := s.Purge(context.Background(), false)
+
+	require.ErrorContains(t, err, "retentionRepository.PurgeWods()")
+	// the first batch is deleted and counted all the same.
+	require.Equal(t, 2, n)
+	require.Equal(t, 2, recorded)
+	require.True(t, repo.unlocked)
+}
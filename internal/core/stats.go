@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+)
+
+type StatsInterface interface {
+	// Get aggregates the wods of the owner of f, or everyone's with
+	// AnyOwner, and the results they logged, over q.
+	Get(ctx context.Context, f repository.WodFilter, q StatsQuery) (models.Stats, error)
+}
+
+// StatsQuery bounds the stats to the wods created, and the results
+// completed, from From, inclusive, to To, exclusive, and groups them by
+// Period, StatsPeriodWeek unless set. A zero bound is open.
+type StatsQuery struct {
+	From   time.Time
+	To     time.Time
+	Period string
+}
+
+type Stats struct {
+	statsRepository repository.StatsRepositoryInterface
+}
+
+func NewStats(statsRepository repository.StatsRepositoryInterface) *Stats {
+	return &Stats{statsRepository: statsRepository}
+}
+
+func (s *Stats) Get(ctx context.Context, f repository.WodFilter, q StatsQuery) (models.Stats, error) {
+	switch q.Period {
+	case "":
+		q.Period = repository.StatsPeriodWeek
+	case repository.StatsPeriodWeek, repository.StatsPeriodMonth:
+	default:
+		return models.Stats{}, fmt.Errorf("%w: group by week or month", common.ErrStatsFilter)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return models.Stats{}, fmt.Errorf("%w: from must be before to", common.ErrStatsFilter)
+	}
+
+	stats, err := s.statsRepository.WodStats(ctx, repository.StatsFilter{
+		Owner:    f.Owner,
+		AnyOwner: f.AnyOwner,
+		From:     q.From,
+		To:       q.To,
+		Period:   q.Period,
+	})
+	if err != nil {
+		return models.Stats{}, fmt.Errorf("statsRepository.WodStats(): %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStatsRepo struct {
+	stats  models.Stats
+	err    error
+	filter repository.StatsFilter
+	called bool
+}
+
+func (m *mockStatsRepo) WodStats(ctx context.Context, f repository.StatsFilter) (models.Stats, error) {
+	m.filter = f
+	m.called = true
+	return m.stats, m.err
+}
+
+func TestStats_Get(t *testing.T) {
+	repo := &mockStatsRepo{stats: models.Stats{Wods: 3}}
+	s := NewStats(repo)
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := s.Get(context.Background(), repository.WodFilter{Owner: "alice"}, StatsQuery{From: from})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, got.Wods)
+	require.Equal(t, repository.StatsFilter{Owner: "alice", From: from, Period: repository.StatsPeriodWeek}, repo.filter,
+		"weeks by default")
+
+	_, err = s.Get(context.Background(), repository.WodFilter{AnyOwner: true},
+		StatsQuery{Period: repository.StatsPeriodMonth})
+
+	require.NoError(t, err)
+	require.Equal(t, repository.StatsFilter{AnyOwner: true, Period: repository.StatsPeriodMonth}, repo.filter)
+}
+
+func TestStats_Get_Invalid(t *testing.T) {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	for name, q := range map[string]StatsQuery{
+		"period":     {Period: "day"},
+		"empty span": {From: from, To: from},
+		"reversed":   {From: from, To: from.AddDate(0, 0, -1)},
+	} {
+		repo := &mockStatsRepo{}
+
+		_, err := NewStats(repo).Get(context.Background(), repository.WodFilter{Owner: "alice"}, q)
+
+		require.ErrorIs(t, err, common.ErrStatsFilter, name)
+		require.False(t, repo.called, name)
+	}
+}
+
+func TestStats_Get_RepoError(t *testing.T) {
+	repo := &mockStatsRepo{err: errors.New("db fail")}
+
+	_, err := NewStats(repo).Get(context.Background(), repository.WodFilter{Owner: "alice"}, StatsQuery{})
+
+	require.ErrorContains(t, err, "statsRepository.WodStats()")
+	require.NotErrorIs(t, err, common.ErrStatsFilter)
+}
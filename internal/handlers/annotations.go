@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+)
+
+func (server *Server) AnnotateWod(ctx context.Context, req AnnotateWodRequestObject) (AnnotateWodResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &AnnotateWod400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+
+	patch := core.AnnotationPatch{Favorite: req.Body.Favorite, Note: req.Body.Note, Labels: req.Body.Labels}
+	a, err := server.wodList.Annotate(ctx, req.Id, pkg.Subject(ctx), patch)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidAnnotation):
+			return &AnnotateWod400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrWodNotFound):
+			return &AnnotateWod404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		default:
+			logger.Error("server.wodList.Annotate()", slog.Any("err", err))
+			return &AnnotateWod500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	resp := AnnotateWod200JSONResponse(toAnnotations(a))
+	return &resp, nil
+}
+
+func toAnnotations(a models.Annotations) Annotations {
+	labels := a.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return Annotations{Favorite: a.Favorite, Note: a.Note, Labels: labels}
+}
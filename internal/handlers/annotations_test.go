@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateWod_Success(t *testing.T) {
+	list := &mockWodList{}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	favorite := true
+	resp, err := s.AnnotateWod(ctxWithSubject("alice", ""), handlers.AnnotateWodRequestObject{Id: uuid.New(), Body: &handlers.AnnotationsPatch{
+		Favorite: &favorite,
+	}})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.AnnotateWod200JSONResponse)
+	require.Equal(t, handlers.AnnotateWod200JSONResponse{Favorite: true, Labels: []string{}}, *r)
+	require.Nil(t, list.patch.Note, "untouched fields are left out of the patch")
+	require.Nil(t, list.patch.Labels)
+}
+
+func TestAnnotateWod_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid":   {fmt.Errorf("%w: at most 20 labels", common.ErrInvalidAnnotation), &handlers.AnnotateWod400JSONResponse{}},
+		"not found": {common.ErrWodNotFound, &handlers.AnnotateWod404JSONResponse{}},
+		"repo":      {errors.New("db fail"), &handlers.AnnotateWod500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{WodList: &mockWodList{err: tc.err}})
+		resp, err := s.AnnotateWod(context.Background(), handlers.AnnotateWodRequestObject{Id: uuid.New(), Body: &handlers.AnnotationsPatch{}})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+
+	s := newTestServer(handlers.Services{})
+	resp, err := s.AnnotateWod(context.Background(), handlers.AnnotateWodRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.AnnotateWod400JSONResponse{}, resp, "missing body")
+}
+
+func TestListWods_AnnotationFilters(t *testing.T) {
+	a := &models.Annotations{Favorite: true, Labels: []string{"engine"}}
+	list := &mockWodList{wods: []models.Wod{{ID: uuid.New(), Annotations: a}, {ID: uuid.New()}}}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	favorite, labels, note := true, []string{"engine"}, "sled"
+	resp, err := s.ListWods(ctxWithSubject("alice", ""), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{
+		Favorite: &favorite, Label: &labels, Note: &note,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Owner: "alice", Annotator: "alice", Favorite: true, Labels: []string{"engine"}, Note: "sled"}, list.filter)
+
+	r := resp.(*handlers.ListWods200JSONResponse)
+	require.Equal(t, &handlers.Annotations{Favorite: true, Labels: []string{"engine"}}, (*r.Wods)[0].Annotations)
+	require.Nil(t, (*r.Wods)[1].Annotations)
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -23,6 +24,51 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for BatchWodParamsLevel.
+const (
+	BatchWodParamsLevelAdvanced     BatchWodParamsLevel = "advanced"
+	BatchWodParamsLevelBeginner     BatchWodParamsLevel = "beginner"
+	BatchWodParamsLevelIntermediate BatchWodParamsLevel = "intermediate"
+)
+
+// Defines values for CatalogImportFormat.
+const (
+	CatalogImportFormatCsv  CatalogImportFormat = "csv"
+	CatalogImportFormatJson CatalogImportFormat = "json"
+)
+
+// Defines values for CatalogMovePattern.
+const (
+	CatalogMovePatternCarry      CatalogMovePattern = "carry"
+	CatalogMovePatternCore       CatalogMovePattern = "core"
+	CatalogMovePatternFullBody   CatalogMovePattern = "full-body"
+	CatalogMovePatternHinge      CatalogMovePattern = "hinge"
+	CatalogMovePatternJump       CatalogMovePattern = "jump"
+	CatalogMovePatternLocomotion CatalogMovePattern = "locomotion"
+	CatalogMovePatternLunge      CatalogMovePattern = "lunge"
+	CatalogMovePatternPull       CatalogMovePattern = "pull"
+	CatalogMovePatternPush       CatalogMovePattern = "push"
+	CatalogMovePatternSquat      CatalogMovePattern = "squat"
+)
+
+// Defines values for ExportResultsParamsFormat.
+const (
+	ExportResultsParamsFormatCsv   ExportResultsParamsFormat = "csv"
+	ExportResultsParamsFormatJsonl ExportResultsParamsFormat = "jsonl"
+)
+
+// Defines values for ExportWodsParamsFormat.
+const (
+	ExportWodsParamsFormatCsv   ExportWodsParamsFormat = "csv"
+	ExportWodsParamsFormatJsonl ExportWodsParamsFormat = "jsonl"
+)
+
+// Defines values for GenerateWodBatchLocale.
+const (
+	GenerateWodBatchLocaleEn GenerateWodBatchLocale = "en"
+	GenerateWodBatchLocaleFr GenerateWodBatchLocale = "fr"
+)
+
 // Defines values for GenerateWodParamsLevel.
 const (
 	GenerateWodParamsLevelAdvanced     GenerateWodParamsLevel = "advanced"
@@ -30,6 +76,90 @@ const (
 	GenerateWodParamsLevelIntermediate GenerateWodParamsLevel = "intermediate"
 )
 
+// Defines values for GenerateWodParamsLocale.
+const (
+	GenerateWodParamsLocaleEn GenerateWodParamsLocale = "en"
+	GenerateWodParamsLocaleFr GenerateWodParamsLocale = "fr"
+)
+
+// Defines values for GetCatalogParamsLevel.
+const (
+	GetCatalogParamsLevelAdvanced     GetCatalogParamsLevel = "advanced"
+	GetCatalogParamsLevelBeginner     GetCatalogParamsLevel = "beginner"
+	GetCatalogParamsLevelIntermediate GetCatalogParamsLevel = "intermediate"
+)
+
+// Defines values for GetLeaderboardParamsLevel.
+const (
+	GetLeaderboardParamsLevelAdvanced     GetLeaderboardParamsLevel = "advanced"
+	GetLeaderboardParamsLevelBeginner     GetLeaderboardParamsLevel = "beginner"
+	GetLeaderboardParamsLevelIntermediate GetLeaderboardParamsLevel = "intermediate"
+)
+
+// Defines values for GetLeaderboardParamsScope.
+const (
+	GetLeaderboardParamsScopeSeed GetLeaderboardParamsScope = "seed"
+	GetLeaderboardParamsScopeWod  GetLeaderboardParamsScope = "wod"
+)
+
+// Defines values for GetLeaderboardParamsScoring.
+const (
+	GetLeaderboardParamsScoringLoad   GetLeaderboardParamsScoring = "load"
+	GetLeaderboardParamsScoringRounds GetLeaderboardParamsScoring = "rounds"
+	GetLeaderboardParamsScoringTime   GetLeaderboardParamsScoring = "time"
+)
+
+// Defines values for GetStatsParamsGroupBy.
+const (
+	GetStatsParamsGroupByMonth GetStatsParamsGroupBy = "month"
+	GetStatsParamsGroupByWeek  GetStatsParamsGroupBy = "week"
+)
+
+// Defines values for LeaderboardScoring.
+const (
+	LeaderboardScoringLoad   LeaderboardScoring = "load"
+	LeaderboardScoringRounds LeaderboardScoring = "rounds"
+	LeaderboardScoringTime   LeaderboardScoring = "time"
+)
+
+// Defines values for ListWodsParamsEquipmentMatch.
+const (
+	ListWodsParamsEquipmentMatchAll ListWodsParamsEquipmentMatch = "all"
+	ListWodsParamsEquipmentMatchAny ListWodsParamsEquipmentMatch = "any"
+)
+
+// Defines values for ListWodsParamsLevel.
+const (
+	ListWodsParamsLevelAdvanced     ListWodsParamsLevel = "advanced"
+	ListWodsParamsLevelBeginner     ListWodsParamsLevel = "beginner"
+	ListWodsParamsLevelIntermediate ListWodsParamsLevel = "intermediate"
+)
+
+// Defines values for ListWodsParamsOrder.
+const (
+	ListWodsParamsOrderAsc  ListWodsParamsOrder = "asc"
+	ListWodsParamsOrderDesc ListWodsParamsOrder = "desc"
+)
+
+// Defines values for ListWodsParamsSort.
+const (
+	ListWodsParamsSortCreatedAt ListWodsParamsSort = "created_at"
+	ListWodsParamsSortDuration  ListWodsParamsSort = "duration"
+)
+
+// Defines values for MoveMediaType.
+const (
+	MoveMediaTypeGif   MoveMediaType = "gif"
+	MoveMediaTypeImage MoveMediaType = "image"
+	MoveMediaTypeVideo MoveMediaType = "video"
+)
+
+// Defines values for WodImportFormat.
+const (
+	WodImportFormatCsv   WodImportFormat = "csv"
+	WodImportFormatJsonl WodImportFormat = "jsonl"
+)
+
 // Defines values for WodLevel.
 const (
 	WodLevelAdvanced     WodLevel = "advanced"
@@ -37,263 +167,4502 @@ const (
 	WodLevelIntermediate WodLevel = "intermediate"
 )
 
+// Defines values for WodSearchLevel.
+const (
+	WodSearchLevelAdvanced     WodSearchLevel = "advanced"
+	WodSearchLevelBeginner     WodSearchLevel = "beginner"
+	WodSearchLevelIntermediate WodSearchLevel = "intermediate"
+)
+
+// Defines values for WodSearchOrder.
+const (
+	WodSearchOrderAsc  WodSearchOrder = "asc"
+	WodSearchOrderDesc WodSearchOrder = "desc"
+)
+
+// Defines values for WodSearchSort.
+const (
+	WodSearchSortCreatedAt WodSearchSort = "created_at"
+	WodSearchSortDuration  WodSearchSort = "duration"
+)
+
+// Annotations What the owner keeps on the WOD, only returned to them
+type Annotations struct {
+	Favorite bool     `json:"favorite"`
+	Labels   []string `json:"labels"`
+	Note     string   `json:"note"`
+}
+
+// AnnotationsPatch The annotations to change, the omitted ones are kept
+type AnnotationsPatch struct {
+	Favorite *bool `json:"favorite,omitempty"`
+
+	// Labels Labels replacing the current ones, lowercased
+	Labels *[]string `json:"labels,omitempty"`
+
+	// Note Free text note, empty to remove it
+	Note *string `json:"note,omitempty"`
+}
+
+// BatchWodParams defines model for BatchWodParams.
+type BatchWodParams struct {
+
+	// Catalog Catalog to pick moves from, the default catalog when omitted
+	Catalog     *string             `json:"catalog,omitempty"`
+	DurationMin int                 `json:"duration_min"`
+	Equipment   *[]string           `json:"equipment,omitempty"`
+	Level       BatchWodParamsLevel `json:"level"`
+	Seed        *string             `json:"seed,omitempty"`
+}
+
+// BatchWodParamsLevel defines model for BatchWodParams.Level.
+type BatchWodParamsLevel string
+
 // Block A workout block (movement + params)
 type Block struct {
+
+	// Id Stable move ID, the name is localized
+	Id *string `json:"id,omitempty"`
+
+	// Move Descriptive fields of a move, in the response locale
+	Move   *MoveDetails            `json:"move,omitempty"`
 	Name   *string                 `json:"name,omitempty"`
 	Params *map[string]interface{} `json:"params,omitempty"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+// CalendarEntry defines model for CalendarEntry.
+type CalendarEntry struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Date      openapi_types.Date `json:"date"`
+	Id        openapi_types.UUID `json:"id"`
+	Note      string             `json:"note"`
+	Program   string             `json:"program"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	WodId     openapi_types.UUID `json:"wod_id"`
 }
 
-// GenerateWodParams defines model for GenerateWodParams.
-type GenerateWodParams struct {
-	DurationMin int                    `json:"duration_min" validate:"required,min=15,max=120"`
-	Equipment   *[]string              `json:"equipment,omitempty"`
-	Level       GenerateWodParamsLevel `json:"level" validate:"required,oneof=beginner intermediate advanced"`
-	Seed        *string                `json:"seed,omitempty"`
+// CalendarEntryInput defines model for CalendarEntryInput.
+type CalendarEntryInput struct {
+	Date openapi_types.Date `json:"date"`
+	Note *string            `json:"note,omitempty"`
+
+	// Program Program the entry belongs to
+	Program *string            `json:"program,omitempty"`
+	WodId   openapi_types.UUID `json:"wod_id"`
 }
 
-// GenerateWodParamsLevel defines model for GenerateWodParams.Level.
-type GenerateWodParamsLevel string
+// CalendarEntryPatch The fields to change, the omitted ones are kept
+type CalendarEntryPatch struct {
+	Date *openapi_types.Date `json:"date,omitempty"`
 
-// Wod defines model for Wod.
-type Wod struct {
-	Blocks           []Block            `json:"blocks"`
-	CreatedAt        time.Time          `json:"created_at"`
-	DurationMin      int                `json:"duration_min"`
-	Equipment        *[]string          `json:"equipment,omitempty"`
-	GeneratorVersion string             `json:"generator_version"`
-	Id               openapi_types.UUID `json:"id"`
-	Level            WodLevel           `json:"level"`
-	Seed             string             `json:"seed"`
+	// Note Empty to remove it
+	Note *string `json:"note,omitempty"`
+
+	// Program Empty to take the entry out of its program
+	Program *string             `json:"program,omitempty"`
+	WodId   *openapi_types.UUID `json:"wod_id,omitempty"`
 }
 
-// WodLevel defines model for Wod.Level.
-type WodLevel string
+// CalendarFeed defines model for CalendarFeed.
+type CalendarFeed struct {
 
-// GenerateWodRequest defines model for GenerateWodRequest.
-type GenerateWodRequest = GenerateWodParams
+	// Url Feed URL holding the token, to subscribe to from a calendar app
+	Url string `json:"url"`
+}
 
-// ListWodsParams defines parameters for ListWods.
-type ListWodsParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+// CalendarPage defines model for CalendarPage.
+type CalendarPage struct {
+	Entries *[]CalendarEntry `json:"entries,omitempty"`
 }
 
-// GenerateWodJSONRequestBody defines body for GenerateWod for application/json ContentType.
-type GenerateWodJSONRequestBody = GenerateWodParams
+// Catalog defines model for Catalog.
+type Catalog struct {
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
+	// Catalogs Names of every catalog served
+	Catalogs  []string `json:"catalogs"`
+	Equipment []string `json:"equipment"`
 
-	// (POST /wod/generate)
-	GenerateWod(c *gin.Context)
-	// List stored WODs
-	// (GET /wod/list)
-	ListWods(c *gin.Context, params ListWodsParams)
+	// Hash sha256 of the catalog content, also sent as ETag
+	Hash    string        `json:"hash"`
+	Levels  []string      `json:"levels"`
+	Moves   []CatalogMove `json:"moves"`
+	Name    string        `json:"name"`
+	Tags    []string      `json:"tags"`
+	Version int64         `json:"version"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
+// CatalogDiff defines model for CatalogDiff.
+type CatalogDiff struct {
+
+	// Added IDs of the new moves
+	Added            []string `json:"added"`
+	Changed          []string `json:"changed"`
+	EquipmentChanged bool     `json:"equipment_changed"`
+	LevelsChanged    bool     `json:"levels_changed"`
+	Removed          []string `json:"removed"`
 }
 
-type MiddlewareFunc func(c *gin.Context)
+// CatalogImport defines model for CatalogImport.
+type CatalogImport struct {
 
-// GenerateWod operation middleware
-func (siw *ServerInterfaceWrapper) GenerateWod(c *gin.Context) {
+	// Content The exported file, see the README for the CSV columns
+	Content string `json:"content"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// DryRun Validate and diff only
+	DryRun *bool               `json:"dry_run,omitempty"`
+	Format CatalogImportFormat `json:"format"`
 
-	siw.Handler.GenerateWod(c)
+	// Yaml Also return the resulting catalog as YAML
+	Yaml *bool `json:"yaml,omitempty"`
 }
 
-// ListWods operation middleware
-func (siw *ServerInterfaceWrapper) ListWods(c *gin.Context) {
+// CatalogImportFormat defines model for CatalogImport.Format.
+type CatalogImportFormat string
 
-	var err error
+// CatalogImportResult defines model for CatalogImportResult.
+type CatalogImportResult struct {
+	Diff   CatalogDiff `json:"diff"`
+	DryRun bool        `json:"dry_run"`
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListWodsParams
+	// Hash Content hash of the imported catalog
+	Hash string `json:"hash"`
+	Name string `json:"name"`
 
-	// ------------- Optional query parameter "limit" -------------
+	// Version Version served after the import, the current one on a dry run
+	Version int64 `json:"version"`
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Yaml The imported catalog as a YAML file, when asked
+	Yaml *string `json:"yaml,omitempty"`
+}
 
-	// ------------- Optional query parameter "offset" -------------
+// CatalogMove defines model for CatalogMove.
+type CatalogMove struct {
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Cues Coaching cues
+	Cues        *[]string `json:"cues,omitempty"`
+	Description *string   `json:"description,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// DisplayCues Coaching cues in the response locale, ignored on writes
+	DisplayCues *[]string `json:"display_cues,omitempty"`
 
-	siw.Handler.ListWods(c, params)
-}
+	// DisplayDescription Description in the response locale, ignored on writes
+	DisplayDescription *string `json:"display_description,omitempty"`
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+	// DisplayName Name in the response locale, ignored on writes
+	DisplayName *string `json:"display_name,omitempty"`
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+	// Id Stable move ID, a slug of the name when omitted
+	Id *string `json:"id,omitempty"`
+
+	// Locales locale -> display texts
+	Locales *map[string]MoveTranslation `json:"locales,omitempty"`
+	Media   *[]MoveMedia                `json:"media,omitempty"`
+
+	// Muscles Primary muscle groups
+	Muscles    *[]string `json:"muscles,omitempty"`
+	Name       string    `json:"name"`
+	NeedsOneOf *[]string `json:"needs_one_of,omitempty"`
+
+	// Pattern Movement pattern
+	Pattern *CatalogMovePattern `json:"pattern,omitempty"`
+
+	// Ranges level -> param -> [min, max]
+	Ranges *CatalogRanges `json:"ranges,omitempty"`
+	Tags   *[]string      `json:"tags,omitempty"`
+
+	// Units param -> unit, params must have ranges
+	Units  *map[string]string `json:"units,omitempty"`
+	Weight *float64           `json:"weight,omitempty"`
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+// CatalogMovePattern defines model for CatalogMove.Pattern.
+type CatalogMovePattern string
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
-	}
+// CatalogMoveList defines model for CatalogMoveList.
+type CatalogMoveList struct {
+	Moves   []CatalogMove `json:"moves"`
+	Name    string        `json:"name"`
+	Version int64         `json:"version"`
+}
 
-	router.POST(options.BaseURL+"/wod/generate", wrapper.GenerateWod)
-	router.GET(options.BaseURL+"/wod/list", wrapper.ListWods)
+// CatalogRanges level -> param -> [min, max]
+type CatalogRanges map[string]map[string][]int
+
+// CountStat defines model for CountStat.
+type CountStat struct {
+	Key  string `json:"key"`
+	Wods int    `json:"wods"`
 }
 
-type GenerateWodRequestObject struct {
-	Body *GenerateWodJSONRequestBody
+// DurationStat defines model for DurationStat.
+type DurationStat struct {
+	DurationMin int `json:"duration_min"`
+	Wods        int `json:"wods"`
 }
 
-type GenerateWodResponseObject interface {
-	VisitGenerateWodResponse(w http.ResponseWriter) error
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
 
-type GenerateWod200JSONResponse Wod
+// GenerateWodBatch Either `items`, or `params` and `count`.
+type GenerateWodBatch struct {
 
-func (response GenerateWod200JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// Count WODs generated from `params`, seeded `<seed>-1` to `<seed>-<count>` when `params` has a seed
+	Count *int `json:"count,omitempty"`
 
-	return json.NewEncoder(w).Encode(response)
+	// Expand Related objects to embed, `moves` adds the move details to every block
+	Expand *[]string         `json:"expand,omitempty"`
+	Items  *[]BatchWodParams `json:"items,omitempty"`
+
+	// Locale Language of the response, overrides Accept-Language
+	Locale *GenerateWodBatchLocale `json:"locale,omitempty"`
+	Params *BatchWodParams         `json:"params,omitempty"`
 }
 
-type GenerateWod400JSONResponse ErrorResponse
+// GenerateWodBatchLocale defines model for GenerateWodBatch.Locale.
+type GenerateWodBatchLocale string
 
-func (response GenerateWod400JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// GenerateWodParams defines model for GenerateWodParams.
+type GenerateWodParams struct {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Catalog Catalog to pick moves from, the default catalog when omitted
+	Catalog     *string `json:"catalog,omitempty"`
+	DurationMin int     `json:"duration_min" validate:"required,min=15,max=120"`
 
-type GenerateWod401JSONResponse ErrorResponse
+	// Equipment Equipment available, see GET /catalog for the known values
+	Equipment *[]string `json:"equipment,omitempty"`
 
-func (response GenerateWod401JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// Expand Related objects to embed, `moves` adds the move details to every block
+	Expand *[]string              `json:"expand,omitempty"`
+	Level  GenerateWodParamsLevel `json:"level" validate:"required,oneof=beginner intermediate advanced"`
 
-	return json.NewEncoder(w).Encode(response)
+	// Locale Language of the response, overrides Accept-Language
+	Locale *GenerateWodParamsLocale `json:"locale,omitempty"`
+	Seed   *string                  `json:"seed,omitempty"`
 }
 
-type GenerateWod403JSONResponse ErrorResponse
+// GenerateWodParamsLevel defines model for GenerateWodParams.Level.
+type GenerateWodParamsLevel string
 
-func (response GenerateWod403JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+// GenerateWodParamsLocale defines model for GenerateWodParams.Locale.
+type GenerateWodParamsLocale string
 
-	return json.NewEncoder(w).Encode(response)
+// Leaderboard defines model for Leaderboard.
+type Leaderboard struct {
+	Entries []LeaderboardEntry `json:"entries"`
+	Scoring LeaderboardScoring `json:"scoring"`
+
+	// Wods Count of the WODs ranked together
+	Wods int `json:"wods"`
 }
 
-type GenerateWod429JSONResponse ErrorResponse
+// LeaderboardScoring defines model for Leaderboard.Scoring.
+type LeaderboardScoring string
 
-func (response GenerateWod429JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(429)
+// LeaderboardEntry defines model for LeaderboardEntry.
+type LeaderboardEntry struct {
+	Rank   int    `json:"rank"`
+	Result Result `json:"result"`
+}
 
-	return json.NewEncoder(w).Encode(response)
+// MoveDetails Descriptive fields of a move, in the response locale
+type MoveDetails struct {
+	Cues        *[]string    `json:"cues,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Media       *[]MoveMedia `json:"media,omitempty"`
+	Muscles     *[]string    `json:"muscles,omitempty"`
+	Pattern     *string      `json:"pattern,omitempty"`
+
+	// Units param -> unit
+	Units *map[string]string `json:"units,omitempty"`
 }
 
-type GenerateWod500JSONResponse ErrorResponse
+// MoveMatch defines model for MoveMatch.
+type MoveMatch struct {
 
-func (response GenerateWod500JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// Move Move ID or name
+	Move string `json:"move"`
 
-	return json.NewEncoder(w).Encode(response)
+	// Params Bounds on the total of each param over the blocks of the move
+	Params *map[string]ParamRange `json:"params,omitempty"`
 }
 
-type ListWodsRequestObject struct {
-	Params ListWodsParams
+// MoveMedia defines model for MoveMedia.
+type MoveMedia struct {
+	Title *string       `json:"title,omitempty"`
+	Type  MoveMediaType `json:"type"`
+	Url   string        `json:"url"`
 }
 
-type ListWodsResponseObject interface {
-	VisitListWodsResponse(w http.ResponseWriter) error
+// MoveMediaType defines model for MoveMedia.Type.
+type MoveMediaType string
+
+// MoveStat defines model for MoveStat.
+type MoveStat struct {
+	Blocks int `json:"blocks"`
+
+	// Id Move ID, omitted for blocks stored before moves had IDs
+	Id   *string `json:"id,omitempty"`
+	Name string  `json:"name"`
+	Wods int     `json:"wods"`
 }
 
-type ListWods200JSONResponse struct {
-	Wods *[]Wod `json:"wods,omitempty"`
+// MoveTranslation defines model for MoveTranslation.
+type MoveTranslation struct {
+	Cues        *[]string `json:"cues,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Name        *string   `json:"name,omitempty"`
 }
 
-func (response ListWods200JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ParamRange defines model for ParamRange.
+type ParamRange struct {
 
-	return json.NewEncoder(w).Encode(response)
+	// Max Inclusive upper bound
+	Max *float64 `json:"max,omitempty"`
+
+	// Min Inclusive lower bound
+	Min *float64 `json:"min,omitempty"`
 }
 
-type ListWods500JSONResponse ErrorResponse
+// PeriodStat defines model for PeriodStat.
+type PeriodStat struct {
 
-func (response ListWods500JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// Meters Meters of the blocks of the WODs
+	Meters float64 `json:"meters"`
 
-	return json.NewEncoder(w).Encode(response)
+	// Reps Reps of the blocks of the WODs
+	Reps    float64 `json:"reps"`
+	Results int     `json:"results"`
+
+	// Start First day of the period
+	Start openapi_types.Date `json:"start"`
+	Wods  int                `json:"wods"`
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
+// ProgramInput defines model for ProgramInput.
+type ProgramInput struct {
 
-	// (POST /wod/generate)
-	GenerateWod(ctx context.Context, request GenerateWodRequestObject) (GenerateWodResponseObject, error)
-	// List stored WODs
-	// (GET /wod/list)
+	// EveryDays Days between two WODs
+	EveryDays *int   `json:"every_days,omitempty"`
+	Name      string `json:"name"`
+
+	// Note Note set on every entry
+	Note *string `json:"note,omitempty"`
+
+	// Start Day of the first WOD
+	Start openapi_types.Date `json:"start"`
+
+	// WodIds WODs in the order they are done, a WOD may repeat
+	WodIds []openapi_types.UUID `json:"wod_ids"`
+}
+
+// Result defines model for Result.
+type Result struct {
+
+	// Athlete JWT subject of the athlete
+	Athlete     string             `json:"athlete"`
+	CompletedAt time.Time          `json:"completed_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Division    *string            `json:"division,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
+	LoadKg      *float64           `json:"load_kg,omitempty"`
+	Reps        *int               `json:"reps,omitempty"`
+	Rounds      *int               `json:"rounds,omitempty"`
+	Rpe         *int               `json:"rpe,omitempty"`
+	Scaling     *string            `json:"scaling,omitempty"`
+	Splits      *[]Split           `json:"splits,omitempty"`
+	TimeSec     *int               `json:"time_sec,omitempty"`
+	WodId       openapi_types.UUID `json:"wod_id"`
+}
+
+// ResultInput defines model for ResultInput.
+type ResultInput struct {
+
+	// CompletedAt When the WOD was done, now when omitted
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Division Division ranked in on leaderboards, lowercased
+	Division *string `json:"division,omitempty"`
+
+	// LoadKg Heaviest load lifted, for a WOD scored by load
+	LoadKg *float64 `json:"load_kg,omitempty"`
+
+	// Reps Reps of the unfinished round, with `rounds`
+	Reps *int `json:"reps,omitempty"`
+
+	// Rounds Rounds completed, for a WOD done for rounds and reps
+	Rounds *int `json:"rounds,omitempty"`
+
+	// Rpe Rate of perceived exertion
+	Rpe *int `json:"rpe,omitempty"`
+
+	// Scaling How the WOD was scaled
+	Scaling *string  `json:"scaling,omitempty"`
+	Splits  *[]Split `json:"splits,omitempty"`
+
+	// TimeSec Total time, for a WOD done for time
+	TimeSec *int `json:"time_sec,omitempty"`
+}
+
+// ResultPage defines model for ResultPage.
+type ResultPage struct {
+	Results *[]Result `json:"results,omitempty"`
+}
+
+// Split defines model for Split.
+type Split struct {
+
+	// Block Position of the block in the WOD, from 1
+	Block   int `json:"block"`
+	TimeSec int `json:"time_sec"`
+}
+
+// Stats defines model for Stats.
+type Stats struct {
+
+	// Durations WODs by duration, shortest first
+	Durations []DurationStat `json:"durations"`
+
+	// Equipment WODs by equipment, most first
+	Equipment []CountStat `json:"equipment"`
+
+	// Levels WODs by level, most first
+	Levels []CountStat `json:"levels"`
+
+	// Moves Moves by the blocks they were picked for, most picked first
+	Moves []MoveStat `json:"moves"`
+
+	// Periods Weeks or months with WODs or results, oldest first
+	Periods []PeriodStat `json:"periods"`
+
+	// Results Results logged
+	Results int `json:"results"`
+
+	// Wods WODs generated
+	Wods int `json:"wods"`
+}
+
+// Wod defines model for Wod.
+type Wod struct {
+
+	// Annotations What the owner keeps on the WOD, only returned to them
+	Annotations *Annotations `json:"annotations,omitempty"`
+	Blocks      []Block      `json:"blocks"`
+
+	// Catalog Catalog the blocks were picked from
+	Catalog string `json:"catalog"`
+
+	// CatalogHash Content hash of the catalog at generation time, empty for WODs stored before it was recorded
+	CatalogHash string `json:"catalog_hash"`
+
+	// CatalogVersion Version of the catalog at generation time
+	CatalogVersion   int64              `json:"catalog_version"`
+	CreatedAt        time.Time          `json:"created_at"`
+	DurationMin      int                `json:"duration_min"`
+	Equipment        *[]string          `json:"equipment,omitempty"`
+	GeneratorVersion string             `json:"generator_version"`
+	Id               openapi_types.UUID `json:"id"`
+	Level            WodLevel           `json:"level"`
+
+	// Owner JWT subject of the caller who generated it
+	Owner *string `json:"owner,omitempty"`
+	Seed  string  `json:"seed"`
+}
+
+// WodLevel defines model for Wod.Level.
+type WodLevel string
+
+// WodBatch defines model for WodBatch.
+type WodBatch struct {
+
+	// Failed Items with an error
+	Failed int `json:"failed"`
+
+	// Generated Items with a new WOD
+	Generated int            `json:"generated"`
+	Items     []WodBatchItem `json:"items"`
+
+	// Replayed Items with a WOD stored before
+	Replayed int `json:"replayed"`
+}
+
+// WodBatchItem defines model for WodBatchItem.
+type WodBatchItem struct {
+	Error *ErrorResponse `json:"error,omitempty"`
+
+	// Index Position of the item in the batch, from 0
+	Index    int   `json:"index"`
+	Replayed *bool `json:"replayed,omitempty"`
+	Wod      *Wod  `json:"wod,omitempty"`
+}
+
+// WodImport defines model for WodImport.
+type WodImport struct {
+
+	// Content The exported file, see the README for the CSV columns
+	Content string `json:"content"`
+
+	// DryRun Validate only
+	DryRun *bool           `json:"dry_run,omitempty"`
+	Format WodImportFormat `json:"format"`
+}
+
+// WodImportFormat defines model for WodImport.Format.
+type WodImportFormat string
+
+// WodImportError defines model for WodImportError.
+type WodImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// WodImportResult defines model for WodImportResult.
+type WodImportResult struct {
+	DryRun bool `json:"dry_run"`
+
+	// Errors Why the first 100 refused rows were refused
+	Errors []WodImportError `json:"errors"`
+
+	// Failed Rows refused
+	Failed int `json:"failed"`
+
+	// Imported WODs stored, none on a dry run
+	Imported int `json:"imported"`
+
+	// Rows Rows read
+	Rows int `json:"rows"`
+}
+
+// WodPage A page of WODs. The cursors are opaque, pass one back as `cursor`
+// with the same filters and sort to get the page after or before;
+// they are omitted at the ends of the listing.
+type WodPage struct {
+
+	// NextCursor Cursor of the next page
+	NextCursor *string `json:"next_cursor,omitempty"`
+
+	// PrevCursor Cursor of the previous page
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+
+	// Total Count of every WOD matching the filters, with `include_total` only
+	Total *int   `json:"total,omitempty"`
+	Wods  *[]Wod `json:"wods,omitempty"`
+}
+
+// WodSearch defines model for WodSearch.
+type WodSearch struct {
+
+	// All Moves the WODs all have
+	All *[]MoveMatch `json:"all,omitempty"`
+
+	// Any Moves the WODs have at least one of
+	Any *[]MoveMatch `json:"any,omitempty"`
+
+	// Catalog Only WODs generated from this catalog, the moves are looked up in it
+	Catalog *string `json:"catalog,omitempty"`
+
+	// Cursor `next_cursor` or `prev_cursor` of a previous page of the same search
+	Cursor *string `json:"cursor,omitempty"`
+
+	// IncludeTotal Count every WOD matching in `total`
+	IncludeTotal *bool           `json:"include_total,omitempty"`
+	Level        *WodSearchLevel `json:"level,omitempty"`
+	Limit        *int            `json:"limit,omitempty"`
+	MaxDuration  *int            `json:"max_duration,omitempty"`
+	MinDuration  *int            `json:"min_duration,omitempty"`
+
+	// None Moves the WODs have none of
+	None  *[]MoveMatch    `json:"none,omitempty"`
+	Order *WodSearchOrder `json:"order,omitempty"`
+
+	// Owner Admins only, search the WODs of this subject instead of their own, `*` for every owner
+	Owner *string        `json:"owner,omitempty"`
+	Sort  *WodSearchSort `json:"sort,omitempty"`
+}
+
+// WodSearchLevel defines model for WodSearch.Level.
+type WodSearchLevel string
+
+// WodSearchOrder defines model for WodSearch.Order.
+type WodSearchOrder string
+
+// WodSearchSort defines model for WodSearch.Sort.
+type WodSearchSort string
+
+// CatalogMoveRequest defines model for CatalogMoveRequest.
+type CatalogMoveRequest = CatalogMove
+
+// GenerateWodRequest defines model for GenerateWodRequest.
+type GenerateWodRequest = GenerateWodParams
+
+// ResultRequest defines model for ResultRequest.
+type ResultRequest = ResultInput
+
+// ListCalendarParams defines parameters for ListCalendar.
+type ListCalendarParams struct {
+
+	// From First day listed
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Day the listing stops before
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Program Only the entries of this program
+	Program *string `form:"program,omitempty" json:"program,omitempty"`
+	Limit   *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset  *int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetCalendarFeedParams defines parameters for GetCalendarFeed.
+type GetCalendarFeedParams struct {
+	Token string `form:"token" json:"token"`
+}
+
+// GetCatalogParams defines parameters for GetCatalog.
+type GetCatalogParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+
+	// Tag Only moves carrying this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Equipment Only moves doable with this equipment (bodyweight moves included)
+	Equipment *[]string `form:"equipment,omitempty" json:"equipment,omitempty"`
+
+	// Level Only moves with ranges for this level, other levels are left out
+	Level       *GetCatalogParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+	IfNoneMatch *string                `json:"If-None-Match,omitempty"`
+}
+
+// GetCatalogParamsLevel defines parameters for GetCatalog.
+type GetCatalogParamsLevel string
+
+// ImportCatalogParams defines parameters for ImportCatalog.
+type ImportCatalogParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// ListCatalogMovesParams defines parameters for ListCatalogMoves.
+type ListCatalogMovesParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// CreateCatalogMoveParams defines parameters for CreateCatalogMove.
+type CreateCatalogMoveParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// DeleteCatalogMoveParams defines parameters for DeleteCatalogMove.
+type DeleteCatalogMoveParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// GetCatalogMoveParams defines parameters for GetCatalogMove.
+type GetCatalogMoveParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog     *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// UpdateCatalogMoveParams defines parameters for UpdateCatalogMove.
+type UpdateCatalogMoveParams struct {
+
+	// Catalog Catalog name, the default catalog when omitted
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// ListResultsParams defines parameters for ListResults.
+type ListResultsParams struct {
+
+	// Athlete Admins only, list the results of this subject instead of their own
+	Athlete *string `form:"athlete,omitempty" json:"athlete,omitempty"`
+	Limit   *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset  *int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ExportResultsParams defines parameters for ExportResults.
+type ExportResultsParams struct {
+
+	// Format JSON lines, a JSON object a line, or CSV with a header
+	Format *ExportResultsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Owner Admins only, export this subject's instead of their own, `*` for every subject
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// From Only results completed at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only results completed before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportResultsParamsFormat defines parameters for ExportResults.
+type ExportResultsParamsFormat string
+
+// GetStatsParams defines parameters for GetStats.
+type GetStatsParams struct {
+
+	// Owner Admins only, the stats of this subject instead of their own, `*` for every subject
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// From Only WODs created, and results completed, at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only WODs created, and results completed, before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// GroupBy Periods of `periods`, weeks start on Monday, UTC
+	GroupBy *GetStatsParamsGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// GetStatsParamsGroupBy defines parameters for GetStats.
+type GetStatsParamsGroupBy string
+
+// ExportWodsParams defines parameters for ExportWods.
+type ExportWodsParams struct {
+
+	// Format JSON lines, a JSON object a line, or CSV with a header
+	Format *ExportWodsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Owner Admins only, export this subject's instead of their own, `*` for every subject
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// From Only WODs created at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only WODs created before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportWodsParamsFormat defines parameters for ExportWods.
+type ExportWodsParamsFormat string
+
+// ListWodsParams defines parameters for ListWods.
+type ListWodsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Deprecated, page with `cursor` instead. Cannot be combined with `cursor`.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor `next_cursor` or `prev_cursor` of a previous page, with the same filters and sort
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// IncludeTotal Count every WOD matching the filters in `total`
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
+
+	// Catalog Only WODs generated from this catalog
+	Catalog *string `form:"catalog,omitempty" json:"catalog,omitempty"`
+
+	// CatalogVersion Only WODs generated from this catalog version, of the default catalog when `catalog` is omitted
+	CatalogVersion *int64 `form:"catalog_version,omitempty" json:"catalog_version,omitempty"`
+
+	// CatalogHash Only WODs generated from a catalog with this content hash
+	CatalogHash *string              `form:"catalog_hash,omitempty" json:"catalog_hash,omitempty"`
+	Level       *ListWodsParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// MinDuration Only WODs lasting at least this many minutes
+	MinDuration *int `form:"min_duration,omitempty" json:"min_duration,omitempty"`
+
+	// MaxDuration Only WODs lasting at most this many minutes
+	MaxDuration *int `form:"max_duration,omitempty" json:"max_duration,omitempty"`
+
+	// Equipment Only WODs generated with this equipment, see `equipment_match`
+	Equipment *[]string `form:"equipment,omitempty" json:"equipment,omitempty"`
+
+	// EquipmentMatch `all`: the WOD lists every `equipment` given, `any`: at least one of them
+	EquipmentMatch *ListWodsParamsEquipmentMatch `form:"equipment_match,omitempty" json:"equipment_match,omitempty"`
+
+	// CreatedAfter Only WODs created at or after this time
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only WODs created before this time
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+	Seed          *string    `form:"seed,omitempty" json:"seed,omitempty"`
+
+	// Move Only WODs with a block of this move, by ID or name
+	Move  *string              `form:"move,omitempty" json:"move,omitempty"`
+	Sort  *ListWodsParamsSort  `form:"sort,omitempty" json:"sort,omitempty"`
+	Order *ListWodsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Favorite Only the WODs the caller marked as favorite
+	Favorite *bool `form:"favorite,omitempty" json:"favorite,omitempty"`
+
+	// Label Only the WODs the caller put all these labels on
+	Label *[]string `form:"label,omitempty" json:"label,omitempty"`
+
+	// Note Only the WODs whose note by the caller contains this text, case insensitive
+	Note *string `form:"note,omitempty" json:"note,omitempty"`
+
+	// Owner Admins only, list the WODs of this subject instead of their own, `*` for every owner
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// Expand Related objects to embed, `moves` adds the move details to every block
+	Expand *[]string `form:"expand,omitempty" json:"expand,omitempty"`
+}
+
+// ListWodsParamsLevel defines parameters for ListWods.
+type ListWodsParamsLevel string
+
+// ListWodsParamsEquipmentMatch defines parameters for ListWods.
+type ListWodsParamsEquipmentMatch string
+
+// ListWodsParamsSort defines parameters for ListWods.
+type ListWodsParamsSort string
+
+// ListWodsParamsOrder defines parameters for ListWods.
+type ListWodsParamsOrder string
+
+// SearchWodsParams defines parameters for SearchWods.
+type SearchWodsParams struct {
+
+	// Expand Related objects to embed, `moves` adds the move details to every block
+	Expand *[]string `form:"expand,omitempty" json:"expand,omitempty"`
+}
+
+// GetWodParams defines parameters for GetWod.
+type GetWodParams struct {
+
+	// Expand Related objects to embed, `moves` adds the move details to every block
+	Expand *[]string `form:"expand,omitempty" json:"expand,omitempty"`
+}
+
+// GetLeaderboardParams defines parameters for GetLeaderboard.
+type GetLeaderboardParams struct {
+
+	// Scope `wod`: this WOD only, `seed`: every WOD generated with its parameters
+	Scope *GetLeaderboardParamsScope `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// Scoring Scoring rule, the one most results were logged with when omitted
+	Scoring *GetLeaderboardParamsScoring `form:"scoring,omitempty" json:"scoring,omitempty"`
+
+	// Level Level of the WODs, the level of this WOD when omitted
+	Level *GetLeaderboardParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Division Only results logged in this division
+	Division *string `form:"division,omitempty" json:"division,omitempty"`
+
+	// CompletedAfter Only results completed at or after this time
+	CompletedAfter *time.Time `form:"completed_after,omitempty" json:"completed_after,omitempty"`
+
+	// CompletedBefore Only results completed before this time
+	CompletedBefore *time.Time `form:"completed_before,omitempty" json:"completed_before,omitempty"`
+	Limit           *int       `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset          *int       `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetLeaderboardParamsScope defines parameters for GetLeaderboard.
+type GetLeaderboardParamsScope string
+
+// GetLeaderboardParamsScoring defines parameters for GetLeaderboard.
+type GetLeaderboardParamsScoring string
+
+// GetLeaderboardParamsLevel defines parameters for GetLeaderboard.
+type GetLeaderboardParamsLevel string
+
+// ListWodResultsParams defines parameters for ListWodResults.
+type ListWodResultsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ScheduleWodJSONRequestBody defines body for ScheduleWod for application/json ContentType.
+type ScheduleWodJSONRequestBody = CalendarEntryInput
+
+// ScheduleProgramJSONRequestBody defines body for ScheduleProgram for application/json ContentType.
+type ScheduleProgramJSONRequestBody = ProgramInput
+
+// UpdateCalendarEntryJSONRequestBody defines body for UpdateCalendarEntry for application/json ContentType.
+type UpdateCalendarEntryJSONRequestBody = CalendarEntryPatch
+
+// ImportCatalogJSONRequestBody defines body for ImportCatalog for application/json ContentType.
+type ImportCatalogJSONRequestBody = CatalogImport
+
+// CreateCatalogMoveJSONRequestBody defines body for CreateCatalogMove for application/json ContentType.
+type CreateCatalogMoveJSONRequestBody = CatalogMove
+
+// UpdateCatalogMoveJSONRequestBody defines body for UpdateCatalogMove for application/json ContentType.
+type UpdateCatalogMoveJSONRequestBody = CatalogMove
+
+// GenerateWodJSONRequestBody defines body for GenerateWod for application/json ContentType.
+type GenerateWodJSONRequestBody = GenerateWodParams
+
+// GenerateWodBatchJSONRequestBody defines body for GenerateWodBatch for application/json ContentType.
+type GenerateWodBatchJSONRequestBody = GenerateWodBatch
+
+// ImportWodsJSONRequestBody defines body for ImportWods for application/json ContentType.
+type ImportWodsJSONRequestBody = WodImport
+
+// SearchWodsJSONRequestBody defines body for SearchWods for application/json ContentType.
+type SearchWodsJSONRequestBody = WodSearch
+
+// AnnotateWodJSONRequestBody defines body for AnnotateWod for application/json ContentType.
+type AnnotateWodJSONRequestBody = AnnotationsPatch
+
+// LogResultJSONRequestBody defines body for LogResult for application/json ContentType.
+type LogResultJSONRequestBody = ResultInput
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List the caller's calendar
+	// (GET /calendar)
+	ListCalendar(c *gin.Context, params ListCalendarParams)
+	// Schedule a WOD on a day
+	// (POST /calendar)
+	ScheduleWod(c *gin.Context)
+	// Revoke the caller's calendar feed token
+	// (DELETE /calendar/feed)
+	RevokeCalendarFeed(c *gin.Context)
+	// Create the caller's calendar feed token
+	// (POST /calendar/feed)
+	RotateCalendarFeed(c *gin.Context)
+	// iCalendar feed of a calendar (token protected)
+	// (GET /calendar/feed.ics)
+	GetCalendarFeed(c *gin.Context, params GetCalendarFeedParams)
+	// Schedule a program
+	// (POST /calendar/programs)
+	ScheduleProgram(c *gin.Context)
+	// Unschedule a program
+	// (DELETE /calendar/programs/{program})
+	UnscheduleProgram(c *gin.Context, program string)
+	// Delete an entry of the caller's calendar
+	// (DELETE /calendar/{id})
+	DeleteCalendarEntry(c *gin.Context, id openapi_types.UUID)
+	// Get an entry of the caller's calendar
+	// (GET /calendar/{id})
+	GetCalendarEntry(c *gin.Context, id openapi_types.UUID)
+	// Change an entry of the caller's calendar
+	// (PATCH /calendar/{id})
+	UpdateCalendarEntry(c *gin.Context, id openapi_types.UUID)
+	// Browse the movement catalog (public)
+	// (GET /catalog)
+	GetCatalog(c *gin.Context, params GetCatalogParams)
+	// Import a catalog from a spreadsheet export (admin)
+	// (POST /catalog/import)
+	ImportCatalog(c *gin.Context, params ImportCatalogParams)
+	// List catalog moves (admin)
+	// (GET /catalog/moves)
+	ListCatalogMoves(c *gin.Context, params ListCatalogMovesParams)
+	// Add a move to the catalog (admin)
+	// (POST /catalog/moves)
+	CreateCatalogMove(c *gin.Context, params CreateCatalogMoveParams)
+	// Remove a catalog move (admin)
+	// (DELETE /catalog/moves/{name})
+	DeleteCatalogMove(c *gin.Context, name string, params DeleteCatalogMoveParams)
+	// Get a catalog move (public)
+	// (GET /catalog/moves/{name})
+	GetCatalogMove(c *gin.Context, name string, params GetCatalogMoveParams)
+	// Replace a catalog move (admin)
+	// (PUT /catalog/moves/{name})
+	UpdateCatalogMove(c *gin.Context, name string, params UpdateCatalogMoveParams)
+	// List the results of an athlete
+	// (GET /results)
+	ListResults(c *gin.Context, params ListResultsParams)
+	// Export logged results as JSON lines or CSV
+	// (GET /results/export)
+	ExportResults(c *gin.Context, params ExportResultsParams)
+	// Aggregate stored WODs and results
+	// (GET /stats)
+	GetStats(c *gin.Context, params GetStatsParams)
+	// Export stored WODs as JSON lines or CSV
+	// (GET /wod/export)
+	ExportWods(c *gin.Context, params ExportWodsParams)
+
+	// (POST /wod/generate)
+	GenerateWod(c *gin.Context)
+	// Generate several WODs at once
+	// (POST /wod/generate:batch)
+	GenerateWodBatch(c *gin.Context)
+	// Import WODs exported as JSON lines or CSV
+	// (POST /wod/import)
+	ImportWods(c *gin.Context)
+	// List stored WODs
+	// (GET /wod/list)
+	ListWods(c *gin.Context, params ListWodsParams)
+	// Search stored WODs by their moves
+	// (POST /wod/search)
+	SearchWods(c *gin.Context, params SearchWodsParams)
+	// Get a stored WOD
+	// (GET /wod/{id})
+	GetWod(c *gin.Context, id openapi_types.UUID, params GetWodParams)
+	// Annotate one of the caller's WODs
+	// (PATCH /wod/{id}/annotations)
+	AnnotateWod(c *gin.Context, id openapi_types.UUID)
+	// Rank the results logged on a WOD
+	// (GET /wod/{id}/leaderboard)
+	GetLeaderboard(c *gin.Context, id openapi_types.UUID, params GetLeaderboardParams)
+	// List the results logged on a WOD
+	// (GET /wod/{id}/results)
+	ListWodResults(c *gin.Context, id openapi_types.UUID, params ListWodResultsParams)
+	// Log a result on a WOD
+	// (POST /wod/{id}/results)
+	LogResult(c *gin.Context, id openapi_types.UUID)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// ListCalendar operation middleware
+func (siw *ServerInterfaceWrapper) ListCalendar(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCalendarParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "program" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "program", c.Request.URL.Query(), &params.Program)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter program: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCalendar(c, params)
+}
+
+// ScheduleWod operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleWod(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ScheduleWod(c)
+}
+
+// RevokeCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) RevokeCalendarFeed(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RevokeCalendarFeed(c)
+}
+
+// RotateCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) RotateCalendarFeed(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RotateCalendarFeed(c)
+}
+
+// GetCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarFeed(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCalendarFeedParams
+
+	// ------------- Required query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "token", c.Request.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCalendarFeed(c, params)
+}
+
+// ScheduleProgram operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleProgram(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ScheduleProgram(c)
+}
+
+// UnscheduleProgram operation middleware
+func (siw *ServerInterfaceWrapper) UnscheduleProgram(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "program" -------------
+	var program string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "program", c.Param("program"), &program, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter program: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UnscheduleProgram(c, program)
+}
+
+// DeleteCalendarEntry operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCalendarEntry(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCalendarEntry(c, id)
+}
+
+// GetCalendarEntry operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarEntry(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCalendarEntry(c, id)
+}
+
+// UpdateCalendarEntry operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCalendarEntry(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateCalendarEntry(c, id)
+}
+
+// GetCatalog operation middleware
+func (siw *ServerInterfaceWrapper) GetCatalog(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCatalogParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "equipment" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "equipment", c.Request.URL.Query(), &params.Equipment)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter equipment: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "level" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "level", c.Request.URL.Query(), &params.Level)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter level: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCatalog(c, params)
+}
+
+// ImportCatalog operation middleware
+func (siw *ServerInterfaceWrapper) ImportCatalog(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportCatalogParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportCatalog(c, params)
+}
+
+// ListCatalogMoves operation middleware
+func (siw *ServerInterfaceWrapper) ListCatalogMoves(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCatalogMovesParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCatalogMoves(c, params)
+}
+
+// CreateCatalogMove operation middleware
+func (siw *ServerInterfaceWrapper) CreateCatalogMove(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateCatalogMoveParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateCatalogMove(c, params)
+}
+
+// DeleteCatalogMove operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCatalogMove(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteCatalogMoveParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCatalogMove(c, name, params)
+}
+
+// GetCatalogMove operation middleware
+func (siw *ServerInterfaceWrapper) GetCatalogMove(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCatalogMoveParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCatalogMove(c, name, params)
+}
+
+// UpdateCatalogMove operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCatalogMove(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Param("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateCatalogMoveParams
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateCatalogMove(c, name, params)
+}
+
+// ListResults operation middleware
+func (siw *ServerInterfaceWrapper) ListResults(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListResultsParams
+
+	// ------------- Optional query parameter "athlete" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "athlete", c.Request.URL.Query(), &params.Athlete)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter athlete: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListResults(c, params)
+}
+
+// ExportResults operation middleware
+func (siw *ServerInterfaceWrapper) ExportResults(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportResultsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", c.Request.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter owner: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportResults(c, params)
+}
+
+// GetStats operation middleware
+func (siw *ServerInterfaceWrapper) GetStats(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsParams
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", c.Request.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter owner: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", c.Request.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetStats(c, params)
+}
+
+// ExportWods operation middleware
+func (siw *ServerInterfaceWrapper) ExportWods(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportWodsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", c.Request.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter owner: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportWods(c, params)
+}
+
+// GenerateWod operation middleware
+func (siw *ServerInterfaceWrapper) GenerateWod(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GenerateWod(c)
+}
+
+// GenerateWodBatch operation middleware
+func (siw *ServerInterfaceWrapper) GenerateWodBatch(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GenerateWodBatch(c)
+}
+
+// ImportWods operation middleware
+func (siw *ServerInterfaceWrapper) ImportWods(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportWods(c)
+}
+
+// ListWods operation middleware
+func (siw *ServerInterfaceWrapper) ListWods(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWodsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_total", c.Request.URL.Query(), &params.IncludeTotal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_total: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "catalog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog", c.Request.URL.Query(), &params.Catalog)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "catalog_version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog_version", c.Request.URL.Query(), &params.CatalogVersion)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog_version: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "catalog_hash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "catalog_hash", c.Request.URL.Query(), &params.CatalogHash)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter catalog_hash: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "level" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "level", c.Request.URL.Query(), &params.Level)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter level: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "min_duration" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_duration", c.Request.URL.Query(), &params.MinDuration)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_duration: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "max_duration" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_duration", c.Request.URL.Query(), &params.MaxDuration)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_duration: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "equipment" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "equipment", c.Request.URL.Query(), &params.Equipment)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter equipment: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "equipment_match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "equipment_match", c.Request.URL.Query(), &params.EquipmentMatch)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter equipment_match: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", c.Request.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter created_after: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", c.Request.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter created_before: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "seed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "seed", c.Request.URL.Query(), &params.Seed)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter seed: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "move" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "move", c.Request.URL.Query(), &params.Move)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter move: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "favorite" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "favorite", c.Request.URL.Query(), &params.Favorite)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter favorite: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", c.Request.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter label: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "note" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "note", c.Request.URL.Query(), &params.Note)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", c.Request.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter owner: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expand", c.Request.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expand: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWods(c, params)
+}
+
+// SearchWods operation middleware
+func (siw *ServerInterfaceWrapper) SearchWods(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchWodsParams
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expand", c.Request.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expand: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SearchWods(c, params)
+}
+
+// GetWod operation middleware
+func (siw *ServerInterfaceWrapper) GetWod(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWodParams
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expand", c.Request.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expand: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWod(c, id, params)
+}
+
+// AnnotateWod operation middleware
+func (siw *ServerInterfaceWrapper) AnnotateWod(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AnnotateWod(c, id)
+}
+
+// GetLeaderboard operation middleware
+func (siw *ServerInterfaceWrapper) GetLeaderboard(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLeaderboardParams
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", c.Request.URL.Query(), &params.Scope)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scope: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "scoring" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scoring", c.Request.URL.Query(), &params.Scoring)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scoring: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "level" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "level", c.Request.URL.Query(), &params.Level)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter level: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "division" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "division", c.Request.URL.Query(), &params.Division)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter division: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "completed_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "completed_after", c.Request.URL.Query(), &params.CompletedAfter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter completed_after: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "completed_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "completed_before", c.Request.URL.Query(), &params.CompletedBefore)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter completed_before: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLeaderboard(c, id, params)
+}
+
+// ListWodResults operation middleware
+func (siw *ServerInterfaceWrapper) ListWodResults(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWodResultsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWodResults(c, id, params)
+}
+
+// LogResult operation middleware
+func (siw *ServerInterfaceWrapper) LogResult(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LogResult(c, id)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/calendar", wrapper.ListCalendar)
+	router.POST(options.BaseURL+"/calendar", wrapper.ScheduleWod)
+	router.DELETE(options.BaseURL+"/calendar/feed", wrapper.RevokeCalendarFeed)
+	router.POST(options.BaseURL+"/calendar/feed", wrapper.RotateCalendarFeed)
+	router.GET(options.BaseURL+"/calendar/feed.ics", wrapper.GetCalendarFeed)
+	router.POST(options.BaseURL+"/calendar/programs", wrapper.ScheduleProgram)
+	router.DELETE(options.BaseURL+"/calendar/programs/:program", wrapper.UnscheduleProgram)
+	router.DELETE(options.BaseURL+"/calendar/:id", wrapper.DeleteCalendarEntry)
+	router.GET(options.BaseURL+"/calendar/:id", wrapper.GetCalendarEntry)
+	router.PATCH(options.BaseURL+"/calendar/:id", wrapper.UpdateCalendarEntry)
+	router.GET(options.BaseURL+"/catalog", wrapper.GetCatalog)
+	router.POST(options.BaseURL+"/catalog/import", wrapper.ImportCatalog)
+	router.GET(options.BaseURL+"/catalog/moves", wrapper.ListCatalogMoves)
+	router.POST(options.BaseURL+"/catalog/moves", wrapper.CreateCatalogMove)
+	router.DELETE(options.BaseURL+"/catalog/moves/:name", wrapper.DeleteCatalogMove)
+	router.GET(options.BaseURL+"/catalog/moves/:name", wrapper.GetCatalogMove)
+	router.PUT(options.BaseURL+"/catalog/moves/:name", wrapper.UpdateCatalogMove)
+	router.GET(options.BaseURL+"/results", wrapper.ListResults)
+	router.GET(options.BaseURL+"/results/export", wrapper.ExportResults)
+	router.GET(options.BaseURL+"/stats", wrapper.GetStats)
+	router.GET(options.BaseURL+"/wod/export", wrapper.ExportWods)
+	router.POST(options.BaseURL+"/wod/generate", wrapper.GenerateWod)
+	router.POST(options.BaseURL+"/wod/generate:batch", wrapper.GenerateWodBatch)
+	router.POST(options.BaseURL+"/wod/import", wrapper.ImportWods)
+	router.GET(options.BaseURL+"/wod/list", wrapper.ListWods)
+	router.POST(options.BaseURL+"/wod/search", wrapper.SearchWods)
+	router.GET(options.BaseURL+"/wod/:id", wrapper.GetWod)
+	router.PATCH(options.BaseURL+"/wod/:id/annotations", wrapper.AnnotateWod)
+	router.GET(options.BaseURL+"/wod/:id/leaderboard", wrapper.GetLeaderboard)
+	router.GET(options.BaseURL+"/wod/:id/results", wrapper.ListWodResults)
+	router.POST(options.BaseURL+"/wod/:id/results", wrapper.LogResult)
+}
+
+type ListCalendarRequestObject struct {
+	Params ListCalendarParams
+}
+
+type ListCalendarResponseObject interface {
+	VisitListCalendarResponse(w http.ResponseWriter) error
+}
+
+type ListCalendar200JSONResponse CalendarPage
+
+func (response ListCalendar200JSONResponse) VisitListCalendarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCalendar400JSONResponse ErrorResponse
+
+func (response ListCalendar400JSONResponse) VisitListCalendarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCalendar500JSONResponse ErrorResponse
+
+func (response ListCalendar500JSONResponse) VisitListCalendarResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleWodRequestObject struct {
+	Body *ScheduleWodJSONRequestBody
+}
+
+type ScheduleWodResponseObject interface {
+	VisitScheduleWodResponse(w http.ResponseWriter) error
+}
+
+type ScheduleWod201JSONResponse CalendarEntry
+
+func (response ScheduleWod201JSONResponse) VisitScheduleWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleWod400JSONResponse ErrorResponse
+
+func (response ScheduleWod400JSONResponse) VisitScheduleWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleWod404JSONResponse ErrorResponse
+
+func (response ScheduleWod404JSONResponse) VisitScheduleWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleWod500JSONResponse ErrorResponse
+
+func (response ScheduleWod500JSONResponse) VisitScheduleWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeCalendarFeedRequestObject struct {
+}
+
+type RevokeCalendarFeedResponseObject interface {
+	VisitRevokeCalendarFeedResponse(w http.ResponseWriter) error
+}
+
+type RevokeCalendarFeed204Response struct {
+}
+
+func (response RevokeCalendarFeed204Response) VisitRevokeCalendarFeedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeCalendarFeed500JSONResponse ErrorResponse
+
+func (response RevokeCalendarFeed500JSONResponse) VisitRevokeCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateCalendarFeedRequestObject struct {
+}
+
+type RotateCalendarFeedResponseObject interface {
+	VisitRotateCalendarFeedResponse(w http.ResponseWriter) error
+}
+
+type RotateCalendarFeed201JSONResponse CalendarFeed
+
+func (response RotateCalendarFeed201JSONResponse) VisitRotateCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateCalendarFeed500JSONResponse ErrorResponse
+
+func (response RotateCalendarFeed500JSONResponse) VisitRotateCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalendarFeedRequestObject struct {
+	Params GetCalendarFeedParams
+}
+
+type GetCalendarFeedResponseObject interface {
+	VisitGetCalendarFeedResponse(w http.ResponseWriter) error
+}
+
+type GetCalendarFeed200TextcalendarResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetCalendarFeed200TextcalendarResponse) VisitGetCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/calendar")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCalendarFeed404JSONResponse ErrorResponse
+
+func (response GetCalendarFeed404JSONResponse) VisitGetCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalendarFeed500JSONResponse ErrorResponse
+
+func (response GetCalendarFeed500JSONResponse) VisitGetCalendarFeedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleProgramRequestObject struct {
+	Body *ScheduleProgramJSONRequestBody
+}
+
+type ScheduleProgramResponseObject interface {
+	VisitScheduleProgramResponse(w http.ResponseWriter) error
+}
+
+type ScheduleProgram201JSONResponse CalendarPage
+
+func (response ScheduleProgram201JSONResponse) VisitScheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleProgram400JSONResponse ErrorResponse
+
+func (response ScheduleProgram400JSONResponse) VisitScheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleProgram404JSONResponse ErrorResponse
+
+func (response ScheduleProgram404JSONResponse) VisitScheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleProgram500JSONResponse ErrorResponse
+
+func (response ScheduleProgram500JSONResponse) VisitScheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnscheduleProgramRequestObject struct {
+	Program string `json:"program"`
+}
+
+type UnscheduleProgramResponseObject interface {
+	VisitUnscheduleProgramResponse(w http.ResponseWriter) error
+}
+
+type UnscheduleProgram204Response struct {
+}
+
+func (response UnscheduleProgram204Response) VisitUnscheduleProgramResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnscheduleProgram404JSONResponse ErrorResponse
+
+func (response UnscheduleProgram404JSONResponse) VisitUnscheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnscheduleProgram500JSONResponse ErrorResponse
+
+func (response UnscheduleProgram500JSONResponse) VisitUnscheduleProgramResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCalendarEntryRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteCalendarEntryResponseObject interface {
+	VisitDeleteCalendarEntryResponse(w http.ResponseWriter) error
+}
+
+type DeleteCalendarEntry204Response struct {
+}
+
+func (response DeleteCalendarEntry204Response) VisitDeleteCalendarEntryResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCalendarEntry404JSONResponse ErrorResponse
+
+func (response DeleteCalendarEntry404JSONResponse) VisitDeleteCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCalendarEntry500JSONResponse ErrorResponse
+
+func (response DeleteCalendarEntry500JSONResponse) VisitDeleteCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalendarEntryRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetCalendarEntryResponseObject interface {
+	VisitGetCalendarEntryResponse(w http.ResponseWriter) error
+}
+
+type GetCalendarEntry200JSONResponse CalendarEntry
+
+func (response GetCalendarEntry200JSONResponse) VisitGetCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalendarEntry404JSONResponse ErrorResponse
+
+func (response GetCalendarEntry404JSONResponse) VisitGetCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalendarEntry500JSONResponse ErrorResponse
+
+func (response GetCalendarEntry500JSONResponse) VisitGetCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCalendarEntryRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *UpdateCalendarEntryJSONRequestBody
+}
+
+type UpdateCalendarEntryResponseObject interface {
+	VisitUpdateCalendarEntryResponse(w http.ResponseWriter) error
+}
+
+type UpdateCalendarEntry200JSONResponse CalendarEntry
+
+func (response UpdateCalendarEntry200JSONResponse) VisitUpdateCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCalendarEntry400JSONResponse ErrorResponse
+
+func (response UpdateCalendarEntry400JSONResponse) VisitUpdateCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCalendarEntry404JSONResponse ErrorResponse
+
+func (response UpdateCalendarEntry404JSONResponse) VisitUpdateCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCalendarEntry500JSONResponse ErrorResponse
+
+func (response UpdateCalendarEntry500JSONResponse) VisitUpdateCalendarEntryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCatalogRequestObject struct {
+	Params GetCatalogParams
+}
+
+type GetCatalogResponseObject interface {
+	VisitGetCatalogResponse(w http.ResponseWriter) error
+}
+
+type GetCatalog200ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetCatalog200JSONResponse struct {
+	Body    Catalog
+	Headers GetCatalog200ResponseHeaders
+}
+
+func (response GetCatalog200JSONResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCatalog304ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetCatalog304Response struct {
+	Headers GetCatalog304ResponseHeaders
+}
+
+func (response GetCatalog304Response) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetCatalog400JSONResponse ErrorResponse
+
+func (response GetCatalog400JSONResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCatalog404JSONResponse ErrorResponse
+
+func (response GetCatalog404JSONResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCatalog500JSONResponse ErrorResponse
+
+func (response GetCatalog500JSONResponse) VisitGetCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalogRequestObject struct {
+	Params ImportCatalogParams
+	Body   *ImportCatalogJSONRequestBody
+}
+
+type ImportCatalogResponseObject interface {
+	VisitImportCatalogResponse(w http.ResponseWriter) error
+}
+
+type ImportCatalog200JSONResponse CatalogImportResult
+
+func (response ImportCatalog200JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalog400JSONResponse ErrorResponse
+
+func (response ImportCatalog400JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalog403JSONResponse ErrorResponse
+
+func (response ImportCatalog403JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalog404JSONResponse ErrorResponse
+
+func (response ImportCatalog404JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalog409JSONResponse ErrorResponse
+
+func (response ImportCatalog409JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCatalog500JSONResponse ErrorResponse
+
+func (response ImportCatalog500JSONResponse) VisitImportCatalogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCatalogMovesRequestObject struct {
+	Params ListCatalogMovesParams
+}
+
+type ListCatalogMovesResponseObject interface {
+	VisitListCatalogMovesResponse(w http.ResponseWriter) error
+}
+
+type ListCatalogMoves200JSONResponse CatalogMoveList
+
+func (response ListCatalogMoves200JSONResponse) VisitListCatalogMovesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCatalogMoves403JSONResponse ErrorResponse
+
+func (response ListCatalogMoves403JSONResponse) VisitListCatalogMovesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCatalogMoves404JSONResponse ErrorResponse
+
+func (response ListCatalogMoves404JSONResponse) VisitListCatalogMovesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMoveRequestObject struct {
+	Params CreateCatalogMoveParams
+	Body   *CreateCatalogMoveJSONRequestBody
+}
+
+type CreateCatalogMoveResponseObject interface {
+	VisitCreateCatalogMoveResponse(w http.ResponseWriter) error
+}
+
+type CreateCatalogMove201JSONResponse CatalogMove
+
+func (response CreateCatalogMove201JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMove400JSONResponse ErrorResponse
+
+func (response CreateCatalogMove400JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMove403JSONResponse ErrorResponse
+
+func (response CreateCatalogMove403JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMove404JSONResponse ErrorResponse
+
+func (response CreateCatalogMove404JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMove409JSONResponse ErrorResponse
+
+func (response CreateCatalogMove409JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCatalogMove500JSONResponse ErrorResponse
+
+func (response CreateCatalogMove500JSONResponse) VisitCreateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCatalogMoveRequestObject struct {
+	Name   string `json:"name"`
+	Params DeleteCatalogMoveParams
+}
+
+type DeleteCatalogMoveResponseObject interface {
+	VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error
+}
+
+type DeleteCatalogMove204Response struct {
+}
+
+func (response DeleteCatalogMove204Response) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCatalogMove400JSONResponse ErrorResponse
+
+func (response DeleteCatalogMove400JSONResponse) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCatalogMove403JSONResponse ErrorResponse
+
+func (response DeleteCatalogMove403JSONResponse) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCatalogMove404JSONResponse ErrorResponse
+
+func (response DeleteCatalogMove404JSONResponse) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCatalogMove409JSONResponse ErrorResponse
+
+func (response DeleteCatalogMove409JSONResponse) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCatalogMove500JSONResponse ErrorResponse
+
+func (response DeleteCatalogMove500JSONResponse) VisitDeleteCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCatalogMoveRequestObject struct {
+	Name   string `json:"name"`
+	Params GetCatalogMoveParams
+}
+
+type GetCatalogMoveResponseObject interface {
+	VisitGetCatalogMoveResponse(w http.ResponseWriter) error
+}
+
+type GetCatalogMove200ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetCatalogMove200JSONResponse struct {
+	Body    CatalogMove
+	Headers GetCatalogMove200ResponseHeaders
+}
+
+func (response GetCatalogMove200JSONResponse) VisitGetCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCatalogMove304ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetCatalogMove304Response struct {
+	Headers GetCatalogMove304ResponseHeaders
+}
+
+func (response GetCatalogMove304Response) VisitGetCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetCatalogMove404JSONResponse ErrorResponse
+
+func (response GetCatalogMove404JSONResponse) VisitGetCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCatalogMove500JSONResponse ErrorResponse
+
+func (response GetCatalogMove500JSONResponse) VisitGetCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMoveRequestObject struct {
+	Name   string `json:"name"`
+	Params UpdateCatalogMoveParams
+	Body   *UpdateCatalogMoveJSONRequestBody
+}
+
+type UpdateCatalogMoveResponseObject interface {
+	VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error
+}
+
+type UpdateCatalogMove200JSONResponse CatalogMove
+
+func (response UpdateCatalogMove200JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMove400JSONResponse ErrorResponse
+
+func (response UpdateCatalogMove400JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMove403JSONResponse ErrorResponse
+
+func (response UpdateCatalogMove403JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMove404JSONResponse ErrorResponse
+
+func (response UpdateCatalogMove404JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMove409JSONResponse ErrorResponse
+
+func (response UpdateCatalogMove409JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCatalogMove500JSONResponse ErrorResponse
+
+func (response UpdateCatalogMove500JSONResponse) VisitUpdateCatalogMoveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResultsRequestObject struct {
+	Params ListResultsParams
+}
+
+type ListResultsResponseObject interface {
+	VisitListResultsResponse(w http.ResponseWriter) error
+}
+
+type ListResults200JSONResponse ResultPage
+
+func (response ListResults200JSONResponse) VisitListResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResults400JSONResponse ErrorResponse
+
+func (response ListResults400JSONResponse) VisitListResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResults403JSONResponse ErrorResponse
+
+func (response ListResults403JSONResponse) VisitListResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResults500JSONResponse ErrorResponse
+
+func (response ListResults500JSONResponse) VisitListResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportResultsRequestObject struct {
+	Params ExportResultsParams
+}
+
+type ExportResultsResponseObject interface {
+	VisitExportResultsResponse(w http.ResponseWriter) error
+}
+
+type ExportResults200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportResults200ApplicationxNdjsonResponse) VisitExportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportResults200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportResults200TextcsvResponse) VisitExportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportResults400JSONResponse ErrorResponse
+
+func (response ExportResults400JSONResponse) VisitExportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportResults403JSONResponse ErrorResponse
+
+func (response ExportResults403JSONResponse) VisitExportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportResults500JSONResponse ErrorResponse
+
+func (response ExportResults500JSONResponse) VisitExportResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStatsRequestObject struct {
+	Params GetStatsParams
+}
+
+type GetStatsResponseObject interface {
+	VisitGetStatsResponse(w http.ResponseWriter) error
+}
+
+type GetStats200JSONResponse Stats
+
+func (response GetStats200JSONResponse) VisitGetStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStats400JSONResponse ErrorResponse
+
+func (response GetStats400JSONResponse) VisitGetStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStats403JSONResponse ErrorResponse
+
+func (response GetStats403JSONResponse) VisitGetStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetStats500JSONResponse ErrorResponse
+
+func (response GetStats500JSONResponse) VisitGetStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportWodsRequestObject struct {
+	Params ExportWodsParams
+}
+
+type ExportWodsResponseObject interface {
+	VisitExportWodsResponse(w http.ResponseWriter) error
+}
+
+type ExportWods200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportWods200ApplicationxNdjsonResponse) VisitExportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportWods200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportWods200TextcsvResponse) VisitExportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportWods400JSONResponse ErrorResponse
+
+func (response ExportWods400JSONResponse) VisitExportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportWods403JSONResponse ErrorResponse
+
+func (response ExportWods403JSONResponse) VisitExportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportWods500JSONResponse ErrorResponse
+
+func (response ExportWods500JSONResponse) VisitExportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWodRequestObject struct {
+	Body *GenerateWodJSONRequestBody
+}
+
+type GenerateWodResponseObject interface {
+	VisitGenerateWodResponse(w http.ResponseWriter) error
+}
+
+type GenerateWod200ResponseHeaders struct {
+	IdempotentReplayed bool
+}
+
+type GenerateWod200JSONResponse struct {
+	Body    Wod
+	Headers GenerateWod200ResponseHeaders
+}
+
+func (response GenerateWod200JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Idempotent-Replayed", fmt.Sprint(response.Headers.IdempotentReplayed))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GenerateWod400JSONResponse ErrorResponse
+
+func (response GenerateWod400JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWod401JSONResponse ErrorResponse
+
+func (response GenerateWod401JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWod403JSONResponse ErrorResponse
+
+func (response GenerateWod403JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWod409JSONResponse ErrorResponse
+
+func (response GenerateWod409JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWod429JSONResponse ErrorResponse
+
+func (response GenerateWod429JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWod500JSONResponse ErrorResponse
+
+func (response GenerateWod500JSONResponse) VisitGenerateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWodBatchRequestObject struct {
+	Body *GenerateWodBatchJSONRequestBody
+}
+
+type GenerateWodBatchResponseObject interface {
+	VisitGenerateWodBatchResponse(w http.ResponseWriter) error
+}
+
+type GenerateWodBatch200JSONResponse WodBatch
+
+func (response GenerateWodBatch200JSONResponse) VisitGenerateWodBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWodBatch400JSONResponse ErrorResponse
+
+func (response GenerateWodBatch400JSONResponse) VisitGenerateWodBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWodBatch404JSONResponse ErrorResponse
+
+func (response GenerateWodBatch404JSONResponse) VisitGenerateWodBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateWodBatch500JSONResponse ErrorResponse
+
+func (response GenerateWodBatch500JSONResponse) VisitGenerateWodBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportWodsRequestObject struct {
+	Body *ImportWodsJSONRequestBody
+}
+
+type ImportWodsResponseObject interface {
+	VisitImportWodsResponse(w http.ResponseWriter) error
+}
+
+type ImportWods200JSONResponse WodImportResult
+
+func (response ImportWods200JSONResponse) VisitImportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportWods400JSONResponse ErrorResponse
+
+func (response ImportWods400JSONResponse) VisitImportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportWods500JSONResponse ErrorResponse
+
+func (response ImportWods500JSONResponse) VisitImportWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWodsRequestObject struct {
+	Params ListWodsParams
+}
+
+type ListWodsResponseObject interface {
+	VisitListWodsResponse(w http.ResponseWriter) error
+}
+
+type ListWods200JSONResponse WodPage
+
+func (response ListWods200JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWods400JSONResponse ErrorResponse
+
+func (response ListWods400JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWods403JSONResponse ErrorResponse
+
+func (response ListWods403JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWods500JSONResponse ErrorResponse
+
+func (response ListWods500JSONResponse) VisitListWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchWodsRequestObject struct {
+	Params SearchWodsParams
+	Body   *SearchWodsJSONRequestBody
+}
+
+type SearchWodsResponseObject interface {
+	VisitSearchWodsResponse(w http.ResponseWriter) error
+}
+
+type SearchWods200JSONResponse WodPage
+
+func (response SearchWods200JSONResponse) VisitSearchWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchWods400JSONResponse ErrorResponse
+
+func (response SearchWods400JSONResponse) VisitSearchWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchWods403JSONResponse ErrorResponse
+
+func (response SearchWods403JSONResponse) VisitSearchWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchWods500JSONResponse ErrorResponse
+
+func (response SearchWods500JSONResponse) VisitSearchWodsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWodRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetWodParams
+}
+
+type GetWodResponseObject interface {
+	VisitGetWodResponse(w http.ResponseWriter) error
+}
+
+type GetWod200JSONResponse Wod
+
+func (response GetWod200JSONResponse) VisitGetWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWod404JSONResponse ErrorResponse
+
+func (response GetWod404JSONResponse) VisitGetWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWod500JSONResponse ErrorResponse
+
+func (response GetWod500JSONResponse) VisitGetWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateWodRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *AnnotateWodJSONRequestBody
+}
+
+type AnnotateWodResponseObject interface {
+	VisitAnnotateWodResponse(w http.ResponseWriter) error
+}
+
+type AnnotateWod200JSONResponse Annotations
+
+func (response AnnotateWod200JSONResponse) VisitAnnotateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateWod400JSONResponse ErrorResponse
+
+func (response AnnotateWod400JSONResponse) VisitAnnotateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateWod404JSONResponse ErrorResponse
+
+func (response AnnotateWod404JSONResponse) VisitAnnotateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateWod500JSONResponse ErrorResponse
+
+func (response AnnotateWod500JSONResponse) VisitAnnotateWodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLeaderboardRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetLeaderboardParams
+}
+
+type GetLeaderboardResponseObject interface {
+	VisitGetLeaderboardResponse(w http.ResponseWriter) error
+}
+
+type GetLeaderboard200JSONResponse Leaderboard
+
+func (response GetLeaderboard200JSONResponse) VisitGetLeaderboardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLeaderboard400JSONResponse ErrorResponse
+
+func (response GetLeaderboard400JSONResponse) VisitGetLeaderboardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLeaderboard404JSONResponse ErrorResponse
+
+func (response GetLeaderboard404JSONResponse) VisitGetLeaderboardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLeaderboard500JSONResponse ErrorResponse
+
+func (response GetLeaderboard500JSONResponse) VisitGetLeaderboardResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWodResultsRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params ListWodResultsParams
+}
+
+type ListWodResultsResponseObject interface {
+	VisitListWodResultsResponse(w http.ResponseWriter) error
+}
+
+type ListWodResults200JSONResponse ResultPage
+
+func (response ListWodResults200JSONResponse) VisitListWodResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWodResults400JSONResponse ErrorResponse
+
+func (response ListWodResults400JSONResponse) VisitListWodResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWodResults404JSONResponse ErrorResponse
+
+func (response ListWodResults404JSONResponse) VisitListWodResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWodResults500JSONResponse ErrorResponse
+
+func (response ListWodResults500JSONResponse) VisitListWodResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogResultRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *LogResultJSONRequestBody
+}
+
+type LogResultResponseObject interface {
+	VisitLogResultResponse(w http.ResponseWriter) error
+}
+
+type LogResult201JSONResponse Result
+
+func (response LogResult201JSONResponse) VisitLogResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogResult400JSONResponse ErrorResponse
+
+func (response LogResult400JSONResponse) VisitLogResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogResult404JSONResponse ErrorResponse
+
+func (response LogResult404JSONResponse) VisitLogResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogResult500JSONResponse ErrorResponse
+
+func (response LogResult500JSONResponse) VisitLogResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List the caller's calendar
+	// (GET /calendar)
+	ListCalendar(ctx context.Context, request ListCalendarRequestObject) (ListCalendarResponseObject, error)
+	// Schedule a WOD on a day
+	// (POST /calendar)
+	ScheduleWod(ctx context.Context, request ScheduleWodRequestObject) (ScheduleWodResponseObject, error)
+	// Revoke the caller's calendar feed token
+	// (DELETE /calendar/feed)
+	RevokeCalendarFeed(ctx context.Context, request RevokeCalendarFeedRequestObject) (RevokeCalendarFeedResponseObject, error)
+	// Create the caller's calendar feed token
+	// (POST /calendar/feed)
+	RotateCalendarFeed(ctx context.Context, request RotateCalendarFeedRequestObject) (RotateCalendarFeedResponseObject, error)
+	// iCalendar feed of a calendar (token protected)
+	// (GET /calendar/feed.ics)
+	GetCalendarFeed(ctx context.Context, request GetCalendarFeedRequestObject) (GetCalendarFeedResponseObject, error)
+	// Schedule a program
+	// (POST /calendar/programs)
+	ScheduleProgram(ctx context.Context, request ScheduleProgramRequestObject) (ScheduleProgramResponseObject, error)
+	// Unschedule a program
+	// (DELETE /calendar/programs/{program})
+	UnscheduleProgram(ctx context.Context, request UnscheduleProgramRequestObject) (UnscheduleProgramResponseObject, error)
+	// Delete an entry of the caller's calendar
+	// (DELETE /calendar/{id})
+	DeleteCalendarEntry(ctx context.Context, request DeleteCalendarEntryRequestObject) (DeleteCalendarEntryResponseObject, error)
+	// Get an entry of the caller's calendar
+	// (GET /calendar/{id})
+	GetCalendarEntry(ctx context.Context, request GetCalendarEntryRequestObject) (GetCalendarEntryResponseObject, error)
+	// Change an entry of the caller's calendar
+	// (PATCH /calendar/{id})
+	UpdateCalendarEntry(ctx context.Context, request UpdateCalendarEntryRequestObject) (UpdateCalendarEntryResponseObject, error)
+	// Browse the movement catalog (public)
+	// (GET /catalog)
+	GetCatalog(ctx context.Context, request GetCatalogRequestObject) (GetCatalogResponseObject, error)
+	// Import a catalog from a spreadsheet export (admin)
+	// (POST /catalog/import)
+	ImportCatalog(ctx context.Context, request ImportCatalogRequestObject) (ImportCatalogResponseObject, error)
+	// List catalog moves (admin)
+	// (GET /catalog/moves)
+	ListCatalogMoves(ctx context.Context, request ListCatalogMovesRequestObject) (ListCatalogMovesResponseObject, error)
+	// Add a move to the catalog (admin)
+	// (POST /catalog/moves)
+	CreateCatalogMove(ctx context.Context, request CreateCatalogMoveRequestObject) (CreateCatalogMoveResponseObject, error)
+	// Remove a catalog move (admin)
+	// (DELETE /catalog/moves/{name})
+	DeleteCatalogMove(ctx context.Context, request DeleteCatalogMoveRequestObject) (DeleteCatalogMoveResponseObject, error)
+	// Get a catalog move (public)
+	// (GET /catalog/moves/{name})
+	GetCatalogMove(ctx context.Context, request GetCatalogMoveRequestObject) (GetCatalogMoveResponseObject, error)
+	// Replace a catalog move (admin)
+	// (PUT /catalog/moves/{name})
+	UpdateCatalogMove(ctx context.Context, request UpdateCatalogMoveRequestObject) (UpdateCatalogMoveResponseObject, error)
+	// List the results of an athlete
+	// (GET /results)
+	ListResults(ctx context.Context, request ListResultsRequestObject) (ListResultsResponseObject, error)
+	// Export logged results as JSON lines or CSV
+	// (GET /results/export)
+	ExportResults(ctx context.Context, request ExportResultsRequestObject) (ExportResultsResponseObject, error)
+	// Aggregate stored WODs and results
+	// (GET /stats)
+	GetStats(ctx context.Context, request GetStatsRequestObject) (GetStatsResponseObject, error)
+	// Export stored WODs as JSON lines or CSV
+	// (GET /wod/export)
+	ExportWods(ctx context.Context, request ExportWodsRequestObject) (ExportWodsResponseObject, error)
+
+	// (POST /wod/generate)
+	GenerateWod(ctx context.Context, request GenerateWodRequestObject) (GenerateWodResponseObject, error)
+	// Generate several WODs at once
+	// (POST /wod/generate:batch)
+	GenerateWodBatch(ctx context.Context, request GenerateWodBatchRequestObject) (GenerateWodBatchResponseObject, error)
+	// Import WODs exported as JSON lines or CSV
+	// (POST /wod/import)
+	ImportWods(ctx context.Context, request ImportWodsRequestObject) (ImportWodsResponseObject, error)
+	// List stored WODs
+	// (GET /wod/list)
 	ListWods(ctx context.Context, request ListWodsRequestObject) (ListWodsResponseObject, error)
+	// Search stored WODs by their moves
+	// (POST /wod/search)
+	SearchWods(ctx context.Context, request SearchWodsRequestObject) (SearchWodsResponseObject, error)
+	// Get a stored WOD
+	// (GET /wod/{id})
+	GetWod(ctx context.Context, request GetWodRequestObject) (GetWodResponseObject, error)
+	// Annotate one of the caller's WODs
+	// (PATCH /wod/{id}/annotations)
+	AnnotateWod(ctx context.Context, request AnnotateWodRequestObject) (AnnotateWodResponseObject, error)
+	// Rank the results logged on a WOD
+	// (GET /wod/{id}/leaderboard)
+	GetLeaderboard(ctx context.Context, request GetLeaderboardRequestObject) (GetLeaderboardResponseObject, error)
+	// List the results logged on a WOD
+	// (GET /wod/{id}/results)
+	ListWodResults(ctx context.Context, request ListWodResultsRequestObject) (ListWodResultsResponseObject, error)
+	// Log a result on a WOD
+	// (POST /wod/{id}/results)
+	LogResult(ctx context.Context, request LogResultRequestObject) (LogResultResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// ListCalendar operation middleware
+func (sh *strictHandler) ListCalendar(ctx *gin.Context, params ListCalendarParams) {
+	var request ListCalendarRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCalendar(ctx, request.(ListCalendarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCalendar")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListCalendarResponseObject); ok {
+		if err := validResponse.VisitListCalendarResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ScheduleWod operation middleware
+func (sh *strictHandler) ScheduleWod(ctx *gin.Context) {
+	var request ScheduleWodRequestObject
+
+	var body ScheduleWodJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ScheduleWod(ctx, request.(ScheduleWodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ScheduleWod")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ScheduleWodResponseObject); ok {
+		if err := validResponse.VisitScheduleWodResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeCalendarFeed operation middleware
+func (sh *strictHandler) RevokeCalendarFeed(ctx *gin.Context) {
+	var request RevokeCalendarFeedRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeCalendarFeed(ctx, request.(RevokeCalendarFeedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeCalendarFeed")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RevokeCalendarFeedResponseObject); ok {
+		if err := validResponse.VisitRevokeCalendarFeedResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateCalendarFeed operation middleware
+func (sh *strictHandler) RotateCalendarFeed(ctx *gin.Context) {
+	var request RotateCalendarFeedRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateCalendarFeed(ctx, request.(RotateCalendarFeedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateCalendarFeed")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RotateCalendarFeedResponseObject); ok {
+		if err := validResponse.VisitRotateCalendarFeedResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCalendarFeed operation middleware
+func (sh *strictHandler) GetCalendarFeed(ctx *gin.Context, params GetCalendarFeedParams) {
+	var request GetCalendarFeedRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCalendarFeed(ctx, request.(GetCalendarFeedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCalendarFeed")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCalendarFeedResponseObject); ok {
+		if err := validResponse.VisitGetCalendarFeedResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ScheduleProgram operation middleware
+func (sh *strictHandler) ScheduleProgram(ctx *gin.Context) {
+	var request ScheduleProgramRequestObject
+
+	var body ScheduleProgramJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ScheduleProgram(ctx, request.(ScheduleProgramRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ScheduleProgram")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ScheduleProgramResponseObject); ok {
+		if err := validResponse.VisitScheduleProgramResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnscheduleProgram operation middleware
+func (sh *strictHandler) UnscheduleProgram(ctx *gin.Context, program string) {
+	var request UnscheduleProgramRequestObject
+
+	request.Program = program
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnscheduleProgram(ctx, request.(UnscheduleProgramRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnscheduleProgram")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UnscheduleProgramResponseObject); ok {
+		if err := validResponse.VisitUnscheduleProgramResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCalendarEntry operation middleware
+func (sh *strictHandler) DeleteCalendarEntry(ctx *gin.Context, id openapi_types.UUID) {
+	var request DeleteCalendarEntryRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCalendarEntry(ctx, request.(DeleteCalendarEntryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCalendarEntry")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteCalendarEntryResponseObject); ok {
+		if err := validResponse.VisitDeleteCalendarEntryResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCalendarEntry operation middleware
+func (sh *strictHandler) GetCalendarEntry(ctx *gin.Context, id openapi_types.UUID) {
+	var request GetCalendarEntryRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCalendarEntry(ctx, request.(GetCalendarEntryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCalendarEntry")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCalendarEntryResponseObject); ok {
+		if err := validResponse.VisitGetCalendarEntryResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateCalendarEntry operation middleware
+func (sh *strictHandler) UpdateCalendarEntry(ctx *gin.Context, id openapi_types.UUID) {
+	var request UpdateCalendarEntryRequestObject
+
+	request.Id = id
+
+	var body UpdateCalendarEntryJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCalendarEntry(ctx, request.(UpdateCalendarEntryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCalendarEntry")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateCalendarEntryResponseObject); ok {
+		if err := validResponse.VisitUpdateCalendarEntryResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCatalog operation middleware
+func (sh *strictHandler) GetCatalog(ctx *gin.Context, params GetCatalogParams) {
+	var request GetCatalogRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCatalog(ctx, request.(GetCatalogRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCatalog")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCatalogResponseObject); ok {
+		if err := validResponse.VisitGetCatalogResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportCatalog operation middleware
+func (sh *strictHandler) ImportCatalog(ctx *gin.Context, params ImportCatalogParams) {
+	var request ImportCatalogRequestObject
+
+	request.Params = params
+
+	var body ImportCatalogJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportCatalog(ctx, request.(ImportCatalogRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportCatalog")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ImportCatalogResponseObject); ok {
+		if err := validResponse.VisitImportCatalogResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCatalogMoves operation middleware
+func (sh *strictHandler) ListCatalogMoves(ctx *gin.Context, params ListCatalogMovesParams) {
+	var request ListCatalogMovesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCatalogMoves(ctx, request.(ListCatalogMovesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCatalogMoves")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListCatalogMovesResponseObject); ok {
+		if err := validResponse.VisitListCatalogMovesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCatalogMove operation middleware
+func (sh *strictHandler) CreateCatalogMove(ctx *gin.Context, params CreateCatalogMoveParams) {
+	var request CreateCatalogMoveRequestObject
+
+	request.Params = params
+
+	var body CreateCatalogMoveJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCatalogMove(ctx, request.(CreateCatalogMoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCatalogMove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateCatalogMoveResponseObject); ok {
+		if err := validResponse.VisitCreateCatalogMoveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCatalogMove operation middleware
+func (sh *strictHandler) DeleteCatalogMove(ctx *gin.Context, name string, params DeleteCatalogMoveParams) {
+	var request DeleteCatalogMoveRequestObject
+
+	request.Name = name
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCatalogMove(ctx, request.(DeleteCatalogMoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCatalogMove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteCatalogMoveResponseObject); ok {
+		if err := validResponse.VisitDeleteCatalogMoveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCatalogMove operation middleware
+func (sh *strictHandler) GetCatalogMove(ctx *gin.Context, name string, params GetCatalogMoveParams) {
+	var request GetCatalogMoveRequestObject
+
+	request.Name = name
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCatalogMove(ctx, request.(GetCatalogMoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCatalogMove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCatalogMoveResponseObject); ok {
+		if err := validResponse.VisitGetCatalogMoveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateCatalogMove operation middleware
+func (sh *strictHandler) UpdateCatalogMove(ctx *gin.Context, name string, params UpdateCatalogMoveParams) {
+	var request UpdateCatalogMoveRequestObject
+
+	request.Name = name
+
+	request.Params = params
+
+	var body UpdateCatalogMoveJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCatalogMove(ctx, request.(UpdateCatalogMoveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCatalogMove")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateCatalogMoveResponseObject); ok {
+		if err := validResponse.VisitUpdateCatalogMoveResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListResults operation middleware
+func (sh *strictHandler) ListResults(ctx *gin.Context, params ListResultsParams) {
+	var request ListResultsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListResults(ctx, request.(ListResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListResults")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListResultsResponseObject); ok {
+		if err := validResponse.VisitListResultsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// ExportResults operation middleware
+func (sh *strictHandler) ExportResults(ctx *gin.Context, params ExportResultsParams) {
+	var request ExportResultsRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportResults(ctx, request.(ExportResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportResults")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ExportResultsResponseObject); ok {
+		if err := validResponse.VisitExportResultsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+// GetStats operation middleware
+func (sh *strictHandler) GetStats(ctx *gin.Context, params GetStatsParams) {
+	var request GetStatsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStats(ctx, request.(GetStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetStatsResponseObject); ok {
+		if err := validResponse.VisitGetStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportWods operation middleware
+func (sh *strictHandler) ExportWods(ctx *gin.Context, params ExportWodsParams) {
+	var request ExportWodsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportWods(ctx, request.(ExportWodsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportWods")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ExportWodsResponseObject); ok {
+		if err := validResponse.VisitExportWodsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // GenerateWod operation middleware
@@ -329,6 +4698,72 @@ func (sh *strictHandler) GenerateWod(ctx *gin.Context) {
 	}
 }
 
+// GenerateWodBatch operation middleware
+func (sh *strictHandler) GenerateWodBatch(ctx *gin.Context) {
+	var request GenerateWodBatchRequestObject
+
+	var body GenerateWodBatchJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GenerateWodBatch(ctx, request.(GenerateWodBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GenerateWodBatch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GenerateWodBatchResponseObject); ok {
+		if err := validResponse.VisitGenerateWodBatchResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ImportWods operation middleware
+func (sh *strictHandler) ImportWods(ctx *gin.Context) {
+	var request ImportWodsRequestObject
+
+	var body ImportWodsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportWods(ctx, request.(ImportWodsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportWods")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ImportWodsResponseObject); ok {
+		if err := validResponse.VisitImportWodsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListWods operation middleware
 func (sh *strictHandler) ListWods(ctx *gin.Context, params ListWodsParams) {
 	var request ListWodsRequestObject
@@ -356,27 +4791,357 @@ func (sh *strictHandler) ListWods(ctx *gin.Context, params ListWodsParams) {
 	}
 }
 
+// SearchWods operation middleware
+func (sh *strictHandler) SearchWods(ctx *gin.Context, params SearchWodsParams) {
+	var request SearchWodsRequestObject
+
+	request.Params = params
+
+	var body SearchWodsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchWods(ctx, request.(SearchWodsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchWods")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(SearchWodsResponseObject); ok {
+		if err := validResponse.VisitSearchWodsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetWod operation middleware
+func (sh *strictHandler) GetWod(ctx *gin.Context, id openapi_types.UUID, params GetWodParams) {
+	var request GetWodRequestObject
+
+	request.Id = id
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWod(ctx, request.(GetWodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWod")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetWodResponseObject); ok {
+		if err := validResponse.VisitGetWodResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AnnotateWod operation middleware
+func (sh *strictHandler) AnnotateWod(ctx *gin.Context, id openapi_types.UUID) {
+	var request AnnotateWodRequestObject
+
+	request.Id = id
+
+	var body AnnotateWodJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AnnotateWod(ctx, request.(AnnotateWodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AnnotateWod")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(AnnotateWodResponseObject); ok {
+		if err := validResponse.VisitAnnotateWodResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLeaderboard operation middleware
+func (sh *strictHandler) GetLeaderboard(ctx *gin.Context, id openapi_types.UUID, params GetLeaderboardParams) {
+	var request GetLeaderboardRequestObject
+
+	request.Id = id
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLeaderboard(ctx, request.(GetLeaderboardRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLeaderboard")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetLeaderboardResponseObject); ok {
+		if err := validResponse.VisitGetLeaderboardResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWodResults operation middleware
+func (sh *strictHandler) ListWodResults(ctx *gin.Context, id openapi_types.UUID, params ListWodResultsParams) {
+	var request ListWodResultsRequestObject
+
+	request.Id = id
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWodResults(ctx, request.(ListWodResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWodResults")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListWodResultsResponseObject); ok {
+		if err := validResponse.VisitListWodResultsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// LogResult operation middleware
+func (sh *strictHandler) LogResult(ctx *gin.Context, id openapi_types.UUID) {
+	var request LogResultRequestObject
+
+	request.Id = id
+
+	var body LogResultJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.LogResult(ctx, request.(LogResultRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "LogResult")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(LogResultResponseObject); ok {
+		if err := validResponse.VisitLogResultResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RW72/bNhD9V4jbPmwYHctuM6AC+qHFtizAgAXBhgALgoAWzw5TkaeSlH8s0P8+HCU7",
-	"Uqx0RVdknyxT1Lv37t7x+AAF2YocuhggfwCPH2sM8T1pg2nhDB16FfGK9GX7jlcLchFdelRVVZpCRUNu",
-	"eh/I8Voo7tAqfvrW4xJy+Gb6GGbavg3THvSF8soGaJpGJgrGo4Y8+hp5pfuA8d6XVHzgB42h8KbisJDD",
-	"O7Eh/4HqKBa8QXxnaY0WXRQ/iCphfw8SKk8V+tgpc8oi/+JW2apEyOGSNiAh7ir+E6I3bgWNhBYgadXa",
-	"cERVXvSgmKZ8hHkAixF9gHyWZVlzAKTFPRYRmuMVCT97T/4SQ0UuJIgh1YL0kOrrLDugGBdxhZ5hLIag",
-	"Vk9UGbdWpdGiK+2xwkHOr9tgj1g3I3SPK/dscpaqDPg09br2yTC31rihrlMJVm2NrS3ks3kmwRrX/Ts9",
-	"UixhOyFVmQlTXqGb4DZ6NYlqlaIk2SryF3t90hr3dnYqrdq+nc2zpJ1fVbZz84HJNXjapBihRA0SFrWv",
-	"EMXCk9LivrYVZ8ZEbMUfmaZbUN6rHf8vcY1liuBYzTUscGWcSxFYj7eoDZOVoPRauQI1B+iXcbBpGPAL",
-	"MkEOafl2z0L04cWBQeo+ZF/0HaXR0oSXJ7P5q3/1U6tcDos+5qor0sfWT/2cng65/tSZ0p4PI/kvPKqI",
-	"+lYNywzzbH46yd5Msh//mM3zLMuz7C+QsCRveStw2ibRWBw7GZ76+LgjB+b6fLes2gYjf7tGHwwNuwTW",
-	"szE2JqXvwLyujR7b9oVOPMLZ++LT1U8cesmX436Q+0J3uGMpOPYMBzNuSSMD4eJcRBIdCArl+ACM3uAa",
-	"xa87T9tJiLsS93ODA0cTU3bTa3H1+0/ibM9BvLs4BwmHYkB2MjvJOAtUoVOVgRxenWQnGU8ZFe9Slacb",
-	"0tM9g+RsaqfnkOrZgaNwuElxFyqgFuREQS5Er4xLBLkvUsrOde87bhvZG9y753pkMNunI4M9la6dQUnA",
-	"PMu+2rBnlqleQ/Gsdp8iLUJdFBjCsi7L1AavvyKB4YgdoXI+nJNiwalMJGYvR+JPp+p4R978zacvB3/1",
-	"csF/Ib8wWqNLkedvXi7yJfu/NNZEgdsCUbfqT1+2/hG9U6UI6NfoBfIHvLGRbSuXpm3fFaafYTf+ZkK8",
-	"Ih2guy92V8DrB+DRAB9r9DuQ3aUTklSQPeoal6ouI18a+5ee41teI8chabkM+AxmH3Lk4tjc/MfGH47s",
-	"Dafhcwd2OheeDsBm9KR/euXnegha8okZ/j+37G0iIdTWKr/rzCBCJI+6Y9depNhXrSlqX0IOU1WZ6XoG",
-	"zU3zTwAAAP//c9Ebn4kNAAA=",
+	"H4sIAAAAAAAC/+x9f3PbOJbgV0Hxrmq672hZdidz057qukrH6d7sJp2Uk5nUbTtlQeKThDEJqAHQsrbL",
+	"3/0KDwAJkqBEOY7tbPKXLZECHoD3+xf+TGaiWAkOXKvk5M9kRSUtQIPETy+uV0LqX4QsqDafM1AzyVaa",
+	"CZ6cJP/+7s1vJGccVEoowU9i+i+YaULx65QISZ6/+ydZM70klCyBZiCTNGHm13+UIDdJmnBaQHKSzO0k",
+	"aaJmSyionW1Oy1wnJ8m/lOB5kibAyyI5+b36PFNXycc00ZuVGUJpyfgiublJHeBv1hxkF+5nWcG4IoLn",
+	"m5QAvkn0kimiSgT/L4owrjTQjIg50Utgkog1T8nkf03IXEgCVyA3/u2e9QicO1xOF8ozUGWuX7GC4e7G",
+	"hsnxYXRXjsdpUtBrVpg9ORqbT4y7T9WeMK5hATKY7s18rqB3PmGfRicMZxhHZrhJEwl/lKD0zyJjgBj0",
+	"nGqai8VrcQVn9pn5dia4Bo7/0tUqZzNqjubQHKv5rp77f0qYJyfJ/zissfTQPlWHwdD15ExClpxoWcJN",
+	"mvwKHCTV8EFkdz15MPRbQzKqBwS753c9ux31JV+VOjrvjT8/PINnnAuN06guMXxYUoP9QBBhySXAypAG",
+	"fvXhzWmKZEIk6FJyyIgW5kmRpMlKihVI7c55Tq+EZBoCRJ8KkQPlyU2a5HQKOb4H17RY5WCIGPiCcUjS",
+	"ZLmR4vpAscLQMtNQqAi5VPhGpaQb85kLDY0hk6ksNc0tuzkeXy6IyiFLYvyh3q/fa9DdkBW4NWexbM1M",
+	"GuzlW6pnSzzHLGPmG5q/DfZkTnMFaWu33y+B0HoIs52zJeULSO0ZFExryIjgoAiVQC5hpW+9182pX+H3",
+	"RMIqpzPGFzjhrJQSuMYJU5KLNcgZVbhp1UEU9PoV8IVeJic/HCML8B+P0u4pFfT6pf3l8bj/zJqg/SIB",
+	"iIZrTczzlECx0huzNxIKcQUEOWAAxvF4PI6daue0fjZHVJPorrNqbvPM8pcuuI7xGABXbHZJDIyKzKUo",
+	"7Ck6hkncAGS9BO6PNolsWVZKRIeLgnG34Y6NHzeY+tMuz00Tg8irwnGU4cSTwxXk5k0vUqewYJw78axB",
+	"FpAxitRAsyvKZ5BFJG2aKIAsMmGLxOxsrZXGyOvnXMwuIxKbrIW8FKUmU/MC+c5suVk0+d8ENRb1fYdK",
+	"WNYd552m0xzwwMjLU3taRvARpkguZjRn/4VHVLMUKdaxIzMj7GLSRjCdgqYsV+YnVsCG7OosPvZqB7Ia",
+	"Dh+A+Gfi9bWj8XjcpYIYXTynOfCMyhdcy40ZooX5EqiG7MIqfU4zO0kyquFAswKiSEw1dN6Ovciyxmtl",
+	"yaJE4TlFd3ukWEhaRJ+Vq2xvwNciuxgEUwun8RX349Sv1gNXiZJgKxvgfdx1Kla478ex/BnUKHY8Pn56",
+	"MP7xYHyUpLuPxu/5LlbbOIQmhb21D5CywKyDTCEXfGFEXZOJ//XJHR5G8xx2bu5tRfecQZ7dUmoPJpC4",
+	"jHxxG5m45aCq8TS9hOC4DJMVc8K0IjUy3+Wx9Z7ML06UNLetlHlEYQDIyD/OXpGlyDOvy2hxCTw1S1Ll",
+	"1Lw9Nd+hXCaUzNwshK5WDQ6/1HqlTg4P1yIbuW9HM1Ec0hU7vDo69L87nANkIzZT/xfn+ek/ix+vdqKl",
+	"AX4bMr6lC+gu2RyE+7cS6NsNoZCbd4R9fM8r3Saq9ET0x99oAcqghrV+3YtEgbxqyszfk5kUSs2Z9rp9",
+	"kiay5Nzs0F4afkO3CcaXRk9N0sSp92ua51Oa5/sNvqRq2V2lWtLjp391dn+1SGe0pYTmShAFXBOqyIv3",
+	"dBEjYNR22tbOMP1qOPiodO6BIoGt3B2sq5v4c+vCQRe9hlzBrvFAlJaWW+y1oiuQigneGPyHQGoxrv/6",
+	"JIl6NkKaw7WkNSLXA7szrw7ILSbEM7+vH/tp5pTN5126oVkGEYXz5anyuMRhbS2FZJ9NsXIm20+5r9Zz",
+	"Efw8YiniNmx/x0qbveZvnYfdmXqkek0dCGKQbzmJl8VKyH11pMD/0pXv1hkIGZmzHFKiwErGsxfPTl+/",
+	"QNef+WhcmjORlwVXDUliMC/lAJm6EBwuxDw1+JV6yh9ZPf2cn4l1iiwstZSTPhmPD34cj895VLGWmwtZ",
+	"8oYnLqqg/JPmLKMaCOUZydh8jq6bJI2c6rzy6Hrzz3hSU/SrRs28DS3y3RA8M9zRuopwoyS6qYx89oyU",
+	"KvL/nr1+FQGq7ZTx7mB/XjvxwPrEupSZOXodwB6RtJtb3t27uNx4bsEk5qmneFY4bHKrjyp7wzlvwB9b",
+	"B28fOFFM6FyDDABI254eIjihJJMbYhY5gMOG598mmfYizRFTPGRHROj9oOpygCfOse4Ov/bnkdrD3IIK",
+	"r51dvg9DKEHFzpPOloi5JaimdnMq2RVYJ6PZ2BwWisyZVHo/cdeYL/J+xtQqp5uLAfARVtHbSnAF1pcB",
+	"KWELLiRaJmQtmd5T9ngIWpA2Pian9ae9wOhdr6eIrva51/iBm4UWUMp+V8R2FxElKi8XlRA3YLT8eTud",
+	"RRbMLR6d3X6k95JylaPvrIM7bnxycF6Oxz8AcRuJDtXGTvyZzDEaZnfY70vUPkDtdLB2aSB8jb+IKaql",
+	"muUxFH4rWUHlhtgXyEKKctUitT9KmpmvFnlpTzWnWu0ZJRjqdgsFd9Te2GvaFdUaZIReXnvPpX+jjmqq",
+	"P0oUeYauMQxR2r+rErngqswx5kklBuxmQpqH/yqLVYI4JgqhLducl3l+MBXZxsBcL7z+OrJ8adStoXbE",
+	"mX15qzmw13aVnOmtBNJlF41dRZ9pRQFmtNQ5hg16GbF8BcQtMeo7TYokRgdrYItl0/w8Gh2HrjRRTnNI",
+	"Ajd97YXhZTHtM1B2yLBXTEVUmYey+e7WMqvF+05b66xCyj686Pu+jXqBLhNEqPDY6g/tLeqA1eK7xnqp",
+	"kK6Jgr8XjKekoNcfm/hWGfsB7v3+f8bj9Oh4PP54k9Z+gvCFJ+Nx+qN5HvXliJLrd5pGEOYSNlHiWYss",
+	"ujmtAzM/dy/HDunURXPic7ejWt2DGAhFY6At4LyQUsgzpxt04ZmJrInvT8bjmKpbgFJ00Xw1YfzKWFfE",
+	"5Tfs1GRxsnqsGLhBBsHPt3BHv2B6CZJMENEnmGozsRxvggbgZGbQYjJKugZwGTN/P7w5VWThYMqs19QP",
+	"iJZwBhmZIHbPzCf8Dw6OJkSLyPf2C5zLfjOxalMF4xLtBPOLkECeBiktT3dktJifrSiP6HBnkOMi7Gaj",
+	"vx6KKWQpmSDLmRCaGTf+0ul5mY3S4Xvo3MQoY1MVqXjVcKFWvTmIXbdi1Q1G5bbCfTrqTmVVwFjQny9K",
+	"ugCvv3rVOSXiCqRkGSjybDaDlT7wrwYKCaAyIaMegTpKuc+ibrYTwqOK0w8QjG0eV/OWp+keYfw0uT4Q",
+	"dMUODNtYAD+Aay3pgdetrpxjJzmpeExaMP7T0dO0oNc/HR2PcWMb3vIWs/CPCL2iLDcWjvVu/friPTn0",
+	"6/f+rUsu1pxc0bxj+jYc73vRwiMn1VumQ4QCovFSc8JbnK/gIOY/eShIODypILi5D8oP7IeoEe0zQOr3",
+	"MijEgfn64Oj4h52icnBuyCtMH50KKrNPj5kFg/WEzdJEzQRCHKCFSyKQouRomOaCxvNivG7T9tqUXPsT",
+	"QYkrKb/EtLoFGHm+W4n2QLkp0mrlO7asJ9XDzN80b2KCVla+1d1ZiR2IcYpqjBiYYaZMv4Ppqoq/izmh",
+	"yA7SHpdQ0ufkG+TGS5P/AFjht1M6uyTznH6ab6+aNXlb5jkOvKQ8y8GlUxLJplXSpY0MWHi0kUjGYrf/",
+	"UVmoUTQd6bO4a+7WERN4RAa6JO7eKbCH7X/Tg6evB+nqXbs97gkiL0+N5u4s47aPakeq5a5Ese1YgLoW",
+	"GtjdnfsZ+ZvHSC1MVq2J/NPZ0tm5RohYCjHytwpy4kpR9QkBeRrb3ZBF4M/6OMNrj9x77LhmOoctaFlz",
+	"dFZYyXfFMhAGw9k8ytBdHko3byTMGZFiPSpWT3bKPHya9qaHmFXHjWq723FzOubNfu3d2D5Hyeh47siU",
+	"Rqf5FOZCglOOlzQjL0/VtkjVbZ0JDsfdCrbY8W1/9+3COXcXiIl4kHsiCjGeEZDZnkyDXkfyCfgsL5UR",
+	"XOVqBZJMDaEmMX9kyweJzGTbeJj7PXy86FJBMpHFEbeuKWohKH7v2UeTmRgFadjaJKxUzLZY3cHIRmvp",
+	"oTilqYxYW78YLYJkdOOnW+HGJOmtEzMHkpiFp9IMPeyp3323UTGic3mbt8k6RTvsIqMb1cgQOGoLlVO6",
+	"UWQKeg3AiV4LfwqVnXz8t10Onx6f9QGe7sFRN1NxhwSNp1r+JjQQBdrIP2tkYmrkoHzLHpQ4rZEBdUyz",
+	"9oEHf8FilgQaD075FTKzwniD2aeZ4GDilh/enJKCboiEFWBcqWKJO5Ova8+Tryvrcz3FmXyAiAh+DOP6",
+	"8jWoXuYQO5Z///De19z5vfTvhpRVKpBRuzPFUsccXAb24ATxW2XDsyum+gTKwAx4Y1teXC6ak+5kgRHj",
+	"zRqr8WcriD9QphiCL4KHAY6vcqeYDzI33pnXY4LXbN6FgllvdOAO8vJrDGkcfuNU+7HzNuywjWTtcjuo",
+	"auvImipHrVys+92Plaz46/ujv538MD4Zj/8zSW+BiS2e5J54HwTjhuHltcugXRFWQySvk7RdF7YNf5sT",
+	"/xvQKwZKE/MGydlcQ5aiZmqZlppZxXSDL4QTH42PR0/NZ6e1vPbSwtbE7BWVHaI1lHzOOFNLyAhSUWr9",
+	"BRP8oCYN0I63V8eGdNiaEL8nFd6EW2GwAz/aH2NkB8FuxUy2T7yKcNMzqtE3uAI5A2byxuDaILHg4dh/",
+	"a5QY7xLPAdNonbhYN5DevNhBclevGdZePx1fLpqYdtQjdj8DS2rluqERbJ5Hz8eRX40OT46f7izI7uE6",
+	"8aqAQBMdtEbvjRtSDmD3Yz8+N42X6r0VCkdoKN6EBQXFGFo8amzWLsQKj6X61fGT3SXvoWDwkYJqsBjf",
+	"N9aL6g9m92li0w3xr6RELYXUhr95d+Kg82pE03cVQ8RBqF5JSSH2nb9OI+iLj2xZPD6/81mrfJeuUwNn",
+	"DQw71H7XIAFDfdbR4eDxX+wDVuWBiUBlrbnYZgAYE1OSQnC9VFZW4A4ZBm7JNyUiz/bGjcCyjgAUcIa2",
+	"KMMHJBeLRZh+G8nB2JYMsDso0TU4XaGD36ug5qImpRCnY7T4QUQCPbTZzGDbroV9D27SwHc2LB5vXo9t",
+	"9+54c42WDYyUohgWVnYzXAxPNa+Sr7U/NsOCrbSy1fRGSOGxNt1+TKNAljATMmuJ5B/nx7MjGI1G22Dc",
+	"mZW+E8Ik3S+rrG2RxRXl45PxnoryznylW5bau+UKeRHLo0uujj6hSvquyvhFvFNOxOSe0TwHSdZLEWQL",
+	"MT3UBB/WLwAX2yidjkaJA2+ySyTq7nVNr12cbVFaDw+qsrPaXThYHi35MojhWh5xAlIKGUXimr1uHQLr",
+	"xqy3qDvGfhlGfi1m+LgcMXnjOwFCEy1kIrvlgwUvXHMwW+q3ctv+I8ydM7C7u2PZzbxAs2s8g+vdmqsB",
+	"2iuuUwODU13HSTxEXm9et2hoLbJdYBpp19k3hLRnXx5H+dsd1KvtUaa2s/HX3jVk1Ua+8LjU3LCccWgn",
+	"Pu+bNCrWJ1ZDJucJXJvBz5PdeTGupnZLCmkFe2/t27ZCNiSeaEeoTeCoPhqPiYR5qdAHsnYqjftmqP7a",
+	"2uQI7+njpmdmynq2CAt09Wc9SqxlVCnhkbq3mItmrXphoAMU4bpSDYcKwKuWWG18z4l667/dAWflkrnM",
+	"skbkvS3pU0LalhNiRf8owRQ9KIU1fpi5QhWZ2Lcm57xKdVG0MKebY+TNeJQUdsAzAt22AsOpbBmhkI7J",
+	"//2cVxEGH0x2ncOAZ5XHLGdKM74YYS1rExk5XOsLC01EqcXv/SjmVYQi2h9HwtXAccyrTJSqdyxMbdiS",
+	"p2XDPzaSom3BnV5Wm+fdgcxEUTO4wNEmLX4WMbaG0sww580Hkb0DKgdkpYR1CHmenPzuU1OSd8bx9tbU",
+	"Gd2k1Zc2CaXOMqljuKgimwjYzc3Nx5v2QdM8sqXWaK/y3mieY1HOPga5Tb1phae6rITyzc7pzdQGfXOg",
+	"ytXEzj8DKL2m4hvTVC+Wco99KN3P0iqlxtJ4LoSxI8uV0UqYjlplPWQxCYhvYmsFaiqa2IS6BrF4CkJe",
+	"oSx6ReZrYP5ukW/pKkJUjJOJpZ6oInBXZk7uW23WUeqdTu2CXl94q8Ph/ra3Gd/jbS44DMNV/tlwFKPH",
+	"jT1BcIJkYIqf8Ms9bMdGl1WLQfWaxLzRcXVQv1U7T8ymFLJ5qE3T0S+j8WV1Rh8HZPHcIKbPRWSRb19a",
+	"wWmJ2MVntGRwBeTfbF9LvcnB969TI2KOBhP+rOxFZeCcOzXPE7prRed5ArRTtV0vXfIdcGOVfG/lrct6",
+	"S3BiJLBfvT1Mnr19GdTenSTj0dFojMe3Ak5XLDlJfhiNR2Pk+HqJGFY1PzIfFhArLLDJx+j/ppvUpql2",
+	"0xJQZzS4mZU5ZMafJFbOBfQyM8nqTGnfxciLHF/71p9dY3QNq4PG+glbb1vdT3VHrsVN2p7I5GwEKo1R",
+	"JVeqNnljk2rxaVOiWPC9uBjUdFK34opNWz/d0m04zjDqrT4MuxEPft11E775iN5fNLERdY7H4zts9Bt0",
+	"y0Ji7NON3bYZtH5yhwC0PAhdCF46Sw/NWelzap/eLwgaJKe58ziZF1RZmAJ7R1+B9+4vqmqHhgq1ULqX",
+	"f3uqJZRvbPwOaVsvQaqaV3dJ+p373QdhnT2+L/TmztEiaJcY78HcQsyjzwNB7FDeV231Kub3YLgJvszl",
+	"yfjJ/c1uhBAXmsxt+oRAbLHJHUoUIDgQyNUjI5d3Fc6jELVuA2d+NRsCWrLx+WpNCjiDK3EJje6GHVx8",
+	"EnfEzX1/QyNzVpCh+uC0ncezTXaBcb5il4CtEvtZzBm2irKqrlmtMUM4Yc8bY4h5fAZMwzjnYV9H1W7+",
+	"aP0kCIW1mYjROZemvFHwGaSmLxHji3NOF5Rx1xsblHnR6GYNF4I/DuUPw+pcrTMXmuodZ373/Afn6WE/",
+	"HpUeF+o8R118AOp0KM604OxVR98HqhOqzj+ODeEqQhcCyRgdDwdGeYQrs5y0LgD78Ob0nLuwrdVgmSTB",
+	"8CPymyBToBJk1WrU76/DMHXOgYtysYyhxq+gW3jRUnPjCqXZhbZM26bn7VbENFzrhmbfP1gUo0LV4V5l",
+	"yT+4rUzGbIorcRkymMeD2l32FTSf/c5iykoKDTMN2fctDHeaPJ5cnGd62RQ4CBh3tpZt2oDJ16YjBAdn",
+	"OU/qFP0J0kN6zkvurDNvW6BNOiL7qX0RPPcAvq2Mks+h/DXqFR5I7euzR0Iu9PB6nzvee6fWZ1W2Z1P/",
+	"Y+oLUwGrDYxS6uGf7r+bbbrgP7jqkEWM/RvXS8yu/xT+/2S7nKxCJZYLoKvGLiO7d5z5TThzifGm3+NR",
+	"4UZ9mL3Y8SfLtuLDKX7ftB/3ObiNrR14oGNCeGuyflynY7cWc28QzD77webg6O7ZBIpaz8GMH8CD8O2Q",
+	"w0P+FfSwE97NZFm2lb/uKj76aOZwCWItno+3fXRR6TN7wuzdFoNUoofA46A9sW3x/ZU5xCxZ2WTcx0pd",
+	"z/FghhCYFXpVkDtqlGM4MyXYC70uUUAHi00Kdw2nXEJ/lcJpnTfmkgW0rCP3MdgUaO+pcW1wfd2JyDDu",
+	"yDPjzcFsGMbP+cv5wW+CwwEGR33OCyU/jJ+QkmuWN699wH1Qvda8zyvdGq9yr6F5NajvWMwPUOewbo3w",
+	"RKJJNn0Am8ba7BWmzFH0zKPp7efIBHZOdg4VpoKz/s6chu2l6l52iQPZ9z2AhFcy1OAMv4JgC5wIoO0F",
+	"63IambKYmFoD16MlhmJhroko+2759GnJNYiflKFg4MZpqqtS3TwNtE0+zf/zyTdu9juGPJJa8O0VsuaS",
+	"lN5SiQ4hd4l4Oz4m/6Qyku7TCpen5Fmpl0Ky/3IlWn7wxsVvCMBc5LnJ9zNvaOCUa+x1k9PtgBhQfhg/",
+	"6V9pyd2NFkQxPrOuzwW7Au6vkfk69+xBJL9N33sw/6Wnk0cl8382CatQZZwh2/YC6rtVOc3Z7PuGvD9k",
+	"Vf55X3THRVPqLDbnDHViz3veBfePTGq5kPYqaZuMPjrn9S0TBvN8s8SsuubE/GvCN1WIO7yYymYx/91l",
+	"v05cfu6EcKFt4pnCKwM0cHNTBZst7RVPJsSD/a8k0OwAr8B140a1AZvc/NgUgo+fy9oIb9+5d0Oje+VL",
+	"BNX9Hvv0a/Q81qiDG+xwAUOCoB/ODLm2+2im/+H+pv9FyCnLMuCPgQ0+Gf94f/NXuKFq6n5cvNhid8Aq",
+	"3R2GamUAVksA7dCGfEdNvKbFmau66ah/yybbVXcDqMfFrz4v36juVogcyWsvohomoL02Kd84efI102k3",
+	"pczvkpXuFS7W+R5NzLNR/+AoHrGojG1d/QqDBkr5+/0/b9Sx3rYe7CUuxfnBZFnh7hr5JsnuZX48c5ob",
+	"obAhcM2UxlYTs0cv4J5lmevg7DsgV6ZGrzw7/NPQ86CoWshfdsfUXtu+8kEobXz/yshalLmpp7YOJ2zZ",
+	"8BUREh5BgLkNv/g35bCVdYl0QxvCN5S9/UHNLXL38Xsd+wTf+7rt9De34ze341C342Phbo8spt7iKpXj",
+	"b0gkHf/sk6uUPqS+vyojbNLH7JsaxOcwDMb3ahiUuK5vhsFXp8/gvbneRNDUJB9/ERaCCx70qjnGPAj6",
+	"3kUTD15R7MLoGqsywW0wICV0JoVStpNGtBbVNc7b5Z5olDjnvsbOgTWoxrmHhdVdi/9bFnQGbU63lnP6",
+	"A36w1Gm6uH+u9cqVG7dQiXKbm+BQgzgpa7UfpAoiRf5Y604bC/FraFDxoYuD9BHzOy2BFqoxmu1r6ROH",
+	"bHoSRnqmm2q/PPWZWjEsg/Ade3HPTIvrOJcYkWc2DpkzDoZRUtdL6Jzb6Yf0xxqRZ9x76k37H3OuBcvW",
+	"dHPO6VRIbdczE5zDrFZ3taRczUHib1TIOIBnZgygMt/EApEvruu4WIR97eAA9te/2IzHm3Tg+2+wL0NP",
+	"yo0/qqq7NaHmLKosQKZ8D8j9i/h7WjkOhsS1wNwFhBb7g7Af97w+4FmXLDvLcpVb6uoWRVsPzUrDlIt7",
+	"ZKYWQ7ewU88evgh2alfj2Z5fDVU1o0L/7/N3/7SsVflm1lGOis14VDOz06hEyEFb+/MXHNjzz7+oBged",
+	"btxlfDaJ7pz75ipopQdNqc1HVRa+gMxykaqnvRlnDXBZdVCuizPPeYvlM+4uI8P+wj0JmraX9z46nNkL",
+	"3LRbNahx7/awEd++Zt/kSnMkPrCTus1qcbL0wZjqIOA+I5/tQGU7ZeP5TSxymOur19iZGwsjieDkteDY",
+	"reYf75/3wLKQolxdTJuOpLq/kBkv6CzkPiLSJh8/VRrsx1EskvdwfbpYSFhQDV8d438fUnIPs3/UvP6Z",
+	"PzrfaxdJLaAwy+LXIttHc+5n9MietrH5c85hXXWuT43YqRpCSsMaMUnFAEsXsEV7xmLiYapz1b4dJ+FC",
+	"Vx1qR+e8T7MmgxXrc763Zv1BZBGJcv9qdch3HwXz/6qUabPwr1uT9k38Gtzji1KiG1y1V4M27NU39+vP",
+	"dP61av/nu7WTKVWQGV1jJrjSkjKuTebwOX8H3ESvyORlBsVKaOCzzcF/wGZStfQrV0QLcvz0qak/knSm",
+	"QarvzVcFvURN2HUWoHM4IZS42IO75s8f0CVsnLPFcDRmjoj7WN05d038ZdB/J+jqrpfAR+QD00tRmpDQ",
+	"JWzSYCLbBv6cTxRANukM4p3NbjDP2rGRaM3kjCQ7596369AiJcyAvInr83aPo/3Edsdkgp/fR0zGNnOP",
+	"dsOqG76qcjYDpcwd2JvUdjWpWuIHYdkKUfTBWW+Hfi1LsMGxxj1b2t+kFiCC25iYKVK1XH242gyPZRhP",
+	"RiCO7jOhi7rg88Nnet5jkKjFiioSLpUj4BbxW55fEzMCfHyPAOPlddjMl8D1DCCDB4ylY2KwrGRNR26c",
+	"TKsLRLZKD0Us5386dtq5y/rGwJKY1/tNFGjs+jPBeshJes5NR+eZ8edMUPly3a8m+BM1saeWgcR7/hRA",
+	"5jrIW+kn4ZzXXGkmeJ307GtrjVjDMh3HURg3Gj3jixzOOWrVdGabZL0wbhkDl+FmqIgzrWwHIc/esC19",
+	"Y4sm5zwTrhyTIoANHLR9Y7Sye7xDOvzskpU+R+lLZ5p7rn5pzttVTEWpZ6KAunO9OYi02ZDX7csDJgNM",
+	"fWPqB0nWxZyVR8Es/K0U9Z3kgdDuJOc4FVOZg6W5I12NfQxrXXVXTZ6/c0U5/JBiTWhtnVsFjmf2o9Xp",
+	"7Jkh8U835OVpalRQE5wPbAH/c+QzTI/Iy/rqE+U8BO5qGdT71CVbrSAbEbxaY+01Te7sCCqbrQH/ju5Z",
+	"54hwNV22IYC3QwIrRFk/pG3diSE/X8dvn/fX733wt9jdPeOor+u5f46xq1YO9/ALKJRzuP0YK7VwC6vb",
+	"k7abk0acb63NivuYot0HMLUj6qI+Gjdu7t15U6r56UrCjGqPlZ3r+6vnqU3QsBW1/goJ50obked4Q6NJ",
+	"ZZ+JYso4ZM03R33xEZt4El3NePsVxzfpJ993ETQCjV6P0wO0HXC/qE7vJRjBzTLNCzFiMzdv3ojumruC",
+	"o2Pbpbe6jeTOO5PsmpC4ywpSLyGjuaAT92li2NOgxNDg+r+II9LfOrmbXAYtqFPubtYXZFLvANW9snVr",
+	"76kvSd+Cc2rzlarbdHCNBeUbUjBealA9a2xclRKCetutDyApxD6A0Ou7BaTGgUgzHBv8mFSfL5D+J/fU",
+	"B2dC83xyUultOdaM+S6xfq6JLRFIyYTyzeSkfVGS+XWxC167rJ74Kc3z8H4Z/ET5Jvl4q9DDPvGP6hIY",
+	"8/ZDBkI8INV9IvtDEhvW3YN6K17s7ve0N7b7BAib2YGav9lll+MfJSObLr83r3LiNYYnn3iPT3w+NIN7",
+	"Jtzn5qMt17fgdga35RZUXlrFcE6vhGS6NzxXP97inB0+8cqYVTl2NVNAcjqFXBHRl3aMz++y4VZt1y6F",
+	"wgguNLM1URZSxpWjF7jWKZlRBUafBK6YZld9e2VG2w/V4/nan3wh1R1l+5xBjgzE3jqFdxlAMTXq9gQL",
+	"YCeEZlndTYdkoDE9VAsHDxJuDzxwvaK8yRmq2wB/d9e1f0yHn/fHz2uz7s4N/xpjsGFq+BccgTXLCOOv",
+	"tW2sqnss4+6rXxjPgsb3KLAs7iNFmKQvo9+kHY0FFRnre/I+t4n5Z5ISGC1GhJLqBkzrPfK/N5dcksK8",
+	"fybWI/IWHerG9yRBLUWeKTI1dT0IE5pizrY072E1Ij5x10o4OwZF6jnXa2EGDR4e2clMnNdNbN309oSr",
+	"S0fNGApdYNUlJXSBii/qHYbTNm9j/XvIce0eg0vCPEefqI03OP9aNcIEcWoSbfKPg8Q9FV86V/ss3j+7",
+	"YQ/g/XvknNRR/H1zUnsc/w14qV1II5tluvH51Yj/FXP1PenjyeDIHKxu5LPzrNbTZA7uUWG0ylEs6drm",
+	"Z3z2ttvftKc9U09cuPfbBXDDS+FromoS0SGtM1NRV/Hx/TaPaVdXeOuOzHO6SK05hJ0UvF3molaYV2uv",
+	"13lv/sWYrmthTSbB7JM65cfzLForSDHB7ZJq4Z7I9DMJ1CA1+EHa3Qfz96beh9nLj6XlfYi43xjBkCx8",
+	"Ry6B57OZQ9/iDDmm7U0FlVn/nckmQ8aVwP7FXCiMyZu2pFRSfmn3ZKJmwhCRcdayAghVM5uibuLvZguV",
+	"vWwZK6cyqJ6ajc0FzYLvRuTFH6XJPZhhUN/MYaPnNiOwLn717XJt1GtJJRCKr1s206jhxDxSDJUZ8Gy6",
+	"KAK9gp8wMdQuplmwK3gQ96q85Oe8uuYaBzQ/T4n37LXvDKgux8esxk1/UP9X0K+C43gIpWSyFhl625ly",
+	"N4kaz5NNnD2JbYXPoFBhdl1cJ8Gt7qtPElngxbSfzKSDvJjvLOIRWeauCYtBfoyo+HPES6JcKR5CPKAt",
+	"i8PnaFDK+bgtXidpYhB4EKyvTJwrzKCxAOf1127nBwB4PzGzbiEjwpixK+aCkjHYgsf7uvY/rQK7+tXd",
+	"RkxuXYxdw/MJgZMvtW9GyM561I5QAH1l7fW/SAXjzEjXSE8Lbwu09ItbtryJd7nBWojbdYr41lrmzlrL",
+	"fKOQPXvIdCjknm55i0Yk6t4aJv8oNA9MJpz1qZnsn0ADbnnWzjn1YUmsfSJG/wICDF2REyPFLhTMJimZ",
+	"WP1oUqeqwbWWphJLwkpNXL1XyeeMM7WEjMjqnCdGo7q4XESd+a/EwpLNbQqr7C/vowF2fwarfeIQI3m4",
+	"yiUL3zeiHkDUYkGo27FQ1pmXMEPeEnIp8+QkOaQrdnh1lNx8vPn/AwDKRu4cr+AAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) GenerateWodBatch(ctx context.Context, req GenerateWodBatchRequestObject) (GenerateWodBatchResponseObject, error) {
+	if !batchPath(ctx) {
+		return &GenerateWodBatch404JSONResponse{
+			Code:    http.StatusNotFound,
+			Message: common.Translate(common.ErrUnknownPath, locale(ctx, "")),
+		}, nil
+	}
+	if req.Body == nil {
+		return &GenerateWodBatch400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, locale(ctx, "")),
+		}, nil
+	}
+
+	var requested string
+	if req.Body.Locale != nil {
+		requested = string(*req.Body.Locale)
+	}
+	loc := locale(ctx, requested)
+
+	ps, err := batchParams(ctx, *req.Body)
+	if err != nil {
+		return &GenerateWodBatch400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	}
+
+	items, err := server.wodGenerate.GenerateBatch(ctx, ps)
+	switch {
+	case errors.Is(err, common.ErrInvalidBatch):
+		return &GenerateWodBatch400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	case err != nil:
+		logger.Error("server.wodGenerate.GenerateBatch()", slog.Any("err", err))
+		return &GenerateWodBatch500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	expand := expandMoves(req.Body.Expand)
+	batch := WodBatch{Items: make([]WodBatchItem, len(items))}
+	for i, item := range items {
+		batch.Items[i].Index = i
+		if item.Err != nil {
+			batch.Items[i].Error = batchError(item.Err, loc)
+			batch.Failed++
+			continue
+		}
+		wod := server.toWod(ctx, item.Wod, loc, expand)
+		replayed := item.Replayed
+		batch.Items[i].Wod, batch.Items[i].Replayed = &wod, &replayed
+		if replayed {
+			batch.Replayed++
+		} else {
+			batch.Generated++
+		}
+	}
+	resp := GenerateWodBatch200JSONResponse(batch)
+	return &resp, nil
+}
+
+// batchPath is false for the other POST /wod/generate<suffix> paths: gin
+// reads ":batch" as a wildcard and routes them to GenerateWodBatch too.
+func batchPath(ctx context.Context) bool {
+	c, ok := ctx.(*gin.Context)
+	if !ok || c.Request == nil {
+		return true
+	}
+	return strings.HasSuffix(c.Request.URL.Path, "/wod/generate:batch")
+}
+
+// batchParams are the generations asked by batch, for the caller.
+func batchParams(ctx context.Context, batch GenerateWodBatch) ([]core.Params, error) {
+	caller := core.Params{Tenant: pkg.Tenant(ctx), Owner: pkg.Subject(ctx)}
+	switch {
+	case batch.Items != nil && batch.Params == nil && batch.Count == nil:
+		ps := make([]core.Params, len(*batch.Items))
+		for i, item := range *batch.Items {
+			ps[i] = batchItemParams(caller, item)
+		}
+		return ps, nil
+	case batch.Items == nil && batch.Params != nil && batch.Count != nil:
+		if *batch.Count < 1 || *batch.Count > core.MaxBatch {
+			return nil, fmt.Errorf("%w: count is 1 to %d", common.ErrInvalidBatch, core.MaxBatch)
+		}
+		return core.DeriveSeeds(batchItemParams(caller, *batch.Params), *batch.Count), nil
+	default:
+		return nil, fmt.Errorf("%w: send either items, or params and count", common.ErrInvalidBatch)
+	}
+}
+
+func batchItemParams(caller core.Params, item BatchWodParams) core.Params {
+	p := caller
+	p.Catalog = catalogName(item.Catalog)
+	p.Level = string(item.Level)
+	p.DurationMin = item.DurationMin
+	if item.Equipment != nil {
+		p.Equipment = *item.Equipment
+	}
+	if item.Seed != nil {
+		p.Seed = *item.Seed
+	}
+	return p
+}
+
+// batchError reports the failed generation of an item, with the status
+// POST /wod/generate answers it with.
+func batchError(err error, loc string) *ErrorResponse {
+	status := generateStatus(err)
+	if status == http.StatusInternalServerError {
+		logger.Error("server.wodGenerate.GenerateBatch()", slog.Any("err", err))
+		err = common.ErrInternal
+	}
+	return &ErrorResponse{Code: status, Message: common.Translate(err, loc)}
+}
@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWodBatch_Items(t *testing.T) {
+	first := models.Wod{ID: uuid.New(), Level: "beginner", DurationMin: 30, OwnerSub: "alice"}
+	stored := models.Wod{ID: uuid.New(), Level: "beginner", DurationMin: 45, OwnerSub: "alice"}
+	gen := &mockWodGenerator{items: []core.BatchItem{
+		{Wod: first},
+		{Err: common.InvalidDataError{DataType: "level", Data: "expert"}},
+		{Wod: stored, Replayed: true},
+		{Err: errors.New("catalog gone")},
+	}}
+	s := newTestServer(handlers.Services{WodGenerate: gen})
+
+	seed, catalog := "s1", "hyrox"
+	resp, err := s.GenerateWodBatch(ctxWithSubject("alice", ""), handlers.GenerateWodBatchRequestObject{
+		Body: &handlers.GenerateWodBatch{Items: &[]handlers.BatchWodParams{
+			{Level: "beginner", DurationMin: 30, Seed: &seed, Catalog: &catalog},
+			{Level: "expert", DurationMin: 30},
+			{Level: "beginner", DurationMin: 45},
+			{Level: "beginner", DurationMin: 60},
+		}},
+	})
+
+	require.NoError(t, err)
+	r := resp.(*handlers.GenerateWodBatch200JSONResponse)
+	require.Equal(t, 1, r.Generated)
+	require.Equal(t, 1, r.Replayed)
+	require.Equal(t, 2, r.Failed)
+	require.Equal(t, first.ID, r.Items[0].Wod.Id)
+	require.False(t, *r.Items[0].Replayed)
+	require.Equal(t, &handlers.ErrorResponse{Code: http.StatusBadRequest, Message: "invalid level: expert, choose between [beginner, intermediate, advanced]"},
+		r.Items[1].Error)
+	require.Equal(t, 2, r.Items[2].Index)
+	require.True(t, *r.Items[2].Replayed)
+	require.Equal(t, &handlers.ErrorResponse{Code: http.StatusInternalServerError, Message: "internal server error"},
+		r.Items[3].Error, "internal errors are not leaked")
+	require.Equal(t, core.Params{Owner: "alice", Catalog: "hyrox", Level: "beginner", DurationMin: 30, Seed: "s1"}, gen.batch[0])
+}
+
+func TestGenerateWodBatch_Count(t *testing.T) {
+	gen := &mockWodGenerator{items: []core.BatchItem{{}, {}, {}}}
+	s := newTestServer(handlers.Services{WodGenerate: gen})
+
+	seed, count := "week", 3
+	resp, err := s.GenerateWodBatch(ctxWithSubject("alice", ""), handlers.GenerateWodBatchRequestObject{
+		Body: &handlers.GenerateWodBatch{
+			Params: &handlers.BatchWodParams{Level: "beginner", DurationMin: 30, Seed: &seed},
+			Count:  &count,
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.(*handlers.GenerateWodBatch200JSONResponse).Items, 3)
+	require.Len(t, gen.batch, 3)
+	require.Equal(t, "week-3", gen.batch[2].Seed)
+	require.Equal(t, "alice", gen.batch[2].Owner)
+}
+
+func TestGenerateWodBatch_Invalid(t *testing.T) {
+	params := &handlers.BatchWodParams{Level: "beginner", DurationMin: 30}
+	items := &[]handlers.BatchWodParams{*params}
+	zero, many := 0, core.MaxBatch+1
+	for name, body := range map[string]*handlers.GenerateWodBatch{
+		"no body":        nil,
+		"empty":          {},
+		"no count":       {Params: params},
+		"items and more": {Items: items, Params: params, Count: &many},
+		"zero count":     {Params: params, Count: &zero},
+		"too many":       {Params: params, Count: &many},
+	} {
+		gen := &mockWodGenerator{}
+		s := newTestServer(handlers.Services{WodGenerate: gen})
+
+		resp, err := s.GenerateWodBatch(context.Background(), handlers.GenerateWodBatchRequestObject{Body: body})
+
+		require.NoError(t, err, name)
+		require.IsType(t, &handlers.GenerateWodBatch400JSONResponse{}, resp, name)
+		require.Nil(t, gen.batch, name)
+	}
+}
+
+func TestGenerateWodBatch_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"batch": {common.ErrInvalidBatch, &handlers.GenerateWodBatch400JSONResponse{}},
+		"save":  {errors.New("db down"), &handlers.GenerateWodBatch500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: tc.err}})
+		items := []handlers.BatchWodParams{{Level: "beginner", DurationMin: 30}}
+
+		resp, err := s.GenerateWodBatch(context.Background(), handlers.GenerateWodBatchRequestObject{
+			Body: &handlers.GenerateWodBatch{Items: &items},
+		})
+
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
+
+func TestGenerateWodBatch_UnknownPath(t *testing.T) {
+	gen := &mockWodGenerator{}
+	s := newTestServer(handlers.Services{WodGenerate: gen})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/wod/generatefoo", nil)
+	items := []handlers.BatchWodParams{{Level: "beginner", DurationMin: 30}}
+	resp, err := s.GenerateWodBatch(c, handlers.GenerateWodBatchRequestObject{
+		Body: &handlers.GenerateWodBatch{Items: &items},
+	})
+
+	require.NoError(t, err)
+	require.IsType(t, &handlers.GenerateWodBatch404JSONResponse{}, resp)
+	require.Nil(t, gen.batch)
+}
@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (server *Server) ListCalendar(ctx context.Context, req ListCalendarRequestObject) (ListCalendarResponseObject, error) {
+	loc := locale(ctx, "")
+	f := repository.CalendarFilter{Subject: pkg.Subject(ctx)}
+	if req.Params.From != nil {
+		f.From = req.Params.From.Time
+	}
+	if req.Params.To != nil {
+		f.To = req.Params.To.Time
+	}
+	if req.Params.Program != nil {
+		f.Program = *req.Params.Program
+	}
+
+	limit, offset, err := resultPage(req.Params.Limit, req.Params.Offset)
+	if err != nil {
+		return &ListCalendar400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	}
+	entries, err := server.calendar.List(ctx, f, limit, offset)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidCalendarEntry) {
+			return &ListCalendar400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		}
+		logger.Error("server.calendar.List()", slog.Any("err", err))
+		return &ListCalendar500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	page := toCalendarEntries(entries)
+	return &ListCalendar200JSONResponse{Entries: &page}, nil
+}
+
+func (server *Server) ScheduleWod(ctx context.Context, req ScheduleWodRequestObject) (ScheduleWodResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &ScheduleWod400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+
+	e := models.CalendarEntry{Subject: pkg.Subject(ctx), WodID: req.Body.WodId, Date: req.Body.Date.Time}
+	if req.Body.Program != nil {
+		e.Program = *req.Body.Program
+	}
+	if req.Body.Note != nil {
+		e.Note = *req.Body.Note
+	}
+
+	// admins schedule any wod, the others their own only.
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	saved, err := server.calendar.Schedule(ctx, e, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidCalendarEntry):
+			return &ScheduleWod400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrWodNotFound):
+			return &ScheduleWod404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		default:
+			logger.Error("server.calendar.Schedule()", slog.Any("err", err))
+			return &ScheduleWod500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	resp := ScheduleWod201JSONResponse(toCalendarEntry(saved))
+	return &resp, nil
+}
+
+func (server *Server) ScheduleProgram(ctx context.Context, req ScheduleProgramRequestObject) (ScheduleProgramResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &ScheduleProgram400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+
+	p := core.Program{Subject: pkg.Subject(ctx), Name: req.Body.Name, Start: req.Body.Start.Time, WodIDs: req.Body.WodIds}
+	if req.Body.EveryDays != nil {
+		p.EveryDays = *req.Body.EveryDays
+	}
+	if req.Body.Note != nil {
+		p.Note = *req.Body.Note
+	}
+
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	entries, err := server.calendar.ScheduleProgram(ctx, p, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidCalendarEntry):
+			return &ScheduleProgram400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrWodNotFound):
+			return &ScheduleProgram404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(err, loc),
+			}, nil
+		default:
+			logger.Error("server.calendar.ScheduleProgram()", slog.Any("err", err))
+			return &ScheduleProgram500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	page := toCalendarEntries(entries)
+	return &ScheduleProgram201JSONResponse{Entries: &page}, nil
+}
+
+func (server *Server) UnscheduleProgram(ctx context.Context, req UnscheduleProgramRequestObject) (UnscheduleProgramResponseObject, error) {
+	loc := locale(ctx, "")
+	if _, err := server.calendar.DeleteProgram(ctx, pkg.Subject(ctx), req.Program); err != nil {
+		if errors.Is(err, common.ErrCalendarEntryNotFound) {
+			return &UnscheduleProgram404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrCalendarEntryNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.calendar.DeleteProgram()", slog.Any("err", err))
+		return &UnscheduleProgram500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+	return &UnscheduleProgram204Response{}, nil
+}
+
+func (server *Server) GetCalendarEntry(ctx context.Context, req GetCalendarEntryRequestObject) (GetCalendarEntryResponseObject, error) {
+	loc := locale(ctx, "")
+	e, err := server.calendar.Get(ctx, pkg.Subject(ctx), req.Id)
+	if err != nil {
+		if errors.Is(err, common.ErrCalendarEntryNotFound) {
+			return &GetCalendarEntry404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrCalendarEntryNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.calendar.Get()", slog.Any("err", err))
+		return &GetCalendarEntry500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	resp := GetCalendarEntry200JSONResponse(toCalendarEntry(e))
+	return &resp, nil
+}
+
+func (server *Server) UpdateCalendarEntry(ctx context.Context, req UpdateCalendarEntryRequestObject) (UpdateCalendarEntryResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &UpdateCalendarEntry400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+
+	patch := core.EntryPatch{WodID: req.Body.WodId, Program: req.Body.Program, Note: req.Body.Note}
+	if req.Body.Date != nil {
+		patch.Date = &req.Body.Date.Time
+	}
+
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	e, err := server.calendar.Update(ctx, pkg.Subject(ctx), req.Id, patch, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidCalendarEntry):
+			return &UpdateCalendarEntry400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrCalendarEntryNotFound), errors.Is(err, common.ErrWodNotFound):
+			return &UpdateCalendarEntry404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(err, loc),
+			}, nil
+		default:
+			logger.Error("server.calendar.Update()", slog.Any("err", err))
+			return &UpdateCalendarEntry500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	resp := UpdateCalendarEntry200JSONResponse(toCalendarEntry(e))
+	return &resp, nil
+}
+
+func (server *Server) DeleteCalendarEntry(ctx context.Context, req DeleteCalendarEntryRequestObject) (DeleteCalendarEntryResponseObject, error) {
+	loc := locale(ctx, "")
+	if err := server.calendar.Delete(ctx, pkg.Subject(ctx), req.Id); err != nil {
+		if errors.Is(err, common.ErrCalendarEntryNotFound) {
+			return &DeleteCalendarEntry404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrCalendarEntryNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.calendar.Delete()", slog.Any("err", err))
+		return &DeleteCalendarEntry500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+	return &DeleteCalendarEntry204Response{}, nil
+}
+
+func (server *Server) RotateCalendarFeed(ctx context.Context, req RotateCalendarFeedRequestObject) (RotateCalendarFeedResponseObject, error) {
+	token, err := server.calendar.RotateFeed(ctx, pkg.Subject(ctx))
+	if err != nil {
+		logger.Error("server.calendar.RotateFeed()", slog.Any("err", err))
+		return &RotateCalendarFeed500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, locale(ctx, "")),
+		}, nil
+	}
+	return &RotateCalendarFeed201JSONResponse{Url: feedURL(ctx, token)}, nil
+}
+
+func (server *Server) RevokeCalendarFeed(ctx context.Context, req RevokeCalendarFeedRequestObject) (RevokeCalendarFeedResponseObject, error) {
+	if err := server.calendar.RevokeFeed(ctx, pkg.Subject(ctx)); err != nil {
+		logger.Error("server.calendar.RevokeFeed()", slog.Any("err", err))
+		return &RevokeCalendarFeed500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, locale(ctx, "")),
+		}, nil
+	}
+	return &RevokeCalendarFeed204Response{}, nil
+}
+
+func (server *Server) GetCalendarFeed(ctx context.Context, req GetCalendarFeedRequestObject) (GetCalendarFeedResponseObject, error) {
+	loc := locale(ctx, "")
+	feed, err := server.calendar.Feed(ctx, req.Params.Token)
+	if err != nil {
+		if errors.Is(err, common.ErrCalendarFeedNotFound) {
+			return &GetCalendarFeed404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrCalendarFeedNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.calendar.Feed()", slog.Any("err", err))
+		return &GetCalendarFeed500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	body := server.toICal(ctx, feed, loc)
+	return &GetCalendarFeed200TextcalendarResponse{Body: bytes.NewReader(body), ContentLength: int64(len(body))}, nil
+}
+
+// feedURL is the URL of the feed read with token, next to the route of the
+// request when ctx is a gin context.
+func feedURL(ctx context.Context, token string) string {
+	u := url.URL{Path: "/api/v1/calendar/feed.ics", RawQuery: url.Values{"token": {token}}.Encode()}
+	c, ok := ctx.(*gin.Context)
+	if !ok || c.Request == nil {
+		return u.String()
+	}
+	u.Path = c.Request.URL.Path + ".ics"
+	u.Host = c.Request.Host
+	u.Scheme = "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+func toCalendarEntries(entries []models.CalendarEntry) []CalendarEntry {
+	out := make([]CalendarEntry, len(entries))
+	for i, e := range entries {
+		out[i] = toCalendarEntry(e)
+	}
+	return out
+}
+
+func toCalendarEntry(e models.CalendarEntry) CalendarEntry {
+	return CalendarEntry{
+		Id:        e.ID,
+		WodId:     e.WodID,
+		Date:      openapi_types.Date{Time: e.Date},
+		Program:   e.Program,
+		Note:      e.Note,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
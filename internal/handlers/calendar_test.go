@@ -0,0 +1,279 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCalendar struct {
+	entry   models.CalendarEntry
+	program core.Program
+	patch   core.EntryPatch
+	filter  repository.CalendarFilter
+	wods    repository.WodFilter
+	feed    core.Feed
+	token   string
+	err     error
+}
+
+func (m *mockCalendar) Schedule(ctx context.Context, e models.CalendarEntry, f repository.WodFilter) (models.CalendarEntry, error) {
+	m.entry, m.wods = e, f
+	return e, m.err
+}
+
+func (m *mockCalendar) ScheduleProgram(ctx context.Context, p core.Program, f repository.WodFilter) ([]models.CalendarEntry, error) {
+	m.program, m.wods = p, f
+	return []models.CalendarEntry{m.entry}, m.err
+}
+
+func (m *mockCalendar) Get(ctx context.Context, subject string, id uuid.UUID) (models.CalendarEntry, error) {
+	m.filter = repository.CalendarFilter{Subject: subject, ID: id}
+	return m.entry, m.err
+}
+
+func (m *mockCalendar) List(ctx context.Context, f repository.CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	m.filter = f
+	return []models.CalendarEntry{m.entry}, m.err
+}
+
+func (m *mockCalendar) Update(ctx context.Context, subject string, id uuid.UUID, patch core.EntryPatch, f repository.WodFilter) (models.CalendarEntry, error) {
+	m.patch, m.wods = patch, f
+	return m.entry, m.err
+}
+
+func (m *mockCalendar) Delete(ctx context.Context, subject string, id uuid.UUID) error {
+	m.filter = repository.CalendarFilter{Subject: subject, ID: id}
+	return m.err
+}
+
+func (m *mockCalendar) DeleteProgram(ctx context.Context, subject, program string) (int, error) {
+	m.filter = repository.CalendarFilter{Subject: subject, Program: program}
+	return 1, m.err
+}
+
+func (m *mockCalendar) RotateFeed(ctx context.Context, subject string) (string, error) {
+	return m.token, m.err
+}
+
+func (m *mockCalendar) RevokeFeed(ctx context.Context, subject string) error {
+	return m.err
+}
+
+func (m *mockCalendar) Feed(ctx context.Context, token string) (core.Feed, error) {
+	m.token = token
+	return m.feed, m.err
+}
+
+func TestScheduleWod_Success(t *testing.T) {
+	calendar := &mockCalendar{}
+	s := newTestServer(handlers.Services{Calendar: calendar})
+
+	wodID, note := uuid.New(), "easy"
+	date := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := s.ScheduleWod(ctxWithSubject("alice", ""), handlers.ScheduleWodRequestObject{Body: &handlers.CalendarEntryInput{
+		WodId: wodID, Date: openapi_types.Date{Time: date}, Note: &note,
+	}})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ScheduleWod201JSONResponse)
+	require.Equal(t, wodID, r.WodId)
+	require.Equal(t, date, r.Date.Time)
+	require.Equal(t, "easy", r.Note)
+	require.Equal(t, "alice", calendar.entry.Subject)
+	require.Equal(t, repository.WodFilter{Owner: "alice"}, calendar.wods)
+}
+
+func TestScheduleWod_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid":   {fmt.Errorf("%w: date is required", common.ErrInvalidCalendarEntry), &handlers.ScheduleWod400JSONResponse{}},
+		"not found": {common.ErrWodNotFound, &handlers.ScheduleWod404JSONResponse{}},
+		"repo":      {errors.New("db fail"), &handlers.ScheduleWod500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{Calendar: &mockCalendar{err: tc.err}})
+		resp, err := s.ScheduleWod(context.Background(), handlers.ScheduleWodRequestObject{Body: &handlers.CalendarEntryInput{}})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+
+	s := newTestServer(handlers.Services{Calendar: &mockCalendar{}})
+	resp, err := s.ScheduleWod(context.Background(), handlers.ScheduleWodRequestObject{})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ScheduleWod400JSONResponse{}, resp, "missing body")
+}
+
+func TestScheduleProgram(t *testing.T) {
+	calendar := &mockCalendar{}
+	s := newTestServer(handlers.Services{Calendar: calendar})
+
+	ids, every := []uuid.UUID{uuid.New(), uuid.New()}, 2
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := s.ScheduleProgram(ctxWithSubject("root", "admin"), handlers.ScheduleProgramRequestObject{Body: &handlers.ProgramInput{
+		Name: "block-1", Start: openapi_types.Date{Time: start}, EveryDays: &every, WodIds: ids,
+	}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ScheduleProgram201JSONResponse{}, resp)
+	require.Equal(t, core.Program{Subject: "root", Name: "block-1", Start: start, EveryDays: 2, WodIDs: ids}, calendar.program)
+	require.True(t, calendar.wods.AnyOwner, "admins schedule any wod")
+
+	missing := uuid.New()
+	calendar.err = fmt.Errorf("%w: %s", common.ErrWodNotFound, missing)
+	resp, err = s.ScheduleProgram(ctxWithLocale("fr"), handlers.ScheduleProgramRequestObject{Body: &handlers.ProgramInput{}})
+	require.NoError(t, err)
+	require.Equal(t, "WOD introuvable: "+missing.String(), resp.(*handlers.ScheduleProgram404JSONResponse).Message)
+}
+
+func TestListCalendar(t *testing.T) {
+	calendar := &mockCalendar{entry: models.CalendarEntry{ID: uuid.New(), Program: "block-1"}}
+	s := newTestServer(handlers.Services{Calendar: calendar})
+
+	from, program := openapi_types.Date{Time: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}, "block-1"
+	resp, err := s.ListCalendar(ctxWithSubject("alice", ""), handlers.ListCalendarRequestObject{Params: handlers.ListCalendarParams{
+		From: &from, Program: &program,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, repository.CalendarFilter{Subject: "alice", From: from.Time, Program: "block-1"}, calendar.filter)
+
+	r := resp.(*handlers.ListCalendar200JSONResponse)
+	require.Equal(t, "block-1", (*r.Entries)[0].Program)
+
+	calendar.err = fmt.Errorf("%w: from must be before to", common.ErrInvalidCalendarEntry)
+	resp, err = s.ListCalendar(context.Background(), handlers.ListCalendarRequestObject{})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListCalendar400JSONResponse{}, resp)
+
+	negative := -1
+	resp, err = s.ListCalendar(context.Background(), handlers.ListCalendarRequestObject{Params: handlers.ListCalendarParams{
+		Offset: &negative,
+	}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListCalendar400JSONResponse{}, resp, "negative offset")
+}
+
+func TestCalendarEntry_NotFound(t *testing.T) {
+	calendar := &mockCalendar{err: common.ErrCalendarEntryNotFound}
+	s := newTestServer(handlers.Services{Calendar: calendar})
+	id := uuid.New()
+
+	get, err := s.GetCalendarEntry(ctxWithSubject("alice", ""), handlers.GetCalendarEntryRequestObject{Id: id})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.GetCalendarEntry404JSONResponse{}, get)
+	require.Equal(t, repository.CalendarFilter{Subject: "alice", ID: id}, calendar.filter)
+
+	update, err := s.UpdateCalendarEntry(ctxWithSubject("alice", ""), handlers.UpdateCalendarEntryRequestObject{Id: id,
+		Body: &handlers.CalendarEntryPatch{}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.UpdateCalendarEntry404JSONResponse{}, update)
+
+	del, err := s.DeleteCalendarEntry(ctxWithSubject("alice", ""), handlers.DeleteCalendarEntryRequestObject{Id: id})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.DeleteCalendarEntry404JSONResponse{}, del)
+
+	unschedule, err := s.UnscheduleProgram(ctxWithSubject("alice", ""), handlers.UnscheduleProgramRequestObject{Program: "block-1"})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.UnscheduleProgram404JSONResponse{}, unschedule)
+}
+
+func TestUpdateCalendarEntry(t *testing.T) {
+	calendar := &mockCalendar{entry: models.CalendarEntry{ID: uuid.New()}}
+	s := newTestServer(handlers.Services{Calendar: calendar})
+
+	date, note := openapi_types.Date{Time: time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC)}, ""
+	resp, err := s.UpdateCalendarEntry(ctxWithSubject("alice", ""), handlers.UpdateCalendarEntryRequestObject{
+		Id: calendar.entry.ID, Body: &handlers.CalendarEntryPatch{Date: &date, Note: &note},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.UpdateCalendarEntry200JSONResponse{}, resp)
+	require.Equal(t, date.Time, *calendar.patch.Date)
+	require.Empty(t, *calendar.patch.Note)
+	require.Nil(t, calendar.patch.WodID)
+}
+
+func TestRotateCalendarFeed(t *testing.T) {
+	s := newTestServer(handlers.Services{Calendar: &mockCalendar{token: "s3cr3t"}})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "https://wod.example.com/api/v1/calendar/feed", nil)
+	c.Set(pkg.CtxSubject, "alice")
+	resp, err := s.RotateCalendarFeed(c, handlers.RotateCalendarFeedRequestObject{})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.RotateCalendarFeed201JSONResponse)
+	require.Equal(t, "https://wod.example.com/api/v1/calendar/feed.ics?token=s3cr3t", r.Url)
+}
+
+func TestGetCalendarFeed(t *testing.T) {
+	c := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{ID: "row", Name: "Row", Units: map[string]string{"meters": "m"}, Locales: map[string]catalog.Translation{"fr": {Name: "Rameur"}}},
+	}}
+	wod := models.Wod{ID: uuid.New(), Level: "beginner", DurationMin: 30, Catalog: "hyrox", Blocks: []models.Block{
+		{ID: "row", Name: "Row", Params: map[string]any{"meters": 500}},
+		{Name: "Burpees", Params: map[string]any{"reps": 10}},
+	}}
+	updated := time.Date(2025, 8, 30, 7, 15, 0, 0, time.UTC)
+	entry := models.CalendarEntry{ID: uuid.New(), WodID: wod.ID, Date: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Program: "block-1", Note: "Keep it easy, nose breathing; " + strings.Repeat("très lent ", 10), UpdatedAt: updated}
+	gone := models.CalendarEntry{ID: uuid.New(), WodID: uuid.New(), Date: entry.Date}
+	calendar := &mockCalendar{feed: core.Feed{Subject: "alice", Entries: []models.CalendarEntry{entry, gone},
+		Wods: map[uuid.UUID]models.Wod{wod.ID: wod}}}
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: c}, Calendar: calendar})
+
+	resp, err := s.GetCalendarFeed(ctxWithLocale("fr"), handlers.GetCalendarFeedRequestObject{Params: handlers.GetCalendarFeedParams{Token: "s3cr3t"}})
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", calendar.token)
+
+	r := resp.(*handlers.GetCalendarFeed200TextcalendarResponse)
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(body)), r.ContentLength)
+
+	lines := strings.Split(strings.TrimSuffix(string(body), "\r\n"), "\r\n")
+	for _, l := range lines {
+		require.LessOrEqual(t, len(l), 75, "folded: %q", l)
+	}
+	ical := strings.ReplaceAll(string(body), "\r\n ", "")
+	require.Contains(t, ical, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+	require.Contains(t, ical, "UID:"+entry.ID.String()+"@wod-gen\r\n")
+	require.Contains(t, ical, "DTSTAMP:20250830T071500Z\r\n")
+	require.Contains(t, ical, "DTSTART;VALUE=DATE:20250901\r\nDTEND;VALUE=DATE:20250902\r\n")
+	require.Contains(t, ical, "SUMMARY:block-1: WOD 30 min\\, beginner\r\n")
+	require.Contains(t, ical, "DESCRIPTION:1. Rameur: 500 m\\n2. Burpees: 10 reps\\n\\nKeep it easy\\, nose breathing\\; très lent")
+	require.Contains(t, ical, "CATEGORIES:block-1\r\n")
+	require.Equal(t, 1, strings.Count(ical, "BEGIN:VEVENT"), "entries whose wod is gone are left out")
+	require.True(t, strings.HasSuffix(ical, "END:VCALENDAR\r\n"))
+}
+
+func TestGetCalendarFeed_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"unknown token": {fmt.Errorf("calendarRepository.FeedSubject(): %w", common.ErrCalendarFeedNotFound), &handlers.GetCalendarFeed404JSONResponse{}},
+		"repo":          {errors.New("db fail"), &handlers.GetCalendarFeed500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{Calendar: &mockCalendar{err: tc.err}})
+		resp, err := s.GetCalendarFeed(context.Background(), handlers.GetCalendarFeedRequestObject{})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
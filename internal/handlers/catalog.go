@@ -0,0 +1,468 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+)
+
+func (server *Server) GetCatalog(ctx context.Context, req GetCatalogRequestObject) (GetCatalogResponseObject, error) {
+	loc := locale(ctx, "")
+	f := core.CatalogFilter{Catalog: catalogName(req.Params.Catalog), Tenant: pkg.Tenant(ctx)}
+	if req.Params.Tag != nil {
+		f.Tag = *req.Params.Tag
+	}
+	if req.Params.Equipment != nil {
+		f.Equipment = *req.Params.Equipment
+	}
+	if req.Params.Level != nil {
+		f.Level = string(*req.Params.Level)
+	}
+
+	c, moves, err := server.catalog.Find(ctx, f)
+	if err != nil {
+		code, msg := catalogError(err, loc)
+		switch code {
+		case http.StatusNotFound:
+			return &GetCatalog404JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusInternalServerError:
+			logger.Error("server.catalog.Find()", slog.Any("err", err))
+			return &GetCatalog500JSONResponse{Code: code, Message: msg}, nil
+		default:
+			return &GetCatalog400JSONResponse{Code: code, Message: msg}, nil
+		}
+	}
+
+	hash := c.Hash()
+	etag := catalogETag(hash, loc)
+	if etagMatches(req.Params.IfNoneMatch, etag) {
+		return &GetCatalog304Response{Headers: GetCatalog304ResponseHeaders{ETag: etag, Vary: varyCatalog}}, nil
+	}
+
+	resp := GetCatalog200JSONResponse{
+		Headers: GetCatalog200ResponseHeaders{ETag: etag, Vary: varyCatalog},
+		Body: Catalog{
+			Name:      c.Name,
+			Catalogs:  server.catalog.Names(ctx),
+			Version:   c.Version,
+			Hash:      hash,
+			Levels:    c.LevelNames(),
+			Tags:      c.Tags(),
+			Equipment: c.AllEquipment(),
+			Moves:     make([]CatalogMove, len(moves)),
+		},
+	}
+	for i, m := range moves {
+		resp.Body.Moves[i] = toCatalogMove(m, loc)
+	}
+
+	return &resp, nil
+}
+
+func (server *Server) GetCatalogMove(ctx context.Context, req GetCatalogMoveRequestObject) (GetCatalogMoveResponseObject, error) {
+	loc := locale(ctx, "")
+	c, m, err := server.catalog.Move(ctx, catalogName(req.Params.Catalog), pkg.Tenant(ctx), req.Name)
+	if err != nil {
+		code, msg := catalogError(err, loc)
+		if code == http.StatusNotFound {
+			return &GetCatalogMove404JSONResponse{Code: code, Message: msg}, nil
+		}
+		logger.Error("server.catalog.Move()", slog.Any("err", err))
+		return &GetCatalogMove500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	etag := catalogETag(c.Hash(), loc)
+	if etagMatches(req.Params.IfNoneMatch, etag) {
+		return &GetCatalogMove304Response{Headers: GetCatalogMove304ResponseHeaders{ETag: etag, Vary: varyCatalog}}, nil
+	}
+
+	return &GetCatalogMove200JSONResponse{
+		Headers: GetCatalogMove200ResponseHeaders{ETag: etag, Vary: varyCatalog},
+		Body:    toCatalogMove(m, loc),
+	}, nil
+}
+
+func (server *Server) ListCatalogMoves(ctx context.Context, req ListCatalogMovesRequestObject) (ListCatalogMovesResponseObject, error) {
+	loc := locale(ctx, "")
+	if !pkg.IsAdmin(ctx) {
+		return &ListCatalogMoves403JSONResponse{Code: http.StatusForbidden, Message: common.Translate(common.ErrAdminOnly, loc)}, nil
+	}
+
+	c, err := server.catalog.Snapshot(ctx, catalogName(req.Params.Catalog))
+	if err != nil {
+		_, msg := catalogError(err, loc)
+		return &ListCatalogMoves404JSONResponse{Code: http.StatusNotFound, Message: msg}, nil
+	}
+	moves := make([]CatalogMove, len(c.Moves))
+	for i, m := range c.Moves {
+		moves[i] = toCatalogMove(m, loc)
+	}
+
+	return &ListCatalogMoves200JSONResponse{Name: c.Name, Version: c.Version, Moves: moves}, nil
+}
+
+func (server *Server) CreateCatalogMove(ctx context.Context, req CreateCatalogMoveRequestObject) (CreateCatalogMoveResponseObject, error) {
+	loc := locale(ctx, "")
+	if !pkg.IsAdmin(ctx) {
+		return &CreateCatalogMove403JSONResponse{Code: http.StatusForbidden, Message: common.Translate(common.ErrAdminOnly, loc)}, nil
+	}
+	if req.Body == nil {
+		return &CreateCatalogMove400JSONResponse{Code: http.StatusBadRequest, Message: common.Translate(common.ErrMissingBody, loc)}, nil
+	}
+
+	m, err := fromCatalogMove(*req.Body)
+	if err == nil {
+		m, err = server.catalog.CreateMove(ctx, catalogName(req.Params.Catalog), m)
+	}
+	if err != nil {
+		logger.Error("server.catalog.CreateMove()", slog.Any("err", err))
+
+		code, msg := catalogError(err, loc)
+		switch code {
+		case http.StatusBadRequest:
+			return &CreateCatalogMove400JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusNotFound:
+			return &CreateCatalogMove404JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusConflict:
+			return &CreateCatalogMove409JSONResponse{Code: code, Message: msg}, nil
+		default:
+			return &CreateCatalogMove500JSONResponse{Code: code, Message: msg}, nil
+		}
+	}
+
+	resp := CreateCatalogMove201JSONResponse(toCatalogMove(m, loc))
+	return &resp, nil
+}
+
+func (server *Server) UpdateCatalogMove(ctx context.Context, req UpdateCatalogMoveRequestObject) (UpdateCatalogMoveResponseObject, error) {
+	loc := locale(ctx, "")
+	if !pkg.IsAdmin(ctx) {
+		return &UpdateCatalogMove403JSONResponse{Code: http.StatusForbidden, Message: common.Translate(common.ErrAdminOnly, loc)}, nil
+	}
+	if req.Body == nil {
+		return &UpdateCatalogMove400JSONResponse{Code: http.StatusBadRequest, Message: common.Translate(common.ErrMissingBody, loc)}, nil
+	}
+
+	m, err := fromCatalogMove(*req.Body)
+	if err == nil {
+		m, err = server.catalog.UpdateMove(ctx, catalogName(req.Params.Catalog), req.Name, m)
+	}
+	if err != nil {
+		logger.Error("server.catalog.UpdateMove()", slog.Any("err", err))
+
+		code, msg := catalogError(err, loc)
+		switch code {
+		case http.StatusBadRequest:
+			return &UpdateCatalogMove400JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusNotFound:
+			return &UpdateCatalogMove404JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusConflict:
+			return &UpdateCatalogMove409JSONResponse{Code: code, Message: msg}, nil
+		default:
+			return &UpdateCatalogMove500JSONResponse{Code: code, Message: msg}, nil
+		}
+	}
+
+	resp := UpdateCatalogMove200JSONResponse(toCatalogMove(m, loc))
+	return &resp, nil
+}
+
+func (server *Server) DeleteCatalogMove(ctx context.Context, req DeleteCatalogMoveRequestObject) (DeleteCatalogMoveResponseObject, error) {
+	loc := locale(ctx, "")
+	if !pkg.IsAdmin(ctx) {
+		return &DeleteCatalogMove403JSONResponse{Code: http.StatusForbidden, Message: common.Translate(common.ErrAdminOnly, loc)}, nil
+	}
+
+	if err := server.catalog.DeleteMove(ctx, catalogName(req.Params.Catalog), req.Name); err != nil {
+		logger.Error("server.catalog.DeleteMove()", slog.Any("err", err))
+
+		code, msg := catalogError(err, loc)
+		switch code {
+		case http.StatusBadRequest:
+			return &DeleteCatalogMove400JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusNotFound:
+			return &DeleteCatalogMove404JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusConflict:
+			return &DeleteCatalogMove409JSONResponse{Code: code, Message: msg}, nil
+		default:
+			return &DeleteCatalogMove500JSONResponse{Code: code, Message: msg}, nil
+		}
+	}
+
+	return &DeleteCatalogMove204Response{}, nil
+}
+
+func (server *Server) ImportCatalog(ctx context.Context, req ImportCatalogRequestObject) (ImportCatalogResponseObject, error) {
+	loc := locale(ctx, "")
+	if !pkg.IsAdmin(ctx) {
+		return &ImportCatalog403JSONResponse{Code: http.StatusForbidden, Message: common.Translate(common.ErrAdminOnly, loc)}, nil
+	}
+	if req.Body == nil {
+		return &ImportCatalog400JSONResponse{Code: http.StatusBadRequest, Message: common.Translate(common.ErrMissingBody, loc)}, nil
+	}
+	dryRun := req.Body.DryRun != nil && *req.Body.DryRun
+
+	imported, err := catalog.Import(strings.NewReader(req.Body.Content), string(req.Body.Format))
+	var diff catalog.Diff
+	if err == nil {
+		imported, diff, err = server.catalog.Import(ctx, catalogName(req.Params.Catalog), imported, dryRun)
+	}
+	var out []byte
+	if err == nil && req.Body.Yaml != nil && *req.Body.Yaml {
+		out, err = imported.YAML()
+	}
+	if err != nil {
+		logger.Error("server.catalog.Import()", slog.Any("err", err))
+
+		code, msg := catalogError(err, loc)
+		switch code {
+		case http.StatusBadRequest:
+			return &ImportCatalog400JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusNotFound:
+			return &ImportCatalog404JSONResponse{Code: code, Message: msg}, nil
+		case http.StatusConflict:
+			return &ImportCatalog409JSONResponse{Code: code, Message: msg}, nil
+		default:
+			return &ImportCatalog500JSONResponse{Code: code, Message: msg}, nil
+		}
+	}
+
+	resp := ImportCatalog200JSONResponse{
+		Name:    imported.Name,
+		Version: imported.Version,
+		Hash:    imported.Hash(),
+		DryRun:  dryRun,
+		Diff: CatalogDiff{
+			Added:            diff.Added,
+			Removed:          diff.Removed,
+			Changed:          diff.Changed,
+			LevelsChanged:    diff.LevelsChanged,
+			EquipmentChanged: diff.EquipmentChanged,
+		},
+	}
+	if out != nil {
+		y := string(out)
+		resp.Yaml = &y
+	}
+	return &resp, nil
+}
+
+// catalogError maps catalog write errors to a status code and client message.
+func catalogError(err error, loc string) (int, string) {
+	var invalidDataErr common.InvalidDataError
+	switch {
+	case errors.As(err, &invalidDataErr):
+		return http.StatusBadRequest, common.Translate(invalidDataErr, loc)
+	case errors.Is(err, common.ErrInvalidCatalog),
+		errors.Is(err, common.ErrEmptyCatalog),
+		errors.Is(err, common.ErrLevelUnavailable):
+		return http.StatusBadRequest, common.Translate(err, loc)
+	case errors.Is(err, common.ErrMoveNotFound):
+		return http.StatusNotFound, common.Translate(common.ErrMoveNotFound, loc)
+	case errors.Is(err, common.ErrUnknownCatalog):
+		return http.StatusNotFound, common.Translate(err, loc)
+	case errors.Is(err, common.ErrMoveExists):
+		return http.StatusConflict, common.Translate(common.ErrMoveExists, loc)
+	case errors.Is(err, common.ErrCatalogReadOnly):
+		return http.StatusConflict, common.Translate(common.ErrCatalogReadOnly, loc)
+	default:
+		return http.StatusInternalServerError, common.Translate(common.ErrInternal, loc)
+	}
+}
+
+// catalogName unwraps the optional catalog query parameter, empty selects the
+// default catalog.
+func catalogName(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// varyCatalog is the Vary header of the catalog responses: the body is
+// localized, and the tenant of the token picks the overlay applied.
+const varyCatalog = "Accept-Language, Authorization"
+
+// catalogETag is the ETag of a catalog body: the content hash of the catalog
+// and the locale the body is rendered in.
+func catalogETag(hash, loc string) string {
+	return strconv.Quote(hash + "-" + loc)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, weak
+// validators included since the representation is byte-identical.
+func etagMatches(ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil {
+		return false
+	}
+	for _, candidate := range strings.Split(*ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func toCatalogMove(m catalog.Move, loc string) CatalogMove {
+	ranges := make(CatalogRanges, len(m.Ranges))
+	for level, params := range m.Ranges {
+		ranges[level] = make(map[string][]int, len(params))
+		for p, r := range params {
+			ranges[level][p] = []int{r[0], r[1]}
+		}
+	}
+	locales := make(map[string]MoveTranslation, len(m.Locales))
+	for l, t := range m.Locales {
+		tr := MoveTranslation{Name: optional(t.Name), Description: optional(t.Description)}
+		if len(t.Cues) > 0 {
+			cues := cloneOrEmpty(t.Cues)
+			tr.Cues = &cues
+		}
+		locales[l] = tr
+	}
+	id := m.Key()
+	description := m.Description
+	cues := cloneOrEmpty(m.Cues)
+	muscles := cloneOrEmpty(m.Muscles)
+	units := maps.Clone(m.Units)
+	if units == nil {
+		units = map[string]string{}
+	}
+	media := toMedia(m.Media)
+	displayName := m.DisplayName(loc)
+	displayDescription := m.DisplayDescription(loc)
+	displayCues := cloneOrEmpty(m.DisplayCues(loc))
+	needs := cloneOrEmpty(m.NeedsOneOf)
+	tags := cloneOrEmpty(m.Tags)
+	weight := m.Weight
+
+	out := CatalogMove{
+		Id:                 &id,
+		Name:               m.Name,
+		Description:        &description,
+		Cues:               &cues,
+		Muscles:            &muscles,
+		Units:              &units,
+		Media:              &media,
+		Locales:            &locales,
+		DisplayName:        &displayName,
+		DisplayDescription: &displayDescription,
+		DisplayCues:        &displayCues,
+		NeedsOneOf:         &needs,
+		Tags:               &tags,
+		Weight:             &weight,
+		Ranges:             &ranges,
+	}
+	if m.Pattern != "" {
+		pattern := CatalogMovePattern(m.Pattern)
+		out.Pattern = &pattern
+	}
+	return out
+}
+
+func fromCatalogMove(in CatalogMove) (catalog.Move, error) {
+	m := catalog.Move{Name: in.Name}
+	if in.Id != nil {
+		m.ID = *in.Id
+	}
+	if in.Description != nil {
+		m.Description = *in.Description
+	}
+	if in.Cues != nil {
+		m.Cues = *in.Cues
+	}
+	if in.Muscles != nil {
+		m.Muscles = *in.Muscles
+	}
+	if in.Pattern != nil {
+		m.Pattern = string(*in.Pattern)
+	}
+	if in.Units != nil {
+		m.Units = *in.Units
+	}
+	if in.Media != nil {
+		m.Media = make([]catalog.Media, len(*in.Media))
+		for i, md := range *in.Media {
+			m.Media[i] = catalog.Media{Type: string(md.Type), URL: md.Url}
+			if md.Title != nil {
+				m.Media[i].Title = *md.Title
+			}
+		}
+	}
+	if in.Locales != nil {
+		m.Locales = make(map[string]catalog.Translation, len(*in.Locales))
+		for l, t := range *in.Locales {
+			var tr catalog.Translation
+			if t.Name != nil {
+				tr.Name = *t.Name
+			}
+			if t.Description != nil {
+				tr.Description = *t.Description
+			}
+			if t.Cues != nil {
+				tr.Cues = *t.Cues
+			}
+			m.Locales[strings.ToLower(l)] = tr
+		}
+	}
+	if in.NeedsOneOf != nil {
+		m.NeedsOneOf = *in.NeedsOneOf
+	}
+	if in.Tags != nil {
+		m.Tags = *in.Tags
+	}
+	if in.Weight != nil {
+		m.Weight = *in.Weight
+	}
+	if in.Ranges != nil {
+		m.Ranges = make(map[string]map[string]catalog.Rng, len(*in.Ranges))
+		for level, params := range *in.Ranges {
+			m.Ranges[level] = make(map[string]catalog.Rng, len(params))
+			for p, r := range params {
+				if len(r) != 2 {
+					return catalog.Move{}, fmt.Errorf("%w: range %s.%s must be [min, max]", common.ErrInvalidCatalog, level, p)
+				}
+				m.Ranges[level][p] = catalog.Rng{r[0], r[1]}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// optional maps an empty string to an absent field.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func cloneOrEmpty(s []string) []string {
+	out := make([]string, len(s))
+	copy(out, s)
+	return out
+}
+
+func toMedia(media []catalog.Media) []MoveMedia {
+	out := make([]MoveMedia, len(media))
+	for i, md := range media {
+		out[i] = MoveMedia{Type: MoveMediaType(md.Type), Url: md.URL, Title: optional(md.Title)}
+	}
+	return out
+}
@@ -0,0 +1,325 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCatalogManager struct {
+	catalog *catalog.Catalog
+	err     error
+	tenant  string
+}
+
+func (m *mockCatalogManager) Names(ctx context.Context) []string {
+	return []string{"hyrox", "running"}
+}
+
+func (m *mockCatalogManager) Snapshot(ctx context.Context, catalogName string) (*catalog.Catalog, error) {
+	return m.catalog, m.err
+}
+
+func (m *mockCatalogManager) Resolve(ctx context.Context, catalogName, tenant string) (*catalog.Catalog, error) {
+	m.tenant = tenant
+	return m.catalog, m.err
+}
+
+func (m *mockCatalogManager) Find(ctx context.Context, f core.CatalogFilter) (*catalog.Catalog, []catalog.Move, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.catalog, m.catalog.Moves, nil
+}
+
+func (m *mockCatalogManager) Move(ctx context.Context, catalogName, tenant, name string) (*catalog.Catalog, catalog.Move, error) {
+	m.tenant = tenant
+	if m.err != nil {
+		return m.catalog, catalog.Move{}, m.err
+	}
+	return m.catalog, m.catalog.Moves[0], nil
+}
+
+func (m *mockCatalogManager) CreateMove(ctx context.Context, catalogName string, mv catalog.Move) (catalog.Move, error) {
+	return mv, m.err
+}
+
+func (m *mockCatalogManager) UpdateMove(ctx context.Context, catalogName, name string, mv catalog.Move) (catalog.Move, error) {
+	return mv, m.err
+}
+
+func (m *mockCatalogManager) DeleteMove(ctx context.Context, catalogName, name string) error {
+	return m.err
+}
+
+func (m *mockCatalogManager) Import(ctx context.Context, catalogName string, imported *catalog.Catalog, dryRun bool) (*catalog.Catalog, catalog.Diff, error) {
+	if m.err != nil {
+		return nil, catalog.Diff{}, m.err
+	}
+	imported.Name = "hyrox"
+	return imported, m.catalog.Diff(imported), nil
+}
+
+func ctxWithRole(role string) context.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(pkg.CtxRole, role)
+	return c
+}
+
+func ctxWithLocale(locale string) context.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(pkg.CtxLocale, locale)
+	return c
+}
+
+func ctxWithSubject(sub, role string) context.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(pkg.CtxSubject, sub)
+	c.Set(pkg.CtxRole, role)
+	return c
+}
+
+func ctxWithTenant(tenant string) context.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(pkg.CtxTenant, tenant)
+	return c
+}
+
+func testCatalog() *catalog.Catalog {
+	return &catalog.Catalog{Name: "hyrox", Version: 2, Moves: []catalog.Move{
+		{Name: "Row", NeedsOneOf: []string{"rower"}, Tags: []string{"engine"}, Weight: 1,
+			Locales: map[string]catalog.Translation{"fr": {Name: "Rameur"}}},
+		{Name: "Run", Tags: []string{"engine"}, Weight: 1},
+	}}
+}
+
+func TestGetCatalog_Success(t *testing.T) {
+	c := testCatalog()
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: c}})
+
+	resp, err := s.GetCatalog(context.Background(), handlers.GetCatalogRequestObject{})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetCatalog200JSONResponse)
+	require.Equal(t, `"`+c.Hash()+`-en"`, r.Headers.ETag)
+	require.Equal(t, "Accept-Language, Authorization", r.Headers.Vary)
+	require.Equal(t, c.Hash(), r.Body.Hash)
+	require.Equal(t, "hyrox", r.Body.Name)
+	require.Equal(t, []string{"hyrox", "running"}, r.Body.Catalogs)
+	require.Equal(t, []string{"beginner", "intermediate", "advanced"}, r.Body.Levels)
+	require.Equal(t, []string{"engine"}, r.Body.Tags)
+	require.Equal(t, []string{"rower"}, r.Body.Equipment)
+	require.Len(t, r.Body.Moves, 2)
+}
+
+func TestGetCatalog_Localized(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog()}})
+
+	resp, err := s.GetCatalog(ctxWithLocale("fr"), handlers.GetCatalogRequestObject{})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetCatalog200JSONResponse)
+	require.Equal(t, "Row", r.Body.Moves[0].Name, "the canonical name stays the identifier")
+	require.Equal(t, "row", *r.Body.Moves[0].Id)
+	require.Equal(t, "Rameur", *r.Body.Moves[0].DisplayName)
+	require.Equal(t, "Run", *r.Body.Moves[1].DisplayName, "falls back to the canonical name")
+}
+
+func TestGetCatalog_NotModified(t *testing.T) {
+	c := testCatalog()
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: c}})
+
+	etag := `W/"` + c.Hash() + `-en"`
+	resp, err := s.GetCatalog(context.Background(), handlers.GetCatalogRequestObject{
+		Params: handlers.GetCatalogParams{IfNoneMatch: &etag},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &handlers.GetCatalog304Response{Headers: handlers.GetCatalog304ResponseHeaders{
+		ETag: `"` + c.Hash() + `-en"`, Vary: "Accept-Language, Authorization",
+	}}, resp)
+
+	resp, err = s.GetCatalog(ctxWithLocale("fr"), handlers.GetCatalogRequestObject{
+		Params: handlers.GetCatalogParams{IfNoneMatch: &etag},
+	})
+	require.NoError(t, err)
+	r := resp.(*handlers.GetCatalog200JSONResponse)
+	require.Equal(t, `"`+c.Hash()+`-fr"`, r.Headers.ETag, "the English body does not serve a French request")
+}
+
+func TestGetCatalog_InternalError(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog(), err: errors.New("db fail")}})
+
+	resp, err := s.GetCatalog(context.Background(), handlers.GetCatalogRequestObject{})
+	require.NoError(t, err)
+	require.Equal(t, &handlers.GetCatalog500JSONResponse{Code: 500, Message: "internal server error"}, resp)
+}
+
+func TestGetCatalog_InvalidLevel(t *testing.T) {
+	err := common.InvalidDataError{DataType: "level", Data: "elite"}
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog(), err: err}})
+
+	resp, gotErr := s.GetCatalog(context.Background(), handlers.GetCatalogRequestObject{})
+	require.NoError(t, gotErr)
+
+	r := resp.(*handlers.GetCatalog400JSONResponse)
+	require.Equal(t, 400, r.Code)
+}
+
+func TestGetCatalog_UnknownCatalog(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{err: common.ErrUnknownCatalog}})
+
+	name := "yoga"
+	resp, err := s.GetCatalog(context.Background(), handlers.GetCatalogRequestObject{
+		Params: handlers.GetCatalogParams{Catalog: &name},
+	})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetCatalog404JSONResponse)
+	require.Equal(t, 404, r.Code)
+}
+
+func TestGetCatalogMove_NotFound(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog(), err: common.ErrMoveNotFound}})
+
+	resp, err := s.GetCatalogMove(context.Background(), handlers.GetCatalogMoveRequestObject{Name: "Nope"})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetCatalogMove404JSONResponse)
+	require.Equal(t, 404, r.Code)
+}
+
+func TestGetCatalogMove_Tenant(t *testing.T) {
+	cm := &mockCatalogManager{catalog: testCatalog()}
+	s := newTestServer(handlers.Services{Catalog: cm})
+
+	resp, err := s.GetCatalogMove(ctxWithTenant("box-lyon"), handlers.GetCatalogMoveRequestObject{Name: "Row"})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.GetCatalogMove200JSONResponse{}, resp)
+	require.Equal(t, "box-lyon", cm.tenant)
+}
+
+func TestGetCatalogMove_InternalError(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog(), err: errors.New("db fail")}})
+
+	resp, err := s.GetCatalogMove(context.Background(), handlers.GetCatalogMoveRequestObject{Name: "Row"})
+	require.NoError(t, err)
+	require.Equal(t, &handlers.GetCatalogMove500JSONResponse{Code: 500, Message: "internal server error"}, resp)
+}
+
+func TestListCatalogMoves_Forbidden(t *testing.T) {
+	s := newTestServer(handlers.Services{})
+
+	resp, err := s.ListCatalogMoves(ctxWithRole(""), handlers.ListCatalogMovesRequestObject{})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ListCatalogMoves403JSONResponse)
+	require.Equal(t, 403, r.Code)
+}
+
+func TestListCatalogMoves_Success(t *testing.T) {
+	c := &catalog.Catalog{Version: 4, Moves: []catalog.Move{{
+		Name:   "Row",
+		Weight: 1.2,
+		Ranges: map[string]map[string]catalog.Rng{"beginner": {"meters": {400, 900}}},
+	}}}
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: c}})
+
+	resp, err := s.ListCatalogMoves(ctxWithRole(pkg.RoleAdmin), handlers.ListCatalogMovesRequestObject{})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ListCatalogMoves200JSONResponse)
+	require.Equal(t, int64(4), r.Version)
+	require.Len(t, r.Moves, 1)
+	require.Equal(t, []int{400, 900}, (*r.Moves[0].Ranges)["beginner"]["meters"])
+}
+
+func TestCreateCatalogMove_BadRange(t *testing.T) {
+	s := newTestServer(handlers.Services{})
+
+	ranges := handlers.CatalogRanges{"beginner": {"reps": {10}}}
+	body := handlers.CreateCatalogMoveJSONRequestBody{Name: "Burpees", Ranges: &ranges}
+	resp, err := s.CreateCatalogMove(ctxWithRole(pkg.RoleAdmin), handlers.CreateCatalogMoveRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.CreateCatalogMove400JSONResponse)
+	require.Equal(t, 400, r.Code)
+}
+
+func TestCreateCatalogMove_Exists(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{err: common.ErrMoveExists}})
+
+	body := handlers.CreateCatalogMoveJSONRequestBody{Name: "Row"}
+	resp, err := s.CreateCatalogMove(ctxWithRole(pkg.RoleAdmin), handlers.CreateCatalogMoveRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.CreateCatalogMove409JSONResponse)
+	require.Equal(t, 409, r.Code)
+}
+
+func TestUpdateCatalogMove_NotFound(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{err: common.ErrMoveNotFound}})
+
+	body := handlers.UpdateCatalogMoveJSONRequestBody{Name: "Row"}
+	resp, err := s.UpdateCatalogMove(ctxWithRole(pkg.RoleAdmin), handlers.UpdateCatalogMoveRequestObject{Name: "Row", Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.UpdateCatalogMove404JSONResponse)
+	require.Equal(t, 404, r.Code)
+}
+
+func TestDeleteCatalogMove_Success(t *testing.T) {
+	s := newTestServer(handlers.Services{})
+
+	resp, err := s.DeleteCatalogMove(ctxWithRole(pkg.RoleAdmin), handlers.DeleteCatalogMoveRequestObject{Name: "Row"})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.DeleteCatalogMove204Response{}, resp)
+}
+
+func TestImportCatalog_DryRunWithYAML(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog()}})
+
+	yes := true
+	body := handlers.ImportCatalogJSONRequestBody{
+		Format:  handlers.CatalogImportFormatCsv,
+		Content: "name,needs_one_of,tags,beginner.meters\nRow,rower,engine,400-900\nSled Push,sled,strength,10-20\n",
+		DryRun:  &yes,
+		Yaml:    &yes,
+	}
+	resp, err := s.ImportCatalog(ctxWithRole(pkg.RoleAdmin), handlers.ImportCatalogRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ImportCatalog200JSONResponse)
+	require.True(t, r.DryRun)
+	require.Equal(t, []string{"sled-push"}, r.Diff.Added)
+	require.Equal(t, []string{"run"}, r.Diff.Removed)
+	require.Contains(t, *r.Yaml, "meters: [10, 20]")
+}
+
+func TestImportCatalog_InvalidCSV(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog()}})
+
+	body := handlers.ImportCatalogJSONRequestBody{Format: handlers.CatalogImportFormatCsv, Content: "name,colour\nRow,red\n"}
+	resp, err := s.ImportCatalog(ctxWithRole(pkg.RoleAdmin), handlers.ImportCatalogRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ImportCatalog400JSONResponse)
+	require.Contains(t, r.Message, `unknown column "colour"`)
+}
+
+func TestImportCatalog_Forbidden(t *testing.T) {
+	s := newTestServer(handlers.Services{Catalog: &mockCatalogManager{catalog: testCatalog()}})
+
+	body := handlers.ImportCatalogJSONRequestBody{Format: handlers.CatalogImportFormatJson, Content: "[]"}
+	resp, err := s.ImportCatalog(ctxWithRole("user"), handlers.ImportCatalogRequestObject{Body: &body})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ImportCatalog403JSONResponse{}, resp)
+}
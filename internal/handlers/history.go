@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) ExportWods(ctx context.Context, req ExportWodsRequestObject) (ExportWodsResponseObject, error) {
+	loc := locale(ctx, "")
+	f, ok := ownerFilter(ctx, req.Params.Owner)
+	if !ok {
+		return &ExportWods403JSONResponse{
+			Code:    http.StatusForbidden,
+			Message: common.Translate(common.ErrAdminOnly, loc),
+		}, nil
+	}
+
+	q := exportQuery((*string)(req.Params.Format), req.Params.From, req.Params.To)
+	export, err := server.history.ExportWods(requestContext(ctx), f, q)
+	switch {
+	case errors.Is(err, common.ErrExportFilter):
+		return &ExportWods400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	case err != nil:
+		logger.Error("server.history.ExportWods()", slog.Any("err", err))
+		return &ExportWods500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	body := stream(export, "server.history.ExportWods()")
+	if q.Format == core.FormatCSV {
+		return &ExportWods200TextcsvResponse{Body: body}, nil
+	}
+	return &ExportWods200ApplicationxNdjsonResponse{Body: body}, nil
+}
+
+func (server *Server) ExportResults(ctx context.Context, req ExportResultsRequestObject) (ExportResultsResponseObject, error) {
+	loc := locale(ctx, "")
+	f, ok := ownerFilter(ctx, req.Params.Owner)
+	if !ok {
+		return &ExportResults403JSONResponse{
+			Code:    http.StatusForbidden,
+			Message: common.Translate(common.ErrAdminOnly, loc),
+		}, nil
+	}
+
+	q := exportQuery((*string)(req.Params.Format), req.Params.From, req.Params.To)
+	export, err := server.history.ExportResults(requestContext(ctx), f, q)
+	switch {
+	case errors.Is(err, common.ErrExportFilter):
+		return &ExportResults400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	case err != nil:
+		logger.Error("server.history.ExportResults()", slog.Any("err", err))
+		return &ExportResults500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	body := stream(export, "server.history.ExportResults()")
+	if q.Format == core.FormatCSV {
+		return &ExportResults200TextcsvResponse{Body: body}, nil
+	}
+	return &ExportResults200ApplicationxNdjsonResponse{Body: body}, nil
+}
+
+func (server *Server) ImportWods(ctx context.Context, req ImportWodsRequestObject) (ImportWodsResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &ImportWods400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+	dryRun := req.Body.DryRun != nil && *req.Body.DryRun
+
+	report, err := server.history.ImportWods(ctx, strings.NewReader(req.Body.Content), core.ImportQuery{
+		Format:   string(req.Body.Format),
+		Owner:    pkg.Subject(ctx),
+		AnyOwner: pkg.IsAdmin(ctx),
+		DryRun:   dryRun,
+	})
+	switch {
+	case errors.Is(err, common.ErrInvalidImport):
+		return &ImportWods400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	case err != nil:
+		logger.Error("server.history.ImportWods()", slog.Any("err", err))
+		return &ImportWods500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	resp := ImportWods200JSONResponse{
+		DryRun:   dryRun,
+		Rows:     report.Rows,
+		Imported: report.Imported,
+		Failed:   report.Failed,
+		Errors:   make([]WodImportError, len(report.Errors)),
+	}
+	for i, e := range report.Errors {
+		resp.Errors[i] = WodImportError{Line: e.Line, Message: common.Translate(e.Err, loc)}
+	}
+	return &resp, nil
+}
+
+func exportQuery(format *string, from, to *time.Time) core.ExportQuery {
+	q := core.ExportQuery{Format: core.FormatJSONL}
+	if format != nil {
+		q.Format = *format
+	}
+	if from != nil {
+		q.From = *from
+	}
+	if to != nil {
+		q.To = *to
+	}
+	return q
+}
+
+// requestContext is the context of the request when ctx is a gin context:
+// gin reuses its contexts once the handler returns, before a stream ends.
+// The exports are exempt from the request timeout, EXPORT_TIMEOUT bounds it.
+func requestContext(ctx context.Context) context.Context {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		return c.Request.Context()
+	}
+	return ctx
+}
+
+// stream runs export as the response body is read. The status is sent by
+// then: an export failing midway aborts the response, so that the client
+// sees the transfer fail rather than a body ending early.
+func stream(export core.Exporter, op string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := export(pw)
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			logger.Error(op, slog.Any("err", err))
+		}
+		pw.CloseWithError(err)
+	}()
+	return abortingReader{pr}
+}
+
+// abortingReader panics with http.ErrAbortHandler on the error of an
+// export: returned, io.Copy would end the response as if complete.
+type abortingReader struct {
+	*io.PipeReader
+}
+
+func (r abortingReader) Read(p []byte) (int, error) {
+	n, err := r.PipeReader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		panic(http.ErrAbortHandler)
+	}
+	return n, err
+}
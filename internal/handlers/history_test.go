@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type mockHistory struct {
+	rows   string
+	report core.ImportReport
+	err    error
+	filter repository.WodFilter
+	query  core.ExportQuery
+	input  string
+	iquery core.ImportQuery
+}
+
+func (m *mockHistory) export(f repository.WodFilter, q core.ExportQuery) (core.Exporter, error) {
+	m.filter, m.query = f, q
+	if m.err != nil {
+		return nil, m.err
+	}
+	return func(w io.Writer) (int, error) {
+		_, err := io.WriteString(w, m.rows)
+		return 1, err
+	}, nil
+}
+
+func (m *mockHistory) ExportWods(ctx context.Context, f repository.WodFilter, q core.ExportQuery) (core.Exporter, error) {
+	return m.export(f, q)
+}
+
+func (m *mockHistory) ExportResults(ctx context.Context, f repository.WodFilter, q core.ExportQuery) (core.Exporter, error) {
+	return m.export(f, q)
+}
+
+func (m *mockHistory) ImportWods(ctx context.Context, r io.Reader, q core.ImportQuery) (core.ImportReport, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return core.ImportReport{}, err
+	}
+	m.input, m.iquery = string(b), q
+	return m.report, m.err
+}
+
+func TestExportWods_Success(t *testing.T) {
+	history := &mockHistory{rows: "id,created_at\n"}
+	s := newTestServer(handlers.Services{History: history})
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	csv := handlers.ExportWodsParamsFormatCsv
+
+	resp, err := s.ExportWods(ctxWithSubject("alice", ""), handlers.ExportWodsRequestObject{
+		Params: handlers.ExportWodsParams{Format: &csv, From: &from},
+	})
+
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ExportWods200TextcsvResponse{}, resp)
+	body, err := io.ReadAll(resp.(*handlers.ExportWods200TextcsvResponse).Body)
+	require.NoError(t, err)
+	require.Equal(t, "id,created_at\n", string(body))
+	require.Equal(t, repository.WodFilter{Owner: "alice"}, history.filter)
+	require.Equal(t, core.ExportQuery{Format: core.FormatCSV, From: from}, history.query)
+}
+
+func TestExportWods_Errors(t *testing.T) {
+	everyone := "*"
+	s := newTestServer(handlers.Services{History: &mockHistory{}})
+	resp, err := s.ExportWods(ctxWithSubject("alice", ""), handlers.ExportWodsRequestObject{
+		Params: handlers.ExportWodsParams{Owner: &everyone},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ExportWods403JSONResponse{}, resp)
+
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid": {fmt.Errorf("%w: from must be before to", common.ErrExportFilter), &handlers.ExportWods400JSONResponse{}},
+		"repo":    {errors.New("db fail"), &handlers.ExportWods500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{History: &mockHistory{err: tc.err}})
+		resp, err := s.ExportWods(ctxWithSubject("alice", ""), handlers.ExportWodsRequestObject{})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
+
+// slowWods reads a page of wods every delay, failing from page failAt when
+// set.
+type slowWods struct {
+	repository.WodRepositoryInterface
+	delay  time.Duration
+	failAt int
+	pages  int
+}
+
+func (r *slowWods) ListWods(ctx context.Context, f repository.WodFilter, p repository.WodPage) ([]models.Wod, error) {
+	r.pages++
+	if r.failAt != 0 && r.pages >= r.failAt {
+		return nil, errors.New("db fail")
+	}
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.WodRepositoryInterface.ListWods(ctx, f, p)
+}
+
+// exportServer serves the wod export of n wods read through slowWods, behind
+// the timeout and recovery of the server, both shorter than the export.
+func exportServer(t *testing.T, n int, wods *slowWods) *httptest.Server {
+	ctx := context.Background()
+	wods.WodRepositoryInterface = repository.NewMemoryWodRepository()
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	stored := make([]models.Wod, n)
+	for i := range stored {
+		stored[i] = models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0.Add(time.Duration(i) * time.Second), Level: "beginner",
+			DurationMin: 30, Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+	}
+	_, err := wods.SaveWods(ctx, stored)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(pkg.RecoveryMiddleware(slog.New(slog.DiscardHandler)))
+	r.Use(pkg.TimeoutMiddleware(10*time.Millisecond, map[string]time.Duration{"/wod/export": time.Minute}))
+	r.Use(func(c *gin.Context) { c.Set(pkg.CtxSubject, "alice") })
+	s := newTestServer(handlers.Services{History: core.NewHistory(wods, repository.NewMemoryResultRepository())})
+	handlers.RegisterHandlers(r, handlers.NewStrictHandler(s, nil))
+
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = 10 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestExportWods_Router(t *testing.T) {
+	wods := &slowWods{delay: 20 * time.Millisecond}
+	srv := exportServer(t, 1200, wods)
+
+	resp, err := http.Get(srv.URL + "/wod/export")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	lines := 0
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		lines++
+	}
+	require.NoError(t, sc.Err())
+	require.Equal(t, 1200, lines, "every page outlives the request and write timeouts")
+	require.Equal(t, 3, wods.pages)
+}
+
+func TestExportWods_RouterFailsMidway(t *testing.T) {
+	srv := exportServer(t, 1200, &slowWods{failAt: 2})
+
+	resp, err := http.Get(srv.URL + "/wod/export")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF, "a truncated export never reads as complete")
+	require.NotEmpty(t, body)
+}
+
+func TestExportResults_Success(t *testing.T) {
+	history := &mockHistory{rows: "{}\n"}
+	s := newTestServer(handlers.Services{History: history})
+	everyone := "*"
+
+	resp, err := s.ExportResults(ctxWithSubject("root", "admin"), handlers.ExportResultsRequestObject{
+		Params: handlers.ExportResultsParams{Owner: &everyone},
+	})
+
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ExportResults200ApplicationxNdjsonResponse{}, resp)
+	body, err := io.ReadAll(resp.(*handlers.ExportResults200ApplicationxNdjsonResponse).Body)
+	require.NoError(t, err)
+	require.Equal(t, "{}\n", string(body))
+	require.Equal(t, repository.WodFilter{AnyOwner: true}, history.filter)
+	require.Equal(t, core.ExportQuery{Format: core.FormatJSONL}, history.query)
+}
+
+func TestImportWods_Success(t *testing.T) {
+	history := &mockHistory{report: core.ImportReport{
+		Rows: 3, Imported: 2, Failed: 1,
+		Errors: []core.RowError{{Line: 2, Err: fmt.Errorf("%w: level %q", common.ErrInvalidRow, "expert")}},
+	}}
+	s := newTestServer(handlers.Services{History: history})
+
+	resp, err := s.ImportWods(ctxWithSubject("alice", ""), handlers.ImportWodsRequestObject{Body: &handlers.WodImport{
+		Format: handlers.WodImportFormatJsonl, Content: "{}\n",
+	}})
+
+	require.NoError(t, err)
+	require.Equal(t, &handlers.ImportWods200JSONResponse{
+		Rows: 3, Imported: 2, Failed: 1,
+		Errors: []handlers.WodImportError{{Line: 2, Message: `invalid row: level "expert"`}},
+	}, resp)
+	require.Equal(t, "{}\n", history.input)
+	require.Equal(t, core.ImportQuery{Format: core.FormatJSONL, Owner: "alice"}, history.iquery)
+
+	dryRun := true
+	_, err = s.ImportWods(ctxWithSubject("root", "admin"), handlers.ImportWodsRequestObject{Body: &handlers.WodImport{
+		Format: handlers.WodImportFormatCsv, DryRun: &dryRun,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, core.ImportQuery{Format: core.FormatCSV, Owner: "root", AnyOwner: true, DryRun: true}, history.iquery)
+}
+
+func TestImportWods_Errors(t *testing.T) {
+	s := newTestServer(handlers.Services{History: &mockHistory{}})
+	resp, err := s.ImportWods(ctxWithSubject("alice", ""), handlers.ImportWodsRequestObject{})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ImportWods400JSONResponse{}, resp)
+
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid": {fmt.Errorf("%w: unknown column %q", common.ErrInvalidImport, "notes"), &handlers.ImportWods400JSONResponse{}},
+		"repo":    {errors.New("db fail"), &handlers.ImportWods500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{History: &mockHistory{err: tc.err}})
+		resp, err := s.ImportWods(ctxWithSubject("alice", ""), handlers.ImportWodsRequestObject{Body: &handlers.WodImport{
+			Format: handlers.WodImportFormatCsv,
+		}})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+)
+
+// icalLine is the longest content line, in octets, before it is folded.
+const icalLine = 75
+
+// toICal renders feed as an iCalendar (RFC 5545) document: one all-day event
+// per entry, the blocks of its wod in loc in the description. Entries whose
+// wod is gone are left out.
+func (server *Server) toICal(ctx context.Context, feed core.Feed, loc string) []byte {
+	var b bytes.Buffer
+	line := func(name, value string) { writeICalLine(&b, name+":"+value) }
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//wod-gen//calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "WOD")
+	for _, e := range feed.Entries {
+		w, ok := feed.Wods[e.WodID]
+		if !ok {
+			continue
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", e.ID.String()+"@wod-gen")
+		line("DTSTAMP", e.UpdatedAt.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", e.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", e.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeICal(eventSummary(e, w)))
+		line("DESCRIPTION", escapeICal(server.eventDescription(ctx, e, w, loc)))
+		if e.Program != "" {
+			line("CATEGORIES", escapeICal(e.Program))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// eventSummary reads like "WOD 30 min, beginner", after the program if any.
+func eventSummary(e models.CalendarEntry, w models.Wod) string {
+	s := fmt.Sprintf("WOD %d min, %s", w.DurationMin, w.Level)
+	if e.Program != "" {
+		s = e.Program + ": " + s
+	}
+	return s
+}
+
+// eventDescription lists the blocks of w, one a line with their params in
+// the units of their move, then the note of e.
+func (server *Server) eventDescription(ctx context.Context, e models.CalendarEntry, w models.Wod, loc string) string {
+	var lines []string
+	for i, b := range server.toBlocks(ctx, w, loc, true) {
+		var units map[string]string
+		if b.Move != nil && b.Move.Units != nil {
+			units = *b.Move.Units
+		}
+		var params []string
+		if b.Params != nil {
+			for _, k := range slices.Sorted(maps.Keys(*b.Params)) {
+				unit := units[k]
+				if unit == "" {
+					unit = k
+				}
+				params = append(params, fmt.Sprintf("%v %s", (*b.Params)[k], unit))
+			}
+		}
+		l := fmt.Sprintf("%d. %s", i+1, *b.Name)
+		if len(params) > 0 {
+			l += ": " + strings.Join(params, ", ")
+		}
+		lines = append(lines, l)
+	}
+	if e.Note != "" {
+		lines = append(lines, "", e.Note)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeICal escapes a TEXT value.
+func escapeICal(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// writeICalLine writes l folded to icalLine octets, without splitting a
+// UTF-8 sequence, and ended by CRLF.
+func writeICalLine(b *bytes.Buffer, l string) {
+	limit := icalLine
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		b.WriteString(l[:cut])
+		b.WriteString("\r\n ")
+		l = l[cut:]
+		// the leading space of a continuation line counts.
+		limit = icalLine - 1
+	}
+	b.WriteString(l)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+)
+
+// locale picks the response language: the locale asked in the request
+// itself, then the one negotiated from Accept-Language.
+func locale(ctx context.Context, requested string) string {
+	if requested != "" {
+		return pkg.NegotiateLocale(requested, common.Locales())
+	}
+	if l := pkg.Locale(ctx); l != "" {
+		return l
+	}
+	return common.LocaleEN
+}
+
+// toBlocks renders the blocks of w in loc, looking their moves up in the
+// catalog w was generated from, as the caller's tenant sees it. Blocks whose
+// move is gone keep the stored name and get no details even when expanded.
+func (server *Server) toBlocks(ctx context.Context, w models.Wod, loc string, expand bool) []Block {
+	c, err := server.catalog.Resolve(ctx, w.Catalog, pkg.Tenant(ctx))
+	if err != nil {
+		c = nil
+	}
+
+	blocks := make([]Block, len(w.Blocks))
+	for i, b := range w.Blocks {
+		id, name, params := b.ID, b.Name, b.Params
+		var details *MoveDetails
+		if c != nil {
+			key := id
+			if key == "" {
+				key = name
+			}
+			if m, ok := c.Lookup(key); ok {
+				id, name = m.Key(), m.DisplayName(loc)
+				if expand {
+					details = moveDetails(m, loc)
+				}
+			}
+		}
+		blocks[i] = Block{Id: &id, Name: &name, Params: &params, Move: details}
+	}
+	return blocks
+}
+
+func moveDetails(m catalog.Move, loc string) *MoveDetails {
+	description := m.DisplayDescription(loc)
+	cues := cloneOrEmpty(m.DisplayCues(loc))
+	muscles := cloneOrEmpty(m.Muscles)
+	units := maps.Clone(m.Units)
+	if units == nil {
+		units = map[string]string{}
+	}
+	media := toMedia(m.Media)
+	return &MoveDetails{
+		Description: &description,
+		Cues:        &cues,
+		Muscles:     &muscles,
+		Pattern:     optional(m.Pattern),
+		Units:       &units,
+		Media:       &media,
+	}
+}
+
+// expandMoves reports whether the client asked for the move details.
+func expandMoves(expand *[]string) bool {
+	if expand == nil {
+		return false
+	}
+	for _, e := range *expand {
+		for _, v := range strings.Split(e, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), "moves") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
+	"github.com/bytedance/gopkg/util/logger"
+)
+
+func (server *Server) LogResult(ctx context.Context, req LogResultRequestObject) (LogResultResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &LogResult400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+
+	r := models.Result{
+		Subject: pkg.Subject(ctx),
+		TimeSec: req.Body.TimeSec,
+		Rounds:  req.Body.Rounds,
+		Reps:    req.Body.Reps,
+		LoadKg:  req.Body.LoadKg,
+		RPE:     req.Body.Rpe,
+	}
+	if req.Body.Division != nil {
+		r.Division = *req.Body.Division
+	}
+	if req.Body.Splits != nil {
+		r.Splits = make([]models.Split, len(*req.Body.Splits))
+		for i, sp := range *req.Body.Splits {
+			r.Splits[i] = models.Split{Block: sp.Block, TimeSec: sp.TimeSec}
+		}
+	}
+	if req.Body.Scaling != nil {
+		r.Scaling = *req.Body.Scaling
+	}
+	if req.Body.CompletedAt != nil {
+		r.CompletedAt = *req.Body.CompletedAt
+	}
+
+	// admins log results on any wod, the others on their own only.
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	saved, err := server.results.Log(ctx, req.Id, r, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrInvalidResult):
+			return &LogResult400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrWodNotFound):
+			return &LogResult404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		default:
+			logger.Error("server.results.Log()", slog.Any("err", err))
+			return &LogResult500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	resp := LogResult201JSONResponse(toResult(saved))
+	return &resp, nil
+}
+
+func (server *Server) ListWodResults(ctx context.Context, req ListWodResultsRequestObject) (ListWodResultsResponseObject, error) {
+	loc := locale(ctx, "")
+	limit, offset, err := resultPage(req.Params.Limit, req.Params.Offset)
+	if err != nil {
+		return &ListWodResults400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	}
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	results, err := server.results.ListByWod(ctx, req.Id, f, limit, offset)
+	if err != nil {
+		if errors.Is(err, common.ErrWodNotFound) {
+			return &ListWodResults404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.results.ListByWod()", slog.Any("err", err))
+		return &ListWodResults500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	page := toResults(results)
+	return &ListWodResults200JSONResponse{Results: &page}, nil
+}
+
+func (server *Server) ListResults(ctx context.Context, req ListResultsRequestObject) (ListResultsResponseObject, error) {
+	loc := locale(ctx, "")
+	athlete := pkg.Subject(ctx)
+	if req.Params.Athlete != nil && *req.Params.Athlete != athlete {
+		if !pkg.IsAdmin(ctx) {
+			return &ListResults403JSONResponse{
+				Code:    http.StatusForbidden,
+				Message: common.Translate(common.ErrAdminOnly, loc),
+			}, nil
+		}
+		athlete = *req.Params.Athlete
+	}
+
+	limit, offset, err := resultPage(req.Params.Limit, req.Params.Offset)
+	if err != nil {
+		return &ListResults400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	}
+	results, err := server.results.ListByAthlete(ctx, athlete, limit, offset)
+	if err != nil {
+		logger.Error("server.results.ListByAthlete()", slog.Any("err", err))
+		return &ListResults500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	page := toResults(results)
+	return &ListResults200JSONResponse{Results: &page}, nil
+}
+
+func (server *Server) GetLeaderboard(ctx context.Context, req GetLeaderboardRequestObject) (GetLeaderboardResponseObject, error) {
+	loc := locale(ctx, "")
+	q := core.BoardQuery{Seed: req.Params.Scope != nil && *req.Params.Scope == GetLeaderboardParamsScopeSeed}
+	var err error
+	q.Limit, q.Offset, err = resultPage(req.Params.Limit, req.Params.Offset)
+	if err != nil {
+		return &GetLeaderboard400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	}
+	if req.Params.Scoring != nil {
+		q.Scoring = string(*req.Params.Scoring)
+	}
+	if req.Params.Level != nil {
+		q.Level = string(*req.Params.Level)
+	}
+	if req.Params.Division != nil {
+		q.Division = *req.Params.Division
+	}
+	if req.Params.CompletedAfter != nil {
+		q.CompletedAfter = *req.Params.CompletedAfter
+	}
+	if req.Params.CompletedBefore != nil {
+		q.CompletedBefore = *req.Params.CompletedBefore
+	}
+
+	f := repository.WodFilter{Owner: pkg.Subject(ctx), AnyOwner: pkg.IsAdmin(ctx)}
+	board, err := server.results.Leaderboard(ctx, req.Id, f, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrResultFilter):
+			return &GetLeaderboard400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		case errors.Is(err, common.ErrWodNotFound):
+			return &GetLeaderboard404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		default:
+			logger.Error("server.results.Leaderboard()", slog.Any("err", err))
+			return &GetLeaderboard500JSONResponse{
+				Code:    http.StatusInternalServerError,
+				Message: common.Translate(common.ErrInternal, loc),
+			}, nil
+		}
+	}
+
+	resp := GetLeaderboard200JSONResponse{
+		Scoring: LeaderboardScoring(board.Scoring),
+		Wods:    board.Wods,
+		Entries: make([]LeaderboardEntry, len(board.Entries)),
+	}
+	for i, e := range board.Entries {
+		resp.Entries[i] = LeaderboardEntry{Rank: e.Rank, Result: toResult(e.Result)}
+	}
+	return &resp, nil
+}
+
+// resultPage applies the defaults and the bounds of the ResultLimit and
+// ResultOffset parameters.
+func resultPage(limit, offset *int) (int, int, error) {
+	l, o := 20, 0
+	if limit != nil {
+		l = *limit
+	}
+	if offset != nil {
+		o = *offset
+	}
+	if l < 1 || l > core.MaxResultLimit {
+		return 0, 0, fmt.Errorf("%w: limit is 1 to %d", common.ErrResultFilter, core.MaxResultLimit)
+	}
+	if o < 0 {
+		return 0, 0, fmt.Errorf("%w: offset cannot be negative", common.ErrResultFilter)
+	}
+	return l, o, nil
+}
+
+func toResults(results []models.Result) []Result {
+	out := make([]Result, len(results))
+	for i, r := range results {
+		out[i] = toResult(r)
+	}
+	return out
+}
+
+func toResult(r models.Result) Result {
+	res := Result{
+		Id:          r.ID,
+		WodId:       r.WodID,
+		Athlete:     r.Subject,
+		TimeSec:     r.TimeSec,
+		Rounds:      r.Rounds,
+		Reps:        r.Reps,
+		LoadKg:      r.LoadKg,
+		Rpe:         r.RPE,
+		CompletedAt: r.CompletedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+	if len(r.Splits) > 0 {
+		splits := make([]Split, len(r.Splits))
+		for i, sp := range r.Splits {
+			splits[i] = Split{Block: sp.Block, TimeSec: sp.TimeSec}
+		}
+		res.Splits = &splits
+	}
+	if r.Division != "" {
+		res.Division = &r.Division
+	}
+	if r.Scaling != "" {
+		res.Scaling = &r.Scaling
+	}
+	return res
+}
@@ -0,0 +1,230 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type mockResults struct {
+	results []models.Result
+	err     error
+	logged  models.Result
+	filter  repository.WodFilter
+	athlete string
+	limit   int
+	board   core.Board
+	query   core.BoardQuery
+}
+
+func (m *mockResults) Log(ctx context.Context, id uuid.UUID, r models.Result, f repository.WodFilter) (models.Result, error) {
+	m.logged, m.filter = r, f
+	if m.err != nil {
+		return models.Result{}, m.err
+	}
+	r.ID, r.WodID = uuid.New(), id
+	return r, nil
+}
+
+func (m *mockResults) ListByWod(ctx context.Context, id uuid.UUID, f repository.WodFilter, limit, offset int) ([]models.Result, error) {
+	m.filter, m.limit = f, limit
+	return m.results, m.err
+}
+
+func (m *mockResults) ListByAthlete(ctx context.Context, subject string, limit, offset int) ([]models.Result, error) {
+	m.athlete, m.limit = subject, limit
+	return m.results, m.err
+}
+
+func (m *mockResults) Leaderboard(ctx context.Context, id uuid.UUID, f repository.WodFilter, q core.BoardQuery) (core.Board, error) {
+	m.filter, m.query = f, q
+	return m.board, m.err
+}
+
+func TestLogResult_Success(t *testing.T) {
+	results := &mockResults{}
+	s := newTestServer(handlers.Services{Results: results})
+
+	rounds, reps, rpe, scaling := 5, 12, 8, "13kg wall balls"
+	completed := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	resp, err := s.LogResult(ctxWithSubject("alice", ""), handlers.LogResultRequestObject{Id: id, Body: &handlers.ResultInput{
+		Rounds:      &rounds,
+		Reps:        &reps,
+		Rpe:         &rpe,
+		Scaling:     &scaling,
+		Splits:      &[]handlers.Split{{Block: 1, TimeSec: 240}},
+		CompletedAt: &completed,
+	}})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.LogResult201JSONResponse)
+	require.Equal(t, id, r.WodId)
+	require.Equal(t, "alice", r.Athlete)
+	require.Equal(t, 5, *r.Rounds)
+	require.Equal(t, []handlers.Split{{Block: 1, TimeSec: 240}}, *r.Splits)
+	require.Equal(t, "13kg wall balls", *r.Scaling)
+	require.Equal(t, completed, r.CompletedAt)
+	require.Equal(t, repository.WodFilter{Owner: "alice"}, results.filter)
+}
+
+func TestLogResult_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid":   {fmt.Errorf("%w: rpe must be between 1 and 10", common.ErrInvalidResult), &handlers.LogResult400JSONResponse{}},
+		"not found": {common.ErrWodNotFound, &handlers.LogResult404JSONResponse{}},
+		"repo":      {errors.New("db fail"), &handlers.LogResult500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{Results: &mockResults{err: tc.err}})
+		timeSec := 600
+		resp, err := s.LogResult(context.Background(), handlers.LogResultRequestObject{
+			Id:   uuid.New(),
+			Body: &handlers.ResultInput{TimeSec: &timeSec},
+		})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+
+	s := newTestServer(handlers.Services{Results: &mockResults{}})
+	resp, err := s.LogResult(context.Background(), handlers.LogResultRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.LogResult400JSONResponse{}, resp, "missing body")
+}
+
+func TestLogResult_InvalidTranslated(t *testing.T) {
+	err := fmt.Errorf("%w: rpe must be between 1 and 10", common.ErrInvalidResult)
+	s := newTestServer(handlers.Services{Results: &mockResults{err: err}})
+
+	resp, _ := s.LogResult(ctxWithLocale("fr"), handlers.LogResultRequestObject{Id: uuid.New(), Body: &handlers.ResultInput{}})
+	r := resp.(*handlers.LogResult400JSONResponse)
+	require.Equal(t, "résultat invalide: rpe must be between 1 and 10", r.Message)
+}
+
+func TestListWodResults(t *testing.T) {
+	results := &mockResults{results: []models.Result{{ID: uuid.New(), Subject: "alice"}}}
+	s := newTestServer(handlers.Services{Results: results})
+
+	resp, err := s.ListWodResults(ctxWithSubject("root", "admin"), handlers.ListWodResultsRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	r := resp.(*handlers.ListWodResults200JSONResponse)
+	require.Len(t, *r.Results, 1)
+	require.Equal(t, 20, results.limit)
+	require.True(t, results.filter.AnyOwner, "admins see the results on any wod")
+
+	s = newTestServer(handlers.Services{Results: &mockResults{err: common.ErrWodNotFound}})
+	resp, err = s.ListWodResults(context.Background(), handlers.ListWodResultsRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListWodResults404JSONResponse{}, resp)
+}
+
+func TestListResults_Athlete(t *testing.T) {
+	results := &mockResults{}
+	s := newTestServer(handlers.Services{Results: results})
+	bob := "bob"
+	limit := 5
+
+	resp, err := s.ListResults(ctxWithSubject("alice", ""), handlers.ListResultsRequestObject{
+		Params: handlers.ListResultsParams{Limit: &limit},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListResults200JSONResponse{}, resp)
+	require.Equal(t, "alice", results.athlete)
+	require.Equal(t, 5, results.limit)
+
+	resp, err = s.ListResults(ctxWithSubject("alice", ""), handlers.ListResultsRequestObject{
+		Params: handlers.ListResultsParams{Athlete: &bob},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListResults403JSONResponse{}, resp)
+
+	_, err = s.ListResults(ctxWithSubject("root", "admin"), handlers.ListResultsRequestObject{
+		Params: handlers.ListResultsParams{Athlete: &bob},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "bob", results.athlete)
+}
+
+func TestResults_PageBounds(t *testing.T) {
+	s := newTestServer(handlers.Services{Results: &mockResults{}})
+	zero, over, negative := 0, core.MaxResultLimit+1, -1
+
+	for name, page := range map[string][2]*int{
+		"no limit":        {&zero, nil},
+		"limit too large": {&over, nil},
+		"negative offset": {nil, &negative},
+	} {
+		resp, err := s.ListWodResults(context.Background(), handlers.ListWodResultsRequestObject{
+			Id: uuid.New(), Params: handlers.ListWodResultsParams{Limit: page[0], Offset: page[1]},
+		})
+		require.NoError(t, err, name)
+		require.IsType(t, &handlers.ListWodResults400JSONResponse{}, resp, name)
+
+		resp2, err := s.ListResults(context.Background(), handlers.ListResultsRequestObject{
+			Params: handlers.ListResultsParams{Limit: page[0], Offset: page[1]},
+		})
+		require.NoError(t, err, name)
+		require.IsType(t, &handlers.ListResults400JSONResponse{}, resp2, name)
+
+		resp3, err := s.GetLeaderboard(context.Background(), handlers.GetLeaderboardRequestObject{
+			Id: uuid.New(), Params: handlers.GetLeaderboardParams{Limit: page[0], Offset: page[1]},
+		})
+		require.NoError(t, err, name)
+		require.IsType(t, &handlers.GetLeaderboard400JSONResponse{}, resp3, name)
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	load := 102.5
+	results := &mockResults{board: core.Board{Scoring: core.ScoreLoad, Wods: 3, Entries: []core.BoardEntry{
+		{Rank: 1, Result: models.Result{Subject: "alice", LoadKg: &load, Division: "rx"}},
+	}}}
+	s := newTestServer(handlers.Services{Results: results})
+
+	scope, level, division := handlers.GetLeaderboardParamsScopeSeed, handlers.GetLeaderboardParamsLevelAdvanced, "rx"
+	after := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	resp, err := s.GetLeaderboard(ctxWithSubject("alice", ""), handlers.GetLeaderboardRequestObject{
+		Id: uuid.New(),
+		Params: handlers.GetLeaderboardParams{
+			Scope: &scope, Level: &level, Division: &division, CompletedAfter: &after,
+		},
+	})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetLeaderboard200JSONResponse)
+	require.Equal(t, handlers.LeaderboardScoring("load"), r.Scoring)
+	require.Equal(t, 3, r.Wods)
+	require.Equal(t, 1, r.Entries[0].Rank)
+	require.Equal(t, 102.5, *r.Entries[0].Result.LoadKg)
+	require.Equal(t, "rx", *r.Entries[0].Result.Division)
+	require.Equal(t, core.BoardQuery{Seed: true, Level: "advanced", Division: "rx", CompletedAfter: after, Limit: 20},
+		results.query)
+	require.Equal(t, repository.WodFilter{Owner: "alice"}, results.filter)
+}
+
+func TestGetLeaderboard_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"filter":    {common.ErrResultFilter, &handlers.GetLeaderboard400JSONResponse{}},
+		"not found": {common.ErrWodNotFound, &handlers.GetLeaderboard404JSONResponse{}},
+		"repo":      {errors.New("db fail"), &handlers.GetLeaderboard500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{Results: &mockResults{err: tc.err}})
+		resp, err := s.GetLeaderboard(context.Background(), handlers.GetLeaderboardRequestObject{Id: uuid.New()})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/bytedance/gopkg/util/logger"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (server *Server) GetStats(ctx context.Context, req GetStatsRequestObject) (GetStatsResponseObject, error) {
+	loc := locale(ctx, "")
+	f, ok := ownerFilter(ctx, req.Params.Owner)
+	if !ok {
+		return &GetStats403JSONResponse{
+			Code:    http.StatusForbidden,
+			Message: common.Translate(common.ErrAdminOnly, loc),
+		}, nil
+	}
+
+	var q core.StatsQuery
+	if req.Params.From != nil {
+		q.From = *req.Params.From
+	}
+	if req.Params.To != nil {
+		q.To = *req.Params.To
+	}
+	if req.Params.GroupBy != nil {
+		q.Period = string(*req.Params.GroupBy)
+	}
+
+	stats, err := server.stats.Get(ctx, f, q)
+	switch {
+	case errors.Is(err, common.ErrStatsFilter):
+		return &GetStats400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(err, loc),
+		}, nil
+	case err != nil:
+		logger.Error("server.stats.Get()", slog.Any("err", err))
+		return &GetStats500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+	resp := GetStats200JSONResponse(toStats(stats))
+	return &resp, nil
+}
+
+func toStats(s models.Stats) Stats {
+	out := Stats{
+		Wods:      s.Wods,
+		Results:   s.Results,
+		Moves:     make([]MoveStat, len(s.Moves)),
+		Periods:   make([]PeriodStat, len(s.Periods)),
+		Levels:    toCountStats(s.Levels),
+		Durations: make([]DurationStat, len(s.Durations)),
+		Equipment: toCountStats(s.Equipment),
+	}
+	for i, m := range s.Moves {
+		out.Moves[i] = MoveStat{Name: m.Name, Blocks: m.Blocks, Wods: m.Wods}
+		if m.ID != "" {
+			out.Moves[i].Id = &m.ID
+		}
+	}
+	for i, p := range s.Periods {
+		out.Periods[i] = PeriodStat{
+			Start:   openapi_types.Date{Time: p.Start},
+			Wods:    p.Wods,
+			Results: p.Results,
+			Meters:  p.Meters,
+			Reps:    p.Reps,
+		}
+	}
+	for i, d := range s.Durations {
+		out.Durations[i] = DurationStat{DurationMin: d.DurationMin, Wods: d.Wods}
+	}
+	return out
+}
+
+func toCountStats(counts []models.CountStat) []CountStat {
+	out := make([]CountStat, len(counts))
+	for i, c := range counts {
+		out[i] = CountStat{Key: c.Key, Wods: c.Wods}
+	}
+	return out
+}
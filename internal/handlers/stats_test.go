@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStats struct {
+	stats  models.Stats
+	err    error
+	filter repository.WodFilter
+	query  core.StatsQuery
+}
+
+func (m *mockStats) Get(ctx context.Context, f repository.WodFilter, q core.StatsQuery) (models.Stats, error) {
+	m.filter, m.query = f, q
+	return m.stats, m.err
+}
+
+func TestGetStats_Success(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	stats := &mockStats{stats: models.Stats{
+		Wods:      2,
+		Results:   1,
+		Moves:     []models.MoveStat{{ID: "row", Name: "Row", Blocks: 3, Wods: 2}, {Name: "Row", Blocks: 1, Wods: 1}},
+		Periods:   []models.PeriodStat{{Start: start, Wods: 2, Results: 1, Meters: 2500, Reps: 20}},
+		Levels:    []models.CountStat{{Key: "beginner", Wods: 2}},
+		Durations: []models.DurationStat{{DurationMin: 30, Wods: 2}},
+	}}
+	s := newTestServer(handlers.Services{Stats: stats})
+
+	month := handlers.GetStatsParamsGroupByMonth
+	resp, err := s.GetStats(ctxWithSubject("alice", ""), handlers.GetStatsRequestObject{
+		Params: handlers.GetStatsParams{From: &start, GroupBy: &month},
+	})
+
+	require.NoError(t, err)
+	row := "row"
+	require.Equal(t, &handlers.GetStats200JSONResponse{
+		Wods:      2,
+		Results:   1,
+		Moves:     []handlers.MoveStat{{Id: &row, Name: "Row", Blocks: 3, Wods: 2}, {Name: "Row", Blocks: 1, Wods: 1}},
+		Periods:   []handlers.PeriodStat{{Start: openapi_types.Date{Time: start}, Wods: 2, Results: 1, Meters: 2500, Reps: 20}},
+		Levels:    []handlers.CountStat{{Key: "beginner", Wods: 2}},
+		Durations: []handlers.DurationStat{{DurationMin: 30, Wods: 2}},
+		Equipment: []handlers.CountStat{},
+	}, resp)
+	require.Equal(t, repository.WodFilter{Owner: "alice"}, stats.filter)
+	require.Equal(t, core.StatsQuery{From: start, Period: "month"}, stats.query)
+}
+
+func TestGetStats_Owner(t *testing.T) {
+	stats := &mockStats{}
+	s := newTestServer(handlers.Services{Stats: stats})
+	everyone := "*"
+
+	resp, err := s.GetStats(ctxWithSubject("alice", ""), handlers.GetStatsRequestObject{
+		Params: handlers.GetStatsParams{Owner: &everyone},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.GetStats403JSONResponse{}, resp)
+
+	_, err = s.GetStats(ctxWithSubject("root", "admin"), handlers.GetStatsRequestObject{
+		Params: handlers.GetStatsParams{Owner: &everyone},
+	})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{AnyOwner: true}, stats.filter)
+}
+
+func TestGetStats_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want any
+	}{
+		"invalid": {fmt.Errorf("%w: from must be before to", common.ErrStatsFilter), &handlers.GetStats400JSONResponse{}},
+		"repo":    {errors.New("db fail"), &handlers.GetStats500JSONResponse{}},
+	} {
+		s := newTestServer(handlers.Services{Stats: &mockStats{err: tc.err}})
+		resp, err := s.GetStats(ctxWithSubject("alice", ""), handlers.GetStatsRequestObject{})
+		require.NoError(t, err, name)
+		require.IsType(t, tc.want, resp, name)
+	}
+}
@@ -8,112 +8,404 @@ import (
 
 	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/LinaKACI-pro/wod-gen/pkg"
 	"github.com/bytedance/gopkg/util/logger"
+	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
 	wodGenerate core.WodGeneratorInterface
 	wodList     core.WodListInterface
+	catalog     core.CatalogManagerInterface
+	results     core.ResultsInterface
+	calendar    core.CalendarInterface
+	stats       core.StatsInterface
+	history     core.HistoryInterface
 }
 
-func NewServer(wodGenerate core.WodGeneratorInterface, list core.WodListInterface) *Server {
-	return &Server{wodGenerate: wodGenerate, wodList: list}
+// Services are the core services the handlers call, new ones are added
+// here rather than to NewServer.
+type Services struct {
+	WodGenerate core.WodGeneratorInterface
+	WodList     core.WodListInterface
+	Catalog     core.CatalogManagerInterface
+	Results     core.ResultsInterface
+	Calendar    core.CalendarInterface
+	Stats       core.StatsInterface
+	History     core.HistoryInterface
+}
+
+func NewServer(s Services) *Server {
+	return &Server{
+		wodGenerate: s.WodGenerate,
+		wodList:     s.WodList,
+		catalog:     s.Catalog,
+		results:     s.Results,
+		calendar:    s.Calendar,
+		stats:       s.Stats,
+		history:     s.History,
+	}
 }
 
 func (server *Server) GenerateWod(ctx context.Context, req GenerateWodRequestObject) (GenerateWodResponseObject, error) {
 	if req.Body == nil {
 		return &GenerateWod400JSONResponse{
 			Code:    http.StatusBadRequest,
-			Message: "missing body",
+			Message: common.Translate(common.ErrMissingBody, locale(ctx, "")),
 		}, nil
 	}
 
-	var equipment []string
+	var requested string
+	if req.Body.Locale != nil {
+		requested = string(*req.Body.Locale)
+	}
+	loc := locale(ctx, requested)
+
+	p := core.Params{
+		Catalog:     catalogName(req.Body.Catalog),
+		Tenant:      pkg.Tenant(ctx),
+		Owner:       pkg.Subject(ctx),
+		Level:       string(req.Body.Level),
+		DurationMin: req.Body.DurationMin,
+	}
 	if req.Body.Equipment != nil {
-		equipment = *req.Body.Equipment
-	} else {
-		equipment = nil
+		p.Equipment = *req.Body.Equipment
+	}
+	if req.Body.Seed != nil {
+		p.Seed = *req.Body.Seed
 	}
+	p.IdempotencyKey = header(ctx, "Idempotency-Key")
 
-	wod, err := server.wodGenerate.Generate(ctx, string(req.Body.Level), req.Body.DurationMin, equipment, req.Body.Seed)
+	wod, replayed, err := server.wodGenerate.Generate(ctx, p)
 	if err != nil {
 		logger.Error("server.wodGenerate.Generate()", slog.Any("err", err))
 
-		var invalidDataErr common.InvalidDataError
-		switch {
-		case errors.As(err, &invalidDataErr):
+		switch generateStatus(err) {
+		case http.StatusBadRequest:
 			return &GenerateWod400JSONResponse{
 				Code:    http.StatusBadRequest,
-				Message: invalidDataErr.Error(),
+				Message: common.Translate(err, loc),
 			}, nil
-		case errors.Is(err, common.ErrDuration),
-			errors.Is(err, common.ErrEmptyCatalog),
-			errors.Is(err, common.ErrNoMoves):
-			return &GenerateWod400JSONResponse{
-				Code:    http.StatusBadRequest,
-				Message: err.Error(),
+		case http.StatusConflict:
+			return &GenerateWod409JSONResponse{
+				Code:    http.StatusConflict,
+				Message: common.Translate(err, loc),
 			}, nil
 		default:
 			return &GenerateWod500JSONResponse{
 				Code:    http.StatusInternalServerError,
-				Message: "internal server error",
+				Message: common.Translate(common.ErrInternal, loc),
 			}, nil
 		}
 	}
 
-	blocks := make([]Block, len(wod.Blocks))
-	for i, b := range wod.Blocks {
-		blocks[i] = Block{Name: &b.Name, Params: &b.Params}
-	}
-
 	return &GenerateWod200JSONResponse{
-		Blocks:           blocks,
-		CreatedAt:        wod.CreatedAt,
-		DurationMin:      wod.DurationMin,
-		Equipment:        &wod.Equipment,
-		GeneratorVersion: "v1",
-		Id:               wod.ID,
-		Level:            WodLevel(wod.Level),
-		Seed:             wod.Seed,
+		Body:    server.toWod(ctx, wod, loc, expandMoves(req.Body.Expand)),
+		Headers: GenerateWod200ResponseHeaders{IdempotentReplayed: replayed},
 	}, nil
 }
 
 func (server *Server) ListWods(ctx context.Context, req ListWodsRequestObject) (ListWodsResponseObject, error) {
-	limit := 10
-	offset := 0
+	page := core.Page{Limit: 10}
 	if req.Params.Limit != nil {
-		limit = *req.Params.Limit
+		page.Limit = *req.Params.Limit
 	}
 	if req.Params.Offset != nil {
-		offset = *req.Params.Offset
+		page.Offset = *req.Params.Offset
 	}
+	if req.Params.Cursor != nil {
+		page.Cursor = *req.Params.Cursor
+	}
+	page.Total = req.Params.IncludeTotal != nil && *req.Params.IncludeTotal
+
+	loc := locale(ctx, "")
+	f, ok := ownerFilter(ctx, req.Params.Owner)
+	if !ok {
+		return &ListWods403JSONResponse{
+			Code:    http.StatusForbidden,
+			Message: common.Translate(common.ErrAdminOnly, loc),
+		}, nil
+	}
+	listFilter(req.Params, &f)
+	f.Annotator = pkg.Subject(ctx)
 
-	wods, err := server.wodList.List(ctx, limit, offset)
+	expand := expandMoves(req.Params.Expand)
+	listing, err := server.wodList.List(ctx, f, page)
 	if err != nil {
+		var invalidDataErr common.InvalidDataError
+		if errors.As(err, &invalidDataErr) || errors.Is(err, common.ErrWodFilter) {
+			return &ListWods400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
+		}
 		logger.Error("server.wodList.List()", slog.Any("err", err))
 		return &ListWods500JSONResponse{
 			Code:    http.StatusInternalServerError,
-			Message: "failed to list wods",
+			Message: common.Translate(common.ErrListWods, loc),
 		}, nil
 	}
 
-	resp := make([]Wod, len(wods))
-	for i, w := range wods {
-		blocks := make([]Block, len(w.Blocks))
-		for j, b := range w.Blocks {
-			blocks[j] = Block{Name: &b.Name, Params: &b.Params}
+	wods := make([]Wod, len(listing.Wods))
+	for i, w := range listing.Wods {
+		wods[i] = server.toWod(ctx, w, loc, expand)
+	}
+
+	resp := &ListWods200JSONResponse{Wods: &wods, Total: listing.Total}
+	if listing.NextCursor != "" {
+		resp.NextCursor = &listing.NextCursor
+	}
+	if listing.PrevCursor != "" {
+		resp.PrevCursor = &listing.PrevCursor
+	}
+	return resp, nil
+}
+
+// SearchWods is ListWods with the moves searched, the other filters and the
+// paging come in the body too.
+func (server *Server) SearchWods(ctx context.Context, req SearchWodsRequestObject) (SearchWodsResponseObject, error) {
+	loc := locale(ctx, "")
+	if req.Body == nil {
+		return &SearchWods400JSONResponse{
+			Code:    http.StatusBadRequest,
+			Message: common.Translate(common.ErrMissingBody, loc),
+		}, nil
+	}
+	search := *req.Body
+
+	f, ok := ownerFilter(ctx, search.Owner)
+	if !ok {
+		return &SearchWods403JSONResponse{
+			Code:    http.StatusForbidden,
+			Message: common.Translate(common.ErrAdminOnly, loc),
+		}, nil
+	}
+	searchFilter(search, &f)
+	f.Annotator = pkg.Subject(ctx)
+
+	page := core.Page{Limit: 10}
+	if search.Limit != nil {
+		page.Limit = *search.Limit
+	}
+	if search.Cursor != nil {
+		page.Cursor = *search.Cursor
+	}
+	page.Total = search.IncludeTotal != nil && *search.IncludeTotal
+
+	expand := expandMoves(req.Params.Expand)
+	listing, err := server.wodList.List(ctx, f, page)
+	if err != nil {
+		var invalidDataErr common.InvalidDataError
+		if errors.As(err, &invalidDataErr) || errors.Is(err, common.ErrWodFilter) {
+			return &SearchWods400JSONResponse{
+				Code:    http.StatusBadRequest,
+				Message: common.Translate(err, loc),
+			}, nil
 		}
-		resp[i] = Wod{
-			Id:               w.ID,
-			Seed:             w.Seed,
-			CreatedAt:        w.CreatedAt,
-			Level:            WodLevel(w.Level),
-			DurationMin:      w.DurationMin,
-			Equipment:        &w.Equipment,
-			Blocks:           blocks,
-			GeneratorVersion: "v1",
+		logger.Error("server.wodList.List()", slog.Any("err", err))
+		return &SearchWods500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrListWods, loc),
+		}, nil
+	}
+
+	wods := make([]Wod, len(listing.Wods))
+	for i, w := range listing.Wods {
+		wods[i] = server.toWod(ctx, w, loc, expand)
+	}
+
+	resp := &SearchWods200JSONResponse{Wods: &wods, Total: listing.Total}
+	if listing.NextCursor != "" {
+		resp.NextCursor = &listing.NextCursor
+	}
+	if listing.PrevCursor != "" {
+		resp.PrevCursor = &listing.PrevCursor
+	}
+	return resp, nil
+}
+
+func (server *Server) GetWod(ctx context.Context, req GetWodRequestObject) (GetWodResponseObject, error) {
+	loc := locale(ctx, "")
+	// admins fetch any wod, the others their own only.
+	sub := pkg.Subject(ctx)
+	f := repository.WodFilter{Owner: sub, AnyOwner: pkg.IsAdmin(ctx), Annotator: sub}
+	wod, err := server.wodList.Get(ctx, req.Id, f)
+	if err != nil {
+		if errors.Is(err, common.ErrWodNotFound) {
+			return &GetWod404JSONResponse{
+				Code:    http.StatusNotFound,
+				Message: common.Translate(common.ErrWodNotFound, loc),
+			}, nil
+		}
+		logger.Error("server.wodList.Get()", slog.Any("err", err))
+		return &GetWod500JSONResponse{
+			Code:    http.StatusInternalServerError,
+			Message: common.Translate(common.ErrInternal, loc),
+		}, nil
+	}
+
+	resp := GetWod200JSONResponse(server.toWod(ctx, wod, loc, expandMoves(req.Params.Expand)))
+	return &resp, nil
+}
+
+// generateStatus is the HTTP status of a failed generation.
+func generateStatus(err error) int {
+	var invalidDataErr common.InvalidDataError
+	switch {
+	case errors.As(err, &invalidDataErr),
+		errors.Is(err, common.ErrDuration),
+		errors.Is(err, common.ErrEmptyCatalog),
+		errors.Is(err, common.ErrNoMoves),
+		errors.Is(err, common.ErrUnknownCatalog),
+		errors.Is(err, common.ErrLevelUnavailable),
+		errors.Is(err, common.ErrIdempotencyKey):
+		return http.StatusBadRequest
+	case errors.Is(err, common.ErrIdempotencyConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// header reads a request header the strict handler has no parameter for.
+func header(ctx context.Context, name string) string {
+	c, ok := ctx.(*gin.Context)
+	if !ok || c.Request == nil {
+		return ""
+	}
+	return c.GetHeader(name)
+}
+
+// listFilter copies the filters and sort of params into f.
+func listFilter(params ListWodsParams, f *repository.WodFilter) {
+	if params.Catalog != nil {
+		f.Catalog = *params.Catalog
+	}
+	if params.CatalogVersion != nil {
+		f.CatalogVersion = *params.CatalogVersion
+	}
+	if params.CatalogHash != nil {
+		f.CatalogHash = *params.CatalogHash
+	}
+	if params.Level != nil {
+		f.Level = string(*params.Level)
+	}
+	if params.MinDuration != nil {
+		f.MinDuration = *params.MinDuration
+	}
+	if params.MaxDuration != nil {
+		f.MaxDuration = *params.MaxDuration
+	}
+	if params.Equipment != nil {
+		f.Equipment = *params.Equipment
+	}
+	f.AnyEquipment = params.EquipmentMatch != nil && *params.EquipmentMatch == ListWodsParamsEquipmentMatchAny
+	if params.CreatedAfter != nil {
+		f.CreatedAfter = *params.CreatedAfter
+	}
+	if params.CreatedBefore != nil {
+		f.CreatedBefore = *params.CreatedBefore
+	}
+	if params.Seed != nil {
+		f.Seed = *params.Seed
+	}
+	if params.Move != nil {
+		f.MoveName = *params.Move
+	}
+	f.Favorite = params.Favorite != nil && *params.Favorite
+	if params.Label != nil {
+		f.Labels = *params.Label
+	}
+	if params.Note != nil {
+		f.Note = *params.Note
+	}
+	if params.Sort != nil {
+		f.Sort = string(*params.Sort)
+	}
+	f.Ascending = params.Order != nil && *params.Order == ListWodsParamsOrderAsc
+}
+
+// searchFilter copies the moves, filters and sort of search into f.
+func searchFilter(search WodSearch, f *repository.WodFilter) {
+	if search.All != nil {
+		f.AllMoves = toMoveMatches(*search.All)
+	}
+	if search.Any != nil {
+		f.AnyMoves = toMoveMatches(*search.Any)
+	}
+	if search.None != nil {
+		f.NoMoves = toMoveMatches(*search.None)
+	}
+	if search.Catalog != nil {
+		f.Catalog = *search.Catalog
+	}
+	if search.Level != nil {
+		f.Level = string(*search.Level)
+	}
+	if search.MinDuration != nil {
+		f.MinDuration = *search.MinDuration
+	}
+	if search.MaxDuration != nil {
+		f.MaxDuration = *search.MaxDuration
+	}
+	if search.Sort != nil {
+		f.Sort = string(*search.Sort)
+	}
+	f.Ascending = search.Order != nil && *search.Order == WodSearchOrderAsc
+}
+
+func toMoveMatches(moves []MoveMatch) []repository.MoveMatch {
+	out := make([]repository.MoveMatch, len(moves))
+	for i, m := range moves {
+		out[i].Name = m.Move
+		if m.Params != nil {
+			out[i].Params = make(map[string]repository.ParamRange, len(*m.Params))
+			for k, r := range *m.Params {
+				out[i].Params[k] = repository.ParamRange{Min: r.Min, Max: r.Max}
+			}
 		}
 	}
+	return out
+}
+
+// ownerFilter scopes the wods listed to the caller's own. Admins may list
+// another owner's, or everyone's with owner "*"; ok is false when a non-admin
+// asks for them.
+func ownerFilter(ctx context.Context, owner *string) (repository.WodFilter, bool) {
+	sub := pkg.Subject(ctx)
+	switch {
+	case owner == nil || *owner == sub:
+		return repository.WodFilter{Owner: sub}, true
+	case !pkg.IsAdmin(ctx):
+		return repository.WodFilter{}, false
+	case *owner == "*":
+		return repository.WodFilter{AnyOwner: true}, true
+	default:
+		return repository.WodFilter{Owner: *owner}, true
+	}
+}
 
-	return &ListWods200JSONResponse{Wods: &resp}, nil
+func (server *Server) toWod(ctx context.Context, w models.Wod, loc string, expand bool) Wod {
+	var annotations *Annotations
+	if w.Annotations != nil {
+		a := toAnnotations(*w.Annotations)
+		annotations = &a
+	}
+	return Wod{
+		Id:               w.ID,
+		Seed:             w.Seed,
+		CreatedAt:        w.CreatedAt,
+		Level:            WodLevel(w.Level),
+		DurationMin:      w.DurationMin,
+		Equipment:        &w.Equipment,
+		Blocks:           server.toBlocks(ctx, w, loc, expand),
+		GeneratorVersion: "v1",
+		Catalog:          w.Catalog,
+		CatalogVersion:   w.CatalogVersion,
+		CatalogHash:      w.CatalogHash,
+		Owner:            &w.OwnerSub,
+		Annotations:      annotations,
+	}
 }
@@ -3,42 +3,111 @@ package handlers_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
 	"github.com/LinaKACI-pro/wod-gen/internal/handlers"
 	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 type mockWodGenerator struct {
-	wod models.Wod
-	err error
+	wod      models.Wod
+	replayed bool
+	err      error
+	params   core.Params
+	// items answer GenerateBatch, batch records its params.
+	items []core.BatchItem
+	batch []core.Params
 }
 
-func (m *mockWodGenerator) Generate(ctx context.Context, level string, durationMin int, equipment []string, seed *string) (models.Wod, error) {
+func (m *mockWodGenerator) Generate(ctx context.Context, p core.Params) (models.Wod, bool, error) {
+	m.params = p
 	if m.err != nil {
-		return models.Wod{}, m.err
+		return models.Wod{}, false, m.err
 	}
-	return m.wod, nil
+	return m.wod, m.replayed, nil
+}
+
+func (m *mockWodGenerator) GenerateBatch(ctx context.Context, ps []core.Params) ([]core.BatchItem, error) {
+	m.batch = ps
+	return m.items, m.err
 }
 
 type mockWodList struct {
-	wods []models.Wod
-	err  error
+	wods   []models.Wod
+	next   string
+	err    error
+	filter repository.WodFilter
+	page   core.Page
+	patch  core.AnnotationPatch
+}
+
+func (m *mockWodList) List(ctx context.Context, f repository.WodFilter, p core.Page) (core.Listing, error) {
+	m.filter, m.page = f, p
+	if m.err != nil {
+		return core.Listing{}, m.err
+	}
+	return core.Listing{Wods: m.wods, NextCursor: m.next}, nil
 }
 
-func (m *mockWodList) List(ctx context.Context, limit, offset int) ([]models.Wod, error) {
+func (m *mockWodList) Annotate(ctx context.Context, id uuid.UUID, subject string, patch core.AnnotationPatch) (models.Annotations, error) {
+	m.patch = patch
 	if m.err != nil {
-		return nil, m.err
+		return models.Annotations{}, m.err
+	}
+	a := models.Annotations{Labels: []string{}}
+	if patch.Favorite != nil {
+		a.Favorite = *patch.Favorite
+	}
+	if patch.Note != nil {
+		a.Note = *patch.Note
+	}
+	if patch.Labels != nil {
+		a.Labels = *patch.Labels
 	}
-	return m.wods, nil
+	return a, nil
+}
+
+func (m *mockWodList) Get(ctx context.Context, id uuid.UUID, f repository.WodFilter) (models.Wod, error) {
+	m.filter = f
+	if m.err != nil {
+		return models.Wod{}, m.err
+	}
+	for _, w := range m.wods {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return models.Wod{}, common.ErrWodNotFound
+}
+
+// newTestServer serves s, with empty generator, list and catalog mocks
+// where s has none.
+func newTestServer(s handlers.Services) *handlers.Server {
+	if s.WodGenerate == nil {
+		s.WodGenerate = &mockWodGenerator{}
+	}
+	if s.WodList == nil {
+		s.WodList = &mockWodList{}
+	}
+	if s.Catalog == nil {
+		s.Catalog = &mockCatalogManager{}
+	}
+	return handlers.NewServer(s)
 }
 
 func TestGenerateWod_MissingBody(t *testing.T) {
-	s := handlers.NewServer(&mockWodGenerator{}, &mockWodList{})
+	s := newTestServer(handlers.Services{})
 
 	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: nil})
 	require.NoError(t, err)
@@ -57,9 +126,10 @@ func TestGenerateWod_Success(t *testing.T) {
 		Equipment:   []string{"rower"},
 		Seed:        "seed123",
 		Blocks:      []models.Block{{Name: "Run", Params: map[string]interface{}{"meters": 200}}},
+		OwnerSub:    "athlete-1",
 	}
 
-	s := handlers.NewServer(&mockWodGenerator{wod: mockWod}, &mockWodList{})
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{wod: mockWod}})
 
 	body := handlers.GenerateWodJSONRequestBody{
 		Level:       "beginner",
@@ -69,12 +139,41 @@ func TestGenerateWod_Success(t *testing.T) {
 	require.NoError(t, err)
 
 	r := resp.(*handlers.GenerateWod200JSONResponse)
-	require.Equal(t, "beginner", string(r.Level))
-	require.NotEmpty(t, r.Blocks)
+	require.Equal(t, "beginner", string(r.Body.Level))
+	require.NotEmpty(t, r.Body.Blocks)
+	require.Equal(t, "athlete-1", *r.Body.Owner)
+}
+
+func TestGenerateWod_IdempotencyKey(t *testing.T) {
+	gen := &mockWodGenerator{wod: models.Wod{ID: uuid.New(), Level: "beginner"}, replayed: true}
+	s := newTestServer(handlers.Services{WodGenerate: gen})
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/wod/generate", nil)
+	c.Request.Header.Set("Idempotency-Key", "retry-1")
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20}
+	resp, err := s.GenerateWod(c, handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod200JSONResponse)
+	require.True(t, r.Headers.IdempotentReplayed)
+	require.Equal(t, gen.wod.ID, r.Body.Id)
+	require.Equal(t, "retry-1", gen.params.IdempotencyKey)
+}
+
+func TestGenerateWod_IdempotencyConflict(t *testing.T) {
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: common.ErrIdempotencyConflict}})
+
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20}
+	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod409JSONResponse)
+	require.Equal(t, http.StatusConflict, r.Code)
 }
 
 func TestGenerateWod_ErrorKnown_InvalidData(t *testing.T) {
-	s := handlers.NewServer(&mockWodGenerator{err: common.InvalidDataError{DataType: "level", Data: "bad"}}, &mockWodList{})
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: common.InvalidDataError{DataType: "level", Data: "bad"}}})
 
 	body := handlers.GenerateWodJSONRequestBody{
 		Level:       "wrong",
@@ -88,8 +187,47 @@ func TestGenerateWod_ErrorKnown_InvalidData(t *testing.T) {
 	require.Contains(t, r.Message, "level")
 }
 
+func TestGenerateWod_NamedCatalog(t *testing.T) {
+	gen := &mockWodGenerator{wod: models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "running", CatalogVersion: 3}}
+	s := newTestServer(handlers.Services{WodGenerate: gen})
+
+	name := "running"
+	body := handlers.GenerateWodJSONRequestBody{Catalog: &name, Level: "beginner", DurationMin: 20}
+	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod200JSONResponse)
+	require.Equal(t, "running", gen.params.Catalog)
+	require.Equal(t, "running", r.Body.Catalog)
+	require.Equal(t, int64(3), r.Body.CatalogVersion)
+}
+
+func TestGenerateWod_Tenant(t *testing.T) {
+	gen := &mockWodGenerator{wod: models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "hyrox"}}
+	cm := &mockCatalogManager{catalog: testCatalog()}
+	s := newTestServer(handlers.Services{WodGenerate: gen, Catalog: cm})
+
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20}
+	_, err := s.GenerateWod(ctxWithTenant("box-lyon"), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+	require.Equal(t, "box-lyon", gen.params.Tenant)
+	require.Equal(t, "box-lyon", cm.tenant)
+}
+
+func TestGenerateWod_UnknownCatalog(t *testing.T) {
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: common.ErrUnknownCatalog}})
+
+	name := "yoga"
+	body := handlers.GenerateWodJSONRequestBody{Catalog: &name, Level: "beginner", DurationMin: 20}
+	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod400JSONResponse)
+	require.Equal(t, 400, r.Code)
+}
+
 func TestGenerateWod_ErrorKnown_NoMoves(t *testing.T) {
-	s := handlers.NewServer(&mockWodGenerator{err: common.ErrNoMoves}, &mockWodList{})
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: common.ErrNoMoves}})
 
 	body := handlers.GenerateWodJSONRequestBody{
 		Level:       "beginner",
@@ -103,8 +241,71 @@ func TestGenerateWod_ErrorKnown_NoMoves(t *testing.T) {
 	require.Equal(t, common.ErrNoMoves.Error(), r.Message)
 }
 
+func TestGenerateWod_Localized(t *testing.T) {
+	c := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{
+		ID: "sled-push", Name: "Sled Push", Weight: 1,
+		Locales: map[string]catalog.Translation{"fr": {Name: "Poussée de traîneau"}},
+	}}}
+	wod := models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "hyrox", Blocks: []models.Block{
+		{ID: "sled-push", Name: "Sled Push"},
+		{Name: "Sled Push"}, // stored before moves had an id
+		{ID: "retired", Name: "Retired Move"},
+	}}
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{wod: wod}, Catalog: &mockCatalogManager{catalog: c}})
+
+	fr := handlers.GenerateWodParamsLocaleFr
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20, Locale: &fr}
+	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod200JSONResponse)
+	require.Equal(t, "Poussée de traîneau", *r.Body.Blocks[0].Name)
+	require.Equal(t, "sled-push", *r.Body.Blocks[0].Id)
+	require.Equal(t, "Poussée de traîneau", *r.Body.Blocks[1].Name)
+	require.Equal(t, "sled-push", *r.Body.Blocks[1].Id)
+	require.Equal(t, "Retired Move", *r.Body.Blocks[2].Name)
+}
+
+func TestGenerateWod_ExpandMoves(t *testing.T) {
+	c := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{
+		ID: "row", Name: "Row", Weight: 1, Description: "Row hard", Cues: []string{"Legs first"},
+		Muscles: []string{"quads"}, Pattern: catalog.PatternFullBody, Units: map[string]string{"meters": "m"},
+		Locales: map[string]catalog.Translation{"fr": {Cues: []string{"Les jambes d'abord"}}},
+	}}}
+	wod := models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "hyrox", Blocks: []models.Block{{ID: "row", Name: "Row"}}}
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{wod: wod}, Catalog: &mockCatalogManager{catalog: c}})
+
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20}
+	resp, err := s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+	require.Nil(t, resp.(*handlers.GenerateWod200JSONResponse).Body.Blocks[0].Move)
+
+	fr := handlers.GenerateWodParamsLocaleFr
+	body = handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 20, Locale: &fr, Expand: &[]string{"moves"}}
+	resp, err = s.GenerateWod(context.Background(), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	move := resp.(*handlers.GenerateWod200JSONResponse).Body.Blocks[0].Move
+	require.NotNil(t, move)
+	require.Equal(t, "Row hard", *move.Description)
+	require.Equal(t, []string{"Les jambes d'abord"}, *move.Cues)
+	require.Equal(t, "full-body", *move.Pattern)
+	require.Equal(t, "m", (*move.Units)["meters"])
+}
+
+func TestGenerateWod_LocalizedError(t *testing.T) {
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: common.ErrDuration}})
+
+	body := handlers.GenerateWodJSONRequestBody{Level: "beginner", DurationMin: 5}
+	resp, err := s.GenerateWod(ctxWithLocale("fr"), handlers.GenerateWodRequestObject{Body: &body})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GenerateWod400JSONResponse)
+	require.Equal(t, "duration_min doit être compris entre 15 et 120", r.Message)
+}
+
 func TestGenerateWod_ErrorUnknown_Internal(t *testing.T) {
-	s := handlers.NewServer(&mockWodGenerator{err: errors.New("unexpected failure")}, &mockWodList{})
+	s := newTestServer(handlers.Services{WodGenerate: &mockWodGenerator{err: errors.New("unexpected failure")}})
 
 	body := handlers.GenerateWodJSONRequestBody{
 		Level:       "beginner",
@@ -128,7 +329,7 @@ func TestListWods_Success(t *testing.T) {
 		Seed:        "seed123",
 		Blocks:      []models.Block{{Name: "Run", Params: map[string]interface{}{"meters": 200}}},
 	}
-	s := handlers.NewServer(&mockWodGenerator{}, &mockWodList{wods: []models.Wod{mockWod}})
+	s := newTestServer(handlers.Services{WodList: &mockWodList{wods: []models.Wod{mockWod}}})
 
 	resp, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{})
 	require.NoError(t, err)
@@ -138,8 +339,102 @@ func TestListWods_Success(t *testing.T) {
 	require.Equal(t, "beginner", string((*r.Wods)[0].Level))
 }
 
+func TestListWods_FilterByCatalogVersion(t *testing.T) {
+	list := &mockWodList{wods: []models.Wod{{ID: uuid.New(), Catalog: "running", CatalogVersion: 3, CatalogHash: "abc"}}}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	name, version := "running", int64(3)
+	resp, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{
+		Catalog: &name, CatalogVersion: &version,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Catalog: "running", CatalogVersion: 3}, list.filter)
+
+	r := resp.(*handlers.ListWods200JSONResponse)
+	require.Equal(t, "abc", (*r.Wods)[0].CatalogHash)
+}
+
+func TestListWods_Owner(t *testing.T) {
+	list := &mockWodList{}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	_, err := s.ListWods(ctxWithSubject("alice", ""), handlers.ListWodsRequestObject{})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Owner: "alice", Annotator: "alice"}, list.filter, "scoped to the caller by default")
+
+	bob, all := "bob", "*"
+	resp, err := s.ListWods(ctxWithSubject("alice", ""), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{Owner: &bob}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.ListWods403JSONResponse{}, resp)
+
+	_, err = s.ListWods(ctxWithSubject("root", "admin"), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{Owner: &bob}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Owner: "bob", Annotator: "root"}, list.filter)
+
+	_, err = s.ListWods(ctxWithSubject("root", "admin"), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{Owner: &all}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{AnyOwner: true, Annotator: "root"}, list.filter)
+}
+
+func TestListWods_Cursor(t *testing.T) {
+	list := &mockWodList{wods: []models.Wod{{ID: uuid.New()}}, next: "abc"}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	cursor, total := "xyz", true
+	resp, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{
+		Cursor: &cursor, IncludeTotal: &total,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, core.Page{Limit: 10, Cursor: "xyz", Total: true}, list.page)
+
+	r := resp.(*handlers.ListWods200JSONResponse)
+	require.Equal(t, "abc", *r.NextCursor)
+	require.Nil(t, r.PrevCursor, "omitted at the ends")
+}
+
+func TestListWods_Filters(t *testing.T) {
+	list := &mockWodList{}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	level, minD, move := handlers.ListWodsParamsLevelBeginner, 20, "Row"
+	equipment, match := []string{"rower"}, handlers.ListWodsParamsEquipmentMatchAny
+	sort, order := handlers.ListWodsParamsSortDuration, handlers.ListWodsParamsOrderAsc
+	_, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{
+		Level: &level, MinDuration: &minD, Equipment: &equipment, EquipmentMatch: &match, Move: &move, Sort: &sort, Order: &order,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{
+		Level: "beginner", MinDuration: 20, Equipment: []string{"rower"}, AnyEquipment: true,
+		MoveName: "Row", Sort: "duration", Ascending: true,
+	}, list.filter)
+}
+
+func TestListWods_InvalidFilter(t *testing.T) {
+	err := fmt.Errorf("%w: duration range 40-20", common.ErrWodFilter)
+	s := newTestServer(handlers.Services{WodList: &mockWodList{err: err}})
+
+	resp, rerr := s.ListWods(context.Background(), handlers.ListWodsRequestObject{})
+	require.NoError(t, rerr)
+
+	r := resp.(*handlers.ListWods400JSONResponse)
+	require.Equal(t, "invalid wod filter: duration range 40-20", r.Message)
+}
+
+func TestListWods_ExpandMoves(t *testing.T) {
+	c := &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{{ID: "run", Name: "Run", Weight: 1, Muscles: []string{"calves"}}}}
+	wod := models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "hyrox", Blocks: []models.Block{{ID: "run", Name: "Run"}}}
+	s := newTestServer(handlers.Services{WodList: &mockWodList{wods: []models.Wod{wod}}, Catalog: &mockCatalogManager{catalog: c}})
+
+	expand := []string{"moves"}
+	resp, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{Params: handlers.ListWodsParams{Expand: &expand}})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.ListWods200JSONResponse)
+	require.Equal(t, []string{"calves"}, *(*r.Wods)[0].Blocks[0].Move.Muscles)
+}
+
 func TestListWods_ErrorFromRepo(t *testing.T) {
-	s := handlers.NewServer(&mockWodGenerator{}, &mockWodList{err: errors.New("db fail")})
+	s := newTestServer(handlers.Services{WodList: &mockWodList{err: errors.New("db fail")}})
 
 	resp, err := s.ListWods(context.Background(), handlers.ListWodsRequestObject{})
 	require.NoError(t, err)
@@ -147,3 +442,106 @@ func TestListWods_ErrorFromRepo(t *testing.T) {
 	r := resp.(*handlers.ListWods500JSONResponse)
 	require.Equal(t, 500, r.Code)
 }
+
+func TestGetWod_Success(t *testing.T) {
+	wod := models.Wod{ID: uuid.New(), Level: "beginner", Catalog: "hyrox", Blocks: []models.Block{{Name: "Run"}}}
+	s := newTestServer(handlers.Services{WodList: &mockWodList{wods: []models.Wod{wod}}})
+
+	resp, err := s.GetWod(context.Background(), handlers.GetWodRequestObject{Id: wod.ID})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetWod200JSONResponse)
+	require.Equal(t, wod.ID, r.Id)
+	require.Equal(t, "Run", *r.Blocks[0].Name)
+}
+
+func TestGetWod_ScopedToCaller(t *testing.T) {
+	list := &mockWodList{}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	_, err := s.GetWod(ctxWithSubject("alice", ""), handlers.GetWodRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{Owner: "alice", Annotator: "alice"}, list.filter)
+
+	_, err = s.GetWod(ctxWithSubject("root", "admin"), handlers.GetWodRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.True(t, list.filter.AnyOwner, "admins fetch any wod")
+}
+
+func TestGetWod_NotFound(t *testing.T) {
+	s := newTestServer(handlers.Services{})
+
+	resp, err := s.GetWod(ctxWithLocale("fr"), handlers.GetWodRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+
+	r := resp.(*handlers.GetWod404JSONResponse)
+	require.Equal(t, 404, r.Code)
+	require.Equal(t, "WOD introuvable", r.Message)
+}
+
+func TestGetWod_ErrorFromRepo(t *testing.T) {
+	s := newTestServer(handlers.Services{WodList: &mockWodList{err: errors.New("db fail")}})
+
+	resp, err := s.GetWod(context.Background(), handlers.GetWodRequestObject{Id: uuid.New()})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.GetWod500JSONResponse{}, resp)
+}
+
+func TestSearchWods_Success(t *testing.T) {
+	list := &mockWodList{wods: []models.Wod{{ID: uuid.New()}}, next: "abc"}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	meters, limit := 2000.0, 5
+	level, order := handlers.WodSearchLevelBeginner, handlers.WodSearchOrderAsc
+	resp, err := s.SearchWods(ctxWithSubject("alice", ""), handlers.SearchWodsRequestObject{Body: &handlers.WodSearch{
+		All: &[]handlers.MoveMatch{
+			{Move: "Sled Push"},
+			{Move: "Row", Params: &map[string]handlers.ParamRange{"meters": {Min: &meters}}},
+		},
+		None:  &[]handlers.MoveMatch{{Move: "Burpees"}},
+		Level: &level,
+		Order: &order,
+		Limit: &limit,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, repository.WodFilter{
+		Owner:     "alice",
+		Annotator: "alice",
+		Level:     "beginner",
+		Ascending: true,
+		AllMoves: []repository.MoveMatch{
+			{Name: "Sled Push"},
+			{Name: "Row", Params: map[string]repository.ParamRange{"meters": {Min: &meters}}},
+		},
+		NoMoves: []repository.MoveMatch{{Name: "Burpees"}},
+	}, list.filter)
+	require.Equal(t, core.Page{Limit: 5}, list.page)
+
+	r := resp.(*handlers.SearchWods200JSONResponse)
+	require.Len(t, *r.Wods, 1)
+	require.Equal(t, "abc", *r.NextCursor)
+}
+
+func TestSearchWods_Errors(t *testing.T) {
+	list := &mockWodList{}
+	s := newTestServer(handlers.Services{WodList: list})
+
+	resp, err := s.SearchWods(ctxWithSubject("alice", ""), handlers.SearchWodsRequestObject{})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.SearchWods400JSONResponse{}, resp)
+
+	bob := "bob"
+	resp, err = s.SearchWods(ctxWithSubject("alice", ""), handlers.SearchWodsRequestObject{Body: &handlers.WodSearch{Owner: &bob}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.SearchWods403JSONResponse{}, resp)
+
+	list.err = fmt.Errorf("%w: empty move", common.ErrWodFilter)
+	resp, err = s.SearchWods(ctxWithSubject("alice", ""), handlers.SearchWodsRequestObject{Body: &handlers.WodSearch{}})
+	require.NoError(t, err)
+	require.Equal(t, "invalid wod filter: empty move", resp.(*handlers.SearchWods400JSONResponse).Message)
+
+	list.err = errors.New("db down")
+	resp, err = s.SearchWods(ctxWithSubject("alice", ""), handlers.SearchWodsRequestObject{Body: &handlers.WodSearch{}})
+	require.NoError(t, err)
+	require.IsType(t, &handlers.SearchWods500JSONResponse{}, resp)
+}
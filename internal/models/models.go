@@ -7,6 +7,8 @@ import (
 )
 
 type Block struct {
+	// ID of the catalog move, stable across renames and translations.
+	ID     string                 `json:"id,omitempty"`
 	Name   string                 `json:"name"`
 	Params map[string]interface{} `json:"params,omitempty"`
 }
@@ -19,4 +21,110 @@ type Wod struct {
 	Equipment   []string  `json:"equipment,omitempty"`
 	Seed        string    `json:"seed"`
 	Blocks      []Block   `json:"blocks"`
+	// Catalog the blocks were picked from, its version and content hash at
+	// the time.
+	Catalog        string `json:"catalog"`
+	CatalogVersion int64  `json:"catalog_version"`
+	CatalogHash    string `json:"catalog_hash"`
+	// OwnerSub is the JWT subject of the caller who generated the wod.
+	OwnerSub string `json:"owner_sub"`
+	// IdempotencyKey the wod was generated with, unique per owner.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// Annotations of the owner, set when the owner reads the wod. They are
+	// not stored with it.
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// Annotations are what a subject keeps on a wod for themselves.
+type Annotations struct {
+	Favorite bool     `json:"favorite"`
+	Note     string   `json:"note,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+// CalendarEntry schedules a wod on a day for a subject. The entries
+// scheduled together as a program share its name.
+type CalendarEntry struct {
+	ID      uuid.UUID `json:"id"`
+	Subject string    `json:"subject"`
+	WodID   uuid.UUID `json:"wod_id"`
+	// Date is the day of the session, at midnight UTC.
+	Date      time.Time `json:"date"`
+	Program   string    `json:"program,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Split is the time an athlete took on one block of a wod.
+type Split struct {
+	// Block is the position of the block in the wod, from 1.
+	Block   int `json:"block"`
+	TimeSec int `json:"time_sec"`
+}
+
+// Result is how an athlete did on a wod, scored by TimeSec, by Rounds and
+// the Reps of the unfinished round, or by LoadKg.
+type Result struct {
+	ID      uuid.UUID `json:"id"`
+	WodID   uuid.UUID `json:"wod_id"`
+	Subject string    `json:"subject"`
+	TimeSec *int      `json:"time_sec,omitempty"`
+	Rounds  *int      `json:"rounds,omitempty"`
+	Reps    *int      `json:"reps,omitempty"`
+	LoadKg  *float64  `json:"load_kg,omitempty"`
+	// Division the athlete competes in on leaderboards, e.g. rx or scaled.
+	Division    string    `json:"division,omitempty"`
+	Splits      []Split   `json:"splits,omitempty"`
+	RPE         *int      `json:"rpe,omitempty"`
+	Scaling     string    `json:"scaling,omitempty"`
+	CompletedAt time.Time `json:"completed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Stats aggregates the wods generated for a subject, or for everyone, and
+// the results they logged.
+type Stats struct {
+	Wods    int `json:"wods"`
+	Results int `json:"results"`
+	// Moves by the blocks they were picked for, most picked first.
+	Moves []MoveStat `json:"moves"`
+	// Periods are the weeks or months with wods or results, oldest first.
+	Periods   []PeriodStat   `json:"periods"`
+	Levels    []CountStat    `json:"levels"`
+	Durations []DurationStat `json:"durations"`
+	Equipment []CountStat    `json:"equipment"`
+}
+
+// MoveStat counts the blocks of a move and the wods it appears in.
+type MoveStat struct {
+	// ID is empty for the blocks stored before moves had IDs.
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Blocks int    `json:"blocks"`
+	Wods   int    `json:"wods"`
+}
+
+// PeriodStat sums the wods created in a week or month, and the results
+// completed in it.
+type PeriodStat struct {
+	// Start is the first day of the period, a Monday for weeks, at midnight UTC.
+	Start   time.Time `json:"start"`
+	Wods    int       `json:"wods"`
+	Results int       `json:"results"`
+	// Meters and Reps sum the params of the blocks of the wods.
+	Meters float64 `json:"meters"`
+	Reps   float64 `json:"reps"`
+}
+
+// CountStat counts the wods with a level or a piece of equipment.
+type CountStat struct {
+	Key  string `json:"key"`
+	Wods int    `json:"wods"`
+}
+
+// DurationStat counts the wods lasting DurationMin minutes.
+type DurationStat struct {
+	DurationMin int `json:"duration_min"`
+	Wods        int `json:"wods"`
 }
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AnnotationRepositoryInterface stores the annotations of subjects on wods.
+// The wod repositories implement it, their listings filter on annotations.
+type AnnotationRepositoryInterface interface {
+	// GetAnnotations returns the annotations of subject on the wods ids, the
+	// wods without any are left out.
+	GetAnnotations(ctx context.Context, subject string, ids []uuid.UUID) (map[uuid.UUID]models.Annotations, error)
+	// SaveAnnotations replaces the annotations of subject on the wod id.
+	SaveAnnotations(ctx context.Context, subject string, id uuid.UUID, a models.Annotations) error
+}
+
+func (r *WodRepository) GetAnnotations(ctx context.Context, subject string, ids []uuid.UUID) (map[uuid.UUID]models.Annotations, error) {
+	in := make([]string, len(ids))
+	for i, id := range ids {
+		in[i] = id.String()
+	}
+	return queryAnnotations(ctx, r.db, `
+		SELECT wod_id, 'favorite', '' FROM wod_favorites WHERE subject = $1 AND wod_id = ANY($2::uuid[])
+		UNION ALL
+		SELECT wod_id, 'note', body FROM wod_notes WHERE subject = $1 AND wod_id = ANY($2::uuid[])
+		UNION ALL
+		SELECT wod_id, 'label', label FROM wod_labels WHERE subject = $1 AND wod_id = ANY($2::uuid[])
+		ORDER BY 3
+	`, subject, pq.Array(in))
+}
+
+func (r *WodRepository) SaveAnnotations(ctx context.Context, subject string, id uuid.UUID, a models.Annotations) error {
+	return saveAnnotations(ctx, r.db, a, time.Now().UTC(), annotationStatements{
+		deleteFavorite: `DELETE FROM wod_favorites WHERE subject = $1 AND wod_id = $2`,
+		insertFavorite: `INSERT INTO wod_favorites (subject, wod_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		deleteNote:     `DELETE FROM wod_notes WHERE subject = $1 AND wod_id = $2`,
+		upsertNote: `INSERT INTO wod_notes (subject, wod_id, body, updated_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (subject, wod_id) DO UPDATE SET body = excluded.body, updated_at = excluded.updated_at`,
+		deleteLabels: `DELETE FROM wod_labels WHERE subject = $1 AND wod_id = $2`,
+		insertLabel:  `INSERT INTO wod_labels (subject, wod_id, label) VALUES ($1, $2, $3)`,
+	}, subject, id, func(t time.Time) any { return t })
+}
+
+// annotationStatements are the statements saveAnnotations runs, in the
+// placeholder style of the database. Their args are the subject, the wod id,
+// then the value and time.
+type annotationStatements struct {
+	deleteFavorite, insertFavorite string
+	deleteNote, upsertNote         string
+	deleteLabels, insertLabel      string
+}
+
+// saveAnnotations replaces the annotations of subject on the wod id in one
+// transaction, timestamps passed as t(ts).
+func saveAnnotations(ctx context.Context, db *sql.DB, a models.Annotations, now time.Time, st annotationStatements,
+	subject string, id uuid.UUID, t func(time.Time) any,
+) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.Warn("failed to rollback: ", slog.Any("err", err))
+		}
+	}()
+
+	wodID := id.String()
+	exec := func(query string, args ...any) error {
+		if _, err := tx.ExecContext(ctx, query, append([]any{subject, wodID}, args...)...); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return nil
+	}
+	if a.Favorite {
+		err = exec(st.insertFavorite, t(now))
+	} else {
+		err = exec(st.deleteFavorite)
+	}
+	if err != nil {
+		return err
+	}
+	if a.Note != "" {
+		err = exec(st.upsertNote, a.Note, t(now))
+	} else {
+		err = exec(st.deleteNote)
+	}
+	if err != nil {
+		return err
+	}
+	if err := exec(st.deleteLabels); err != nil {
+		return err
+	}
+	for _, label := range a.Labels {
+		if err := exec(st.insertLabel, label); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// queryAnnotations runs a query of (wod id, kind, value) rows and groups
+// them by wod, labels in the order of the rows.
+func queryAnnotations(ctx context.Context, db *sql.DB, query string, args ...any) (map[uuid.UUID]models.Annotations, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	out := map[uuid.UUID]models.Annotations{}
+	for rows.Next() {
+		var rawID, kind, value string
+		if err := rows.Scan(&rawID, &kind, &value); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("uuid.Parse: %w", err)
+		}
+		a := out[id]
+		switch kind {
+		case "favorite":
+			a.Favorite = true
+		case "note":
+			a.Note = value
+		case "label":
+			a.Labels = append(a.Labels, value)
+		}
+		out[id] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return out, nil
+}
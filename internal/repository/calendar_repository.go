@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+type CalendarRepositoryInterface interface {
+	// SaveEntries inserts entries, all of them or none.
+	SaveEntries(ctx context.Context, entries []models.CalendarEntry) error
+	// UpdateEntry replaces the entry e.ID of e.Subject,
+	// common.ErrCalendarEntryNotFound when there is none.
+	UpdateEntry(ctx context.Context, e models.CalendarEntry) error
+	// DeleteEntries deletes the entries f matches and returns how many.
+	DeleteEntries(ctx context.Context, f CalendarFilter) (int, error)
+	ListEntries(ctx context.Context, f CalendarFilter, limit, offset int) ([]models.CalendarEntry, error)
+
+	// SaveFeed sets the sha256 of the token reading the feed of subject,
+	// the previous token stops working.
+	SaveFeed(ctx context.Context, subject, tokenHash string, createdAt time.Time) error
+	DeleteFeed(ctx context.Context, subject string) error
+	// FeedSubject returns the subject whose feed is read with the token
+	// hashed to tokenHash, common.ErrCalendarFeedNotFound when none is.
+	FeedSubject(ctx context.Context, tokenHash string) (string, error)
+}
+
+// calendarDay is how the days of entries are passed to and compared in SQL.
+const calendarDay = "2006-01-02"
+
+const calendarColumns = "id, subject, wod_id, day, program, note, created_at, updated_at"
+
+// CalendarFilter narrows the entries of Subject, the zero values of the
+// other fields match everything. Entries are listed by day, then in the
+// order they were scheduled.
+type CalendarFilter struct {
+	Subject string
+	ID      uuid.UUID
+	Program string
+	// From is inclusive, To exclusive, both days at midnight UTC.
+	From time.Time
+	To   time.Time
+}
+
+// where renders f as a WHERE clause, with placeholder p(n) for the n-th arg.
+func (f CalendarFilter) where(p func(n int) string) (string, []any) {
+	conds := []string{"subject = " + p(1)}
+	args := []any{f.Subject}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, cond+p(len(args)))
+	}
+	if f.ID != uuid.Nil {
+		add("id = ", f.ID.String())
+	}
+	if f.Program != "" {
+		add("program = ", f.Program)
+	}
+	if !f.From.IsZero() {
+		add("day >= ", f.From.Format(calendarDay))
+	}
+	if !f.To.IsZero() {
+		add("day < ", f.To.Format(calendarDay))
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+func (r *CalendarRepository) SaveEntries(ctx context.Context, entries []models.CalendarEntry) error {
+	return saveEntries(ctx, r.db, `
+		INSERT INTO calendar_entries (`+calendarColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, entries, pgTime)
+}
+
+func (r *CalendarRepository) UpdateEntry(ctx context.Context, e models.CalendarEntry) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE calendar_entries SET wod_id = $3, day = $4, program = $5, note = $6, updated_at = $7
+		WHERE subject = $1 AND id = $2
+	`, e.Subject, e.ID, e.WodID, e.Date.Format(calendarDay), e.Program, e.Note, e.UpdatedAt)
+	return updated(res, err)
+}
+
+func (r *CalendarRepository) DeleteEntries(ctx context.Context, f CalendarFilter) (int, error) {
+	where, args := f.where(pgPlaceholder)
+	return deleteEntries(ctx, r.db, `DELETE FROM calendar_entries `+where, args)
+}
+
+func (r *CalendarRepository) ListEntries(ctx context.Context, f CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	where, args := f.where(pgPlaceholder)
+	args = append(args, limit, offset)
+	return queryEntries(ctx, r.db, fmt.Sprintf(`
+		SELECT %s
+		FROM calendar_entries
+		%s
+		ORDER BY day, created_at, id
+		LIMIT $%d OFFSET $%d
+	`, calendarColumns, where, len(args)-1, len(args)), args, scanEntry)
+}
+
+func (r *CalendarRepository) SaveFeed(ctx context.Context, subject, tokenHash string, createdAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO calendar_feeds (subject, token_hash, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (subject) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+	`, subject, tokenHash, createdAt)
+	if err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return nil
+}
+
+func (r *CalendarRepository) DeleteFeed(ctx context.Context, subject string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM calendar_feeds WHERE subject = $1`, subject); err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return nil
+}
+
+func (r *CalendarRepository) FeedSubject(ctx context.Context, tokenHash string) (string, error) {
+	return feedSubject(ctx, r.db, `SELECT subject FROM calendar_feeds WHERE token_hash = $1`, tokenHash)
+}
+
+// saveEntries runs the insert query of calendarColumns for every entry, in a
+// transaction, with timestamps passed as t(ts).
+func saveEntries(ctx context.Context, db *sql.DB, query string, entries []models.CalendarEntry, t func(time.Time) any) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.Warn("failed to rollback: ", slog.Any("err", err))
+		}
+	}()
+
+	for _, e := range entries {
+		_, err := tx.ExecContext(ctx, query, e.ID.String(), e.Subject, e.WodID.String(), e.Date.Format(calendarDay), e.Program,
+			e.Note, t(e.CreatedAt), t(e.UpdatedAt))
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// updated maps an update touching no row to common.ErrCalendarEntryNotFound.
+func updated(res sql.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %w", err)
+	}
+	if n == 0 {
+		return common.ErrCalendarEntryNotFound
+	}
+	return nil
+}
+
+func deleteEntries(ctx context.Context, db *sql.DB, query string, args []any) (int, error) {
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("db.ExecContext: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("res.RowsAffected: %w", err)
+	}
+	return int(n), nil
+}
+
+func feedSubject(ctx context.Context, db *sql.DB, query, tokenHash string) (string, error) {
+	var subject string
+	err := db.QueryRowContext(ctx, query, tokenHash).Scan(&subject)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", common.ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("db.QueryRowContext: %w", err)
+	}
+	return subject, nil
+}
+
+// queryEntries runs a query of calendarColumns and scans its rows with scan.
+func queryEntries(ctx context.Context, db *sql.DB, query string, args []any,
+	scan func(row interface{ Scan(dest ...any) error }) (models.CalendarEntry, error),
+) ([]models.CalendarEntry, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	var entries []models.CalendarEntry
+	for rows.Next() {
+		e, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return entries, nil
+}
+
+func scanEntry(row interface{ Scan(dest ...any) error }) (models.CalendarEntry, error) {
+	var e models.CalendarEntry
+	err := row.Scan(&e.ID, &e.Subject, &e.WodID, &e.Date, &e.Program, &e.Note, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	e.Date = e.Date.UTC()
+	return e, nil
+}
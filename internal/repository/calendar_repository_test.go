@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveEntries_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO calendar_entries").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO calendar_entries").WillReturnError(errors.New("db fail"))
+	mock.ExpectRollback()
+
+	repo := repository.NewCalendarRepository(db)
+	day := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	err := repo.SaveEntries(context.Background(), []models.CalendarEntry{
+		{ID: uuid.New(), WodID: uuid.New(), Date: day},
+		{ID: uuid.New(), WodID: uuid.New(), Date: day},
+	})
+
+	require.ErrorContains(t, err, "tx.ExecContext")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListEntries_Range(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	id, wodID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "subject", "wod_id", "day", "program", "note", "created_at", "updated_at"}).
+		AddRow(id, "alice", wodID, time.Date(2025, 9, 2, 0, 0, 0, 0, time.FixedZone("", 0)), "block-1", "", now, now)
+	mock.ExpectQuery(`FROM calendar_entries\s+WHERE subject = \$1 AND day >= \$2 AND day < \$3\s+ORDER BY day, created_at, id\s+LIMIT \$4 OFFSET \$5`).
+		WithArgs("alice", "2025-09-01", "2025-10-01", 20, 0).
+		WillReturnRows(rows)
+
+	repo := repository.NewCalendarRepository(db)
+	entries, err := repo.ListEntries(context.Background(), repository.CalendarFilter{
+		Subject: "alice",
+		From:    time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, 20, 0)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), entries[0].Date)
+	require.Equal(t, "block-1", entries[0].Program)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateEntry_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec("UPDATE calendar_entries").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repository.NewCalendarRepository(db)
+	err := repo.UpdateEntry(context.Background(), models.CalendarEntry{ID: uuid.New(), Subject: "bob"})
+
+	require.ErrorIs(t, err, common.ErrCalendarEntryNotFound)
+}
+
+func TestFeedSubject_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT subject FROM calendar_feeds WHERE token_hash = \$1`).
+		WithArgs("abc").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repository.NewCalendarRepository(db)
+	_, err := repo.FeedSubject(context.Background(), "abc")
+
+	require.ErrorIs(t, err, common.ErrCalendarFeedNotFound)
+}
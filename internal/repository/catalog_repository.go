@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/lib/pq"
+)
+
+const pqUniqueViolation = "23505"
+
+type CatalogRepositoryInterface interface {
+	LoadCatalog(ctx context.Context, name string) (*catalog.Catalog, error)
+	CatalogVersions(ctx context.Context) (map[string]int64, error)
+	SeedCatalog(ctx context.Context, c *catalog.Catalog) (bool, error)
+	CreateMove(ctx context.Context, catalogName string, m catalog.Move) error
+	UpdateMove(ctx context.Context, catalogName, name string, m catalog.Move) error
+	DeleteMove(ctx context.Context, catalogName, name string) error
+	ReplaceCatalog(ctx context.Context, c *catalog.Catalog) error
+}
+
+type CatalogRepository struct {
+	db *sql.DB
+}
+
+func NewCatalogRepository(db *sql.DB) *CatalogRepository {
+	return &CatalogRepository{db: db}
+}
+
+// LoadCatalog reads a catalog and its moves in a single snapshot, so a
+// concurrent write cannot pair new moves with an old version.
+func (r *CatalogRepository) LoadCatalog(ctx context.Context, name string) (*catalog.Catalog, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	c := catalog.Catalog{Name: name}
+	err = tx.QueryRowContext(ctx, `SELECT levels, equipment, version FROM catalogs WHERE name = $1`, name).
+		Scan(pq.Array(&c.Levels), pq.Array(&c.Equipment), &c.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", common.ErrUnknownCatalog, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tx.QueryRowContext: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, name, description, cues, muscles, pattern, units, media,
+			locales, needs_one_of, tags, weight, ranges
+		FROM catalog_moves
+		WHERE catalog = $1
+		ORDER BY name
+	`, name)
+	if err != nil {
+		return nil, fmt.Errorf("tx.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	for rows.Next() {
+		var m catalog.Move
+		var raw moveJSON
+		err := rows.Scan(&m.ID, &m.Name, &m.Description, pq.Array(&m.Cues), pq.Array(&m.Muscles), &m.Pattern,
+			&raw.units, &raw.media, &raw.locales, pq.Array(&m.NeedsOneOf), pq.Array(&m.Tags), &m.Weight, &raw.ranges)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		if err := raw.unmarshal(&m); err != nil {
+			return nil, err
+		}
+		c.Moves = append(c.Moves, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return &c, nil
+}
+
+func (r *CatalogRepository) CatalogVersions(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT name, version FROM catalogs`)
+	if err != nil {
+		return nil, fmt.Errorf("db.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	versions := map[string]int64{}
+	for rows.Next() {
+		var name string
+		var v int64
+		if err := rows.Scan(&name, &v); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		versions[name] = v
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return versions, nil
+}
+
+// SeedCatalog stores c only when no catalog of that name exists yet and
+// reports whether it did.
+func (r *CatalogRepository) SeedCatalog(ctx context.Context, c *catalog.Catalog) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO catalogs (name, levels, equipment, version)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING
+	`, c.Name, pq.Array(c.Levels), pq.Array(c.Equipment), c.Version+1) // seeding counts as a write
+	if err != nil {
+		return false, fmt.Errorf("tx.ExecContext: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("res.RowsAffected: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	for _, m := range c.Moves {
+		if err := insertMove(ctx, tx, c.Name, m); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return true, nil
+}
+
+func (r *CatalogRepository) CreateMove(ctx context.Context, catalogName string, m catalog.Move) error {
+	return r.write(ctx, catalogName, func(tx *sql.Tx) error {
+		return insertMove(ctx, tx, catalogName, m)
+	})
+}
+
+func (r *CatalogRepository) UpdateMove(ctx context.Context, catalogName, name string, m catalog.Move) error {
+	raw, err := marshalMove(m)
+	if err != nil {
+		return err
+	}
+
+	return r.write(ctx, catalogName, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE catalog_moves
+			SET id = $3, name = $4, description = $5, cues = $6, muscles = $7, pattern = $8,
+				units = $9, media = $10, locales = $11, needs_one_of = $12, tags = $13,
+				weight = $14, ranges = $15, updated_at = now()
+			WHERE catalog = $1 AND name = $2
+		`, catalogName, name, m.Key(), m.Name, m.Description, pq.Array(m.Cues), pq.Array(m.Muscles), m.Pattern,
+			raw.units, raw.media, raw.locales, pq.Array(m.NeedsOneOf), pq.Array(m.Tags), m.Weight, raw.ranges)
+		if err != nil {
+			return uniqueViolation(fmt.Errorf("tx.ExecContext: %w", err))
+		}
+		return expectOneRow(res)
+	})
+}
+
+func (r *CatalogRepository) DeleteMove(ctx context.Context, catalogName, name string) error {
+	return r.write(ctx, catalogName, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM catalog_moves WHERE catalog = $1 AND name = $2`, catalogName, name)
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		return expectOneRow(res)
+	})
+}
+
+// ReplaceCatalog swaps the levels, equipment and moves of a stored catalog
+// for the ones of c at once.
+func (r *CatalogRepository) ReplaceCatalog(ctx context.Context, c *catalog.Catalog) error {
+	return r.write(ctx, c.Name, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE catalogs SET levels = $2, equipment = $3 WHERE name = $1`,
+			c.Name, pq.Array(c.Levels), pq.Array(c.Equipment))
+		if err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return fmt.Errorf("%w: %q", common.ErrUnknownCatalog, c.Name)
+		}
+
+		if _, err = tx.ExecContext(ctx, `DELETE FROM catalog_moves WHERE catalog = $1`, c.Name); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+		for _, m := range c.Moves {
+			if err := insertMove(ctx, tx, c.Name, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// write runs fn in a transaction and bumps the version of the catalog with it.
+func (r *CatalogRepository) write(ctx context.Context, catalogName string, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE catalogs SET version = version + 1, updated_at = now() WHERE name = $1`, catalogName)
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+func insertMove(ctx context.Context, tx *sql.Tx, catalogName string, m catalog.Move) error {
+	raw, err := marshalMove(m)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO catalog_moves (catalog, id, name, description, cues, muscles, pattern,
+			units, media, locales, needs_one_of, tags, weight, ranges)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, catalogName, m.Key(), m.Name, m.Description, pq.Array(m.Cues), pq.Array(m.Muscles), m.Pattern,
+		raw.units, raw.media, raw.locales, pq.Array(m.NeedsOneOf), pq.Array(m.Tags), m.Weight, raw.ranges)
+	if err != nil {
+		return uniqueViolation(fmt.Errorf("tx.ExecContext: %w", err))
+	}
+	return nil
+}
+
+// moveJSON holds the JSONB columns of a move.
+type moveJSON struct {
+	units, media, locales, ranges []byte
+}
+
+// marshalMove encodes the JSONB columns of m, empty values are stored as
+// empty objects and arrays rather than null.
+func marshalMove(m catalog.Move) (moveJSON, error) {
+	units, media, locales := m.Units, m.Media, m.Locales
+	if units == nil {
+		units = map[string]string{}
+	}
+	if media == nil {
+		media = []catalog.Media{}
+	}
+	if locales == nil {
+		locales = map[string]catalog.Translation{}
+	}
+
+	var raw moveJSON
+	for _, f := range []struct {
+		dst *[]byte
+		v   any
+	}{{&raw.units, units}, {&raw.media, media}, {&raw.locales, locales}, {&raw.ranges, m.Ranges}} {
+		b, err := json.Marshal(f.v)
+		if err != nil {
+			return moveJSON{}, fmt.Errorf("json.Marshal: %w", err)
+		}
+		*f.dst = b
+	}
+	return raw, nil
+}
+
+func (raw moveJSON) unmarshal(m *catalog.Move) error {
+	for _, f := range []struct {
+		src []byte
+		dst any
+	}{{raw.units, &m.Units}, {raw.media, &m.Media}, {raw.locales, &m.Locales}, {raw.ranges, &m.Ranges}} {
+		if err := json.Unmarshal(f.src, f.dst); err != nil {
+			return fmt.Errorf("json.Unmarshal: %w", err)
+		}
+	}
+	return nil
+}
+
+func expectOneRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %w", err)
+	}
+	if n == 0 {
+		return common.ErrMoveNotFound
+	}
+	return nil
+}
+
+func uniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return fmt.Errorf("%w: %w", common.ErrMoveExists, err)
+	}
+	return err
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		slog.Warn("tx.Rollback", slog.Any("err", err))
+	}
+}
@@ -0,0 +1,128 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/core/catalog"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCatalog_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT levels, equipment, version FROM catalogs").
+		WithArgs("hyrox").
+		WillReturnRows(sqlmock.NewRows([]string{"levels", "equipment", "version"}).AddRow(`{}`, `{rower}`, 3))
+	mock.ExpectQuery("SELECT id, name, description, cues, muscles, pattern, units, media").
+		WithArgs("hyrox").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "cues", "muscles", "pattern", "units", "media",
+			"locales", "needs_one_of", "tags", "weight", "ranges"}).
+			AddRow("row", "Row", "", `{"Legs first"}`, `{quads,lats}`, "full-body", `{"meters":"m"}`,
+				`[{"type":"video","url":"https://example.com/row.mp4"}]`,
+				`{"fr":{"name":"Rameur"}}`, `{rower}`, `{engine}`, 1.2, `{"beginner":{"meters":[400,900]}}`))
+	mock.ExpectRollback()
+
+	repo := repository.NewCatalogRepository(db)
+	c, err := repo.LoadCatalog(context.Background(), "hyrox")
+
+	require.NoError(t, err)
+	require.Equal(t, "hyrox", c.Name)
+	require.Equal(t, int64(3), c.Version)
+	require.Equal(t, []string{"rower"}, c.Equipment)
+	require.Len(t, c.Moves, 1)
+	require.Equal(t, []string{"rower"}, c.Moves[0].NeedsOneOf)
+	require.Equal(t, "Rameur", c.Moves[0].DisplayName("fr"))
+	require.Equal(t, catalog.Rng{400, 900}, c.Moves[0].Ranges["beginner"]["meters"])
+	require.Equal(t, []string{"quads", "lats"}, c.Moves[0].Muscles)
+	require.Equal(t, "m", c.Moves[0].Units["meters"])
+	require.Equal(t, catalog.MediaVideo, c.Moves[0].Media[0].Type)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMove_BumpsVersion(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO catalog_moves").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE catalogs SET version").WithArgs("hyrox").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewCatalogRepository(db)
+	err := repo.CreateMove(context.Background(), "hyrox", catalog.Move{Name: "Row", Weight: 1})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceCatalog_SwapsMoves(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE catalogs SET levels").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM catalog_moves").WithArgs("hyrox").WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec("INSERT INTO catalog_moves").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO catalog_moves").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE catalogs SET version").WithArgs("hyrox").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewCatalogRepository(db)
+	err := repo.ReplaceCatalog(context.Background(), &catalog.Catalog{Name: "hyrox", Moves: []catalog.Move{
+		{Name: "Row", Weight: 1}, {Name: "Run", Weight: 1},
+	}})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMove_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM catalog_moves").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := repository.NewCatalogRepository(db)
+	err := repo.DeleteMove(context.Background(), "hyrox", "Nope")
+
+	require.ErrorIs(t, err, common.ErrMoveNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadCatalog_Unknown(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT levels, equipment, version FROM catalogs").
+		WithArgs("yoga").
+		WillReturnRows(sqlmock.NewRows([]string{"levels", "equipment", "version"}))
+	mock.ExpectRollback()
+
+	repo := repository.NewCatalogRepository(db)
+	_, err := repo.LoadCatalog(context.Background(), "yoga")
+
+	require.ErrorIs(t, err, common.ErrUnknownCatalog)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSeedCatalog_AlreadyStored(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO catalogs").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := repository.NewCatalogRepository(db)
+	seeded, err := repo.SeedCatalog(context.Background(), &catalog.Catalog{
+		Name:  "running",
+		Moves: []catalog.Move{{Name: "Easy Run", Weight: 1}},
+	})
+
+	require.NoError(t, err)
+	require.False(t, seeded)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,908 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/db"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suites run against every backend, Postgres only when
+// WODGEN_TEST_DATABASE_URL points at a scratch database.
+
+func TestConformance_Memory(t *testing.T) {
+	testWodRepository(t, func(t *testing.T) repository.WodRepositoryInterface {
+		return repository.NewMemoryWodRepository()
+	})
+	testResultRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.ResultRepositoryInterface) {
+		return repository.NewMemoryWodRepository(), repository.NewMemoryResultRepository()
+	})
+	testAnnotationRepository(t, func(t *testing.T) annotatedRepository {
+		return repository.NewMemoryWodRepository()
+	})
+	testCalendarRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.CalendarRepositoryInterface) {
+		return repository.NewMemoryWodRepository(), repository.NewMemoryCalendarRepository()
+	})
+	testRetentionRepository(t, func(t *testing.T) retentionStores {
+		wods, results, calendar := repository.NewMemoryWodRepository(), repository.NewMemoryResultRepository(),
+			repository.NewMemoryCalendarRepository()
+		return retentionStores{wods, results, calendar, repository.NewMemoryRetentionRepository(wods, results, calendar)}
+	})
+	testStatsRepository(t, func(t *testing.T) statsStores {
+		wods, results := repository.NewMemoryWodRepository(), repository.NewMemoryResultRepository()
+		return statsStores{wods, results, repository.NewMemoryStatsRepository(wods, results)}
+	})
+}
+
+func TestConformance_SQLite(t *testing.T) {
+	testWodRepository(t, func(t *testing.T) repository.WodRepositoryInterface {
+		return repository.NewSQLiteWodRepository(openSQLite(t))
+	})
+	testResultRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.ResultRepositoryInterface) {
+		database := openSQLite(t)
+		return repository.NewSQLiteWodRepository(database), repository.NewSQLiteResultRepository(database)
+	})
+	testAnnotationRepository(t, func(t *testing.T) annotatedRepository {
+		return repository.NewSQLiteWodRepository(openSQLite(t))
+	})
+	testCalendarRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.CalendarRepositoryInterface) {
+		database := openSQLite(t)
+		return repository.NewSQLiteWodRepository(database), repository.NewSQLiteCalendarRepository(database)
+	})
+	testRetentionRepository(t, func(t *testing.T) retentionStores {
+		database := openSQLite(t)
+		return retentionStores{
+			repository.NewSQLiteWodRepository(database),
+			repository.NewSQLiteResultRepository(database),
+			repository.NewSQLiteCalendarRepository(database),
+			repository.NewSQLiteRetentionRepository(database),
+		}
+	})
+	testStatsRepository(t, func(t *testing.T) statsStores {
+		database := openSQLite(t)
+		return statsStores{repository.NewSQLiteWodRepository(database), repository.NewSQLiteResultRepository(database),
+			repository.NewSQLiteStatsRepository(database)}
+	})
+}
+
+func TestSQLite_ForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLite(t)
+	wods, results := repository.NewSQLiteWodRepository(database), repository.NewSQLiteResultRepository(database)
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0, Level: "beginner", DurationMin: 30, Equipment: []string{},
+		Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+	_, err := wods.SaveWod(ctx, w)
+	require.NoError(t, err)
+	sec := 900
+	_, err = results.SaveResult(ctx, models.Result{ID: uuid.New(), WodID: w.ID, Subject: "bob", TimeSec: &sec,
+		CompletedAt: t0, CreatedAt: t0})
+	require.NoError(t, err)
+	require.NoError(t, wods.SaveAnnotations(ctx, "bob", w.ID, models.Annotations{Favorite: true, Labels: []string{"legs"}}))
+	require.NoError(t, repository.NewSQLiteCalendarRepository(database).SaveEntries(ctx, []models.CalendarEntry{{
+		ID: uuid.New(), Subject: "bob", WodID: w.ID, Date: t0, CreatedAt: t0, UpdatedAt: t0,
+	}}))
+
+	_, err = results.SaveResult(ctx, models.Result{ID: uuid.New(), WodID: uuid.New(), Subject: "bob", TimeSec: &sec,
+		CompletedAt: t0, CreatedAt: t0})
+	require.Error(t, err, "a result of a missing wod")
+
+	_, err = database.ExecContext(ctx, `DELETE FROM wods WHERE id = ?`, w.ID.String())
+	require.NoError(t, err)
+	for _, table := range []string{"wod_results", "wod_favorites", "wod_labels", "calendar_entries"} {
+		var n int
+		require.NoError(t, database.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table).Scan(&n))
+		require.Zero(t, n, "%s rows of the deleted wod cascade", table)
+	}
+}
+
+func TestConformance_Postgres(t *testing.T) {
+	url := os.Getenv("WODGEN_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("WODGEN_TEST_DATABASE_URL not set")
+	}
+	testWodRepository(t, func(t *testing.T) repository.WodRepositoryInterface {
+		return repository.NewWodRepository(openPostgres(t, url))
+	})
+	testResultRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.ResultRepositoryInterface) {
+		database := openPostgres(t, url)
+		return repository.NewWodRepository(database), repository.NewResultRepository(database)
+	})
+	testAnnotationRepository(t, func(t *testing.T) annotatedRepository {
+		return repository.NewWodRepository(openPostgres(t, url))
+	})
+	testCalendarRepository(t, func(t *testing.T) (repository.WodRepositoryInterface, repository.CalendarRepositoryInterface) {
+		database := openPostgres(t, url)
+		return repository.NewWodRepository(database), repository.NewCalendarRepository(database)
+	})
+	testRetentionRepository(t, func(t *testing.T) retentionStores {
+		database := openPostgres(t, url)
+		return retentionStores{repository.NewWodRepository(database), repository.NewResultRepository(database),
+			repository.NewCalendarRepository(database), repository.NewRetentionRepository(database)}
+	})
+	testStatsRepository(t, func(t *testing.T) statsStores {
+		database := openPostgres(t, url)
+		return statsStores{repository.NewWodRepository(database), repository.NewResultRepository(database),
+			repository.NewStatsRepository(database)}
+	})
+}
+
+// openSQLite migrates a new file, with the foreign keys the server enforces.
+func openSQLite(t *testing.T) *sql.DB {
+	database, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "wodgen.db")+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	migrator, err := repository.NewSQLiteMigrator(database, db.SQLiteMigrations())
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return database
+}
+
+// openPostgres migrates the database at url and empties it.
+func openPostgres(t *testing.T, url string) *sql.DB {
+	database, err := sql.Open("postgres", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	migrator, err := repository.NewMigrator(database, db.Migrations())
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	_, err = database.Exec(`TRUNCATE wods, calendar_feeds CASCADE`)
+	require.NoError(t, err)
+	return database
+}
+
+func testWodRepository(t *testing.T, open func(t *testing.T) repository.WodRepositoryInterface) {
+	ctx := context.Background()
+	// microseconds, the precision Postgres keeps.
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 123456000, time.UTC)
+	wod := func(owner string, minutes int, duration int) models.Wod {
+		return models.Wod{
+			ID:          uuid.New(),
+			Seed:        "seed-" + owner,
+			CreatedAt:   t0.Add(time.Duration(minutes) * time.Minute),
+			Level:       "beginner",
+			DurationMin: duration,
+			Equipment:   []string{"rower"},
+			Blocks:      []models.Block{{ID: "row", Name: "Row", Params: map[string]any{"meters": float64(500)}}},
+			Catalog:     "hyrox",
+			OwnerSub:    owner,
+		}
+	}
+	ids := func(wods []models.Wod) []uuid.UUID {
+		out := make([]uuid.UUID, len(wods))
+		for i, w := range wods {
+			out[i] = w.ID
+		}
+		return out
+	}
+
+	t.Run("save and get", func(t *testing.T) {
+		repo := open(t)
+		w := wod("alice", 0, 30)
+		w.CatalogVersion = 4
+		w.CatalogHash = "abc123"
+		w.IdempotencyKey = "retry-1"
+		_, err := repo.SaveWod(ctx, w)
+		require.NoError(t, err)
+
+		got, err := repo.GetWod(ctx, w.ID)
+		require.NoError(t, err)
+		require.True(t, w.CreatedAt.Equal(got.CreatedAt))
+		got.CreatedAt = w.CreatedAt
+		require.Equal(t, w, got)
+
+		_, err = repo.GetWod(ctx, uuid.New())
+		require.ErrorIs(t, err, common.ErrWodNotFound)
+	})
+
+	t.Run("idempotency key unique per owner", func(t *testing.T) {
+		repo := open(t)
+		w := wod("alice", 0, 30)
+		w.IdempotencyKey = "retry-1"
+		_, err := repo.SaveWod(ctx, w)
+		require.NoError(t, err)
+
+		again := wod("alice", 1, 30)
+		again.IdempotencyKey = "retry-1"
+		_, err = repo.SaveWod(ctx, again)
+		require.ErrorIs(t, err, common.ErrWodExists)
+
+		other := wod("bob", 1, 30)
+		other.IdempotencyKey = "retry-1"
+		_, err = repo.SaveWod(ctx, other)
+		require.NoError(t, err)
+
+		_, err = repo.SaveWod(ctx, wod("alice", 2, 30))
+		require.NoError(t, err, "wods without a key never collide")
+		_, err = repo.SaveWod(ctx, wod("alice", 3, 30))
+		require.NoError(t, err)
+
+		found, err := repo.ListWods(ctx, repository.WodFilter{Owner: "alice", IdempotencyKey: "retry-1"}, repository.WodPage{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{w.ID}, ids(found))
+	})
+
+	t.Run("save batch", func(t *testing.T) {
+		repo := open(t)
+		batch := []models.Wod{wod("alice", 0, 30), wod("alice", 1, 45)}
+		batch[1].IdempotencyKey = "retry-1"
+		saved, err := repo.SaveWods(ctx, batch)
+		require.NoError(t, err)
+		require.Equal(t, ids(batch), ids(saved))
+
+		taken := wod("alice", 2, 30)
+		taken.IdempotencyKey = "retry-1"
+		_, err = repo.SaveWods(ctx, []models.Wod{wod("alice", 3, 30), taken})
+		require.ErrorIs(t, err, common.ErrWodExists)
+		dup := wod("alice", 4, 30)
+		_, err = repo.SaveWods(ctx, []models.Wod{dup, wod("bob", 5, 30), dup})
+		require.Error(t, err)
+
+		all, err := repo.ListWods(ctx, repository.WodFilter{AnyOwner: true}, repository.WodPage{Limit: 10})
+		require.NoError(t, err)
+		require.ElementsMatch(t, ids(batch), ids(all), "a failed batch stores none of its wods")
+
+		saved, err = repo.SaveWods(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, saved)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		repo := open(t)
+		w := wod("alice", 0, 30)
+		_, err := repo.SaveWod(ctx, w)
+		require.NoError(t, err)
+		bobs := wod("bob", 1, 30)
+		_, err = repo.SaveWod(ctx, bobs)
+		require.NoError(t, err)
+
+		w.DurationMin, w.Equipment, w.IdempotencyKey = 45, []string{"sled"}, "retry-1"
+		fresh := wod("alice", 2, 60)
+		require.NoError(t, repo.UpsertWods(ctx, []models.Wod{w, fresh}))
+		got, err := repo.GetWod(ctx, w.ID)
+		require.NoError(t, err)
+		got.CreatedAt = w.CreatedAt
+		require.Equal(t, w, got, "the wod with the ID is replaced")
+		_, err = repo.GetWod(ctx, fresh.ID)
+		require.NoError(t, err)
+
+		stolen := bobs
+		stolen.OwnerSub = "alice"
+		late := wod("alice", 3, 30)
+		err = repo.UpsertWods(ctx, []models.Wod{late, stolen})
+		require.ErrorIs(t, err, common.ErrWodExists, "a wod keeps its owner")
+		got, err = repo.GetWod(ctx, bobs.ID)
+		require.NoError(t, err)
+		require.Equal(t, "bob", got.OwnerSub)
+		_, err = repo.GetWod(ctx, late.ID)
+		require.ErrorIs(t, err, common.ErrWodNotFound, "a failed upsert stores none of its wods")
+
+		taken := wod("alice", 4, 30)
+		taken.IdempotencyKey = "retry-1"
+		require.ErrorIs(t, repo.UpsertWods(ctx, []models.Wod{taken}), common.ErrWodExists)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		repo := open(t)
+		short := wod("alice", 0, 20)
+		long := wod("alice", 1, 60)
+		long.Level = "advanced"
+		long.Equipment = []string{"rower", "sled"}
+		long.Blocks = []models.Block{{ID: "sled-push", Name: "Sled Push"}}
+		legacy := wod("alice", 2, 40)
+		legacy.Blocks = []models.Block{{Name: "Burpees"}}
+		legacy.Equipment = []string{}
+		legacy.Catalog = "running"
+		legacy.CatalogVersion = 3
+		bobs := wod("bob", 3, 30)
+		for _, w := range []models.Wod{short, long, legacy, bobs} {
+			_, err := repo.SaveWod(ctx, w)
+			require.NoError(t, err)
+		}
+
+		for name, tc := range map[string]struct {
+			f    repository.WodFilter
+			want []uuid.UUID
+		}{
+			"owner":           {repository.WodFilter{Owner: "alice"}, []uuid.UUID{legacy.ID, long.ID, short.ID}},
+			"any owner":       {repository.WodFilter{AnyOwner: true}, []uuid.UUID{bobs.ID, legacy.ID, long.ID, short.ID}},
+			"level":           {repository.WodFilter{Owner: "alice", Level: "advanced"}, []uuid.UUID{long.ID}},
+			"durations":       {repository.WodFilter{Owner: "alice", MinDuration: 30, MaxDuration: 40}, []uuid.UUID{legacy.ID}},
+			"all equipment":   {repository.WodFilter{Owner: "alice", Equipment: []string{"rower", "sled"}}, []uuid.UUID{long.ID}},
+			"any equipment":   {repository.WodFilter{Owner: "alice", Equipment: []string{"sled", "ski"}, AnyEquipment: true}, []uuid.UUID{long.ID}},
+			"created range":   {repository.WodFilter{Owner: "alice", CreatedAfter: long.CreatedAt, CreatedBefore: legacy.CreatedAt}, []uuid.UUID{long.ID}},
+			"seed":            {repository.WodFilter{AnyOwner: true, Seed: "seed-bob"}, []uuid.UUID{bobs.ID}},
+			"catalog version": {repository.WodFilter{Owner: "alice", Catalog: "running", CatalogVersion: 3}, []uuid.UUID{legacy.ID}},
+			"move id":         {repository.WodFilter{Owner: "alice", MoveID: "sled-push"}, []uuid.UUID{long.ID}},
+			"move id or name": {repository.WodFilter{Owner: "alice", MoveID: "burpees", MoveName: "Burpees"}, []uuid.UUID{legacy.ID}},
+			"ids":             {repository.WodFilter{Owner: "alice", IDs: []uuid.UUID{short.ID, legacy.ID, bobs.ID}}, []uuid.UUID{legacy.ID, short.ID}},
+		} {
+			got, err := repo.ListWods(ctx, tc.f, repository.WodPage{Limit: 10})
+			require.NoError(t, err, name)
+			require.Equal(t, tc.want, ids(got), name)
+
+			n, err := repo.CountWods(ctx, tc.f)
+			require.NoError(t, err, name)
+			require.Equal(t, len(tc.want), n, name)
+		}
+	})
+
+	t.Run("move search", func(t *testing.T) {
+		repo := open(t)
+		block := func(id, name string, params map[string]any) models.Block {
+			return models.Block{ID: id, Name: name, Params: params}
+		}
+		sled := block("sled-push", "Sled Push", map[string]any{"meters": 50})
+		row := func(meters any) models.Block { return block("row", "Row", map[string]any{"meters": meters}) }
+
+		twoRows := wod("alice", 0, 30)
+		twoRows.Blocks = []models.Block{sled, row(1000), row(float64(1000))}
+		longRow := wod("alice", 1, 30)
+		longRow.Blocks = []models.Block{row(2500)}
+		shortRow := wod("alice", 2, 30)
+		// blocks stored before moves had IDs only have a name.
+		shortRow.Blocks = []models.Block{sled, block("", "Row", map[string]any{"meters": 500, "note": "easy"})}
+		burpees := wod("alice", 3, 30)
+		burpees.Blocks = []models.Block{block("burpees", "Burpees", map[string]any{"reps": 10})}
+		for _, w := range []models.Wod{twoRows, longRow, shortRow, burpees} {
+			_, err := repo.SaveWod(ctx, w)
+			require.NoError(t, err)
+		}
+
+		n := func(v float64) *float64 { return &v }
+		rowMeters := func(lo, hi *float64) repository.MoveMatch {
+			return repository.MoveMatch{ID: "row", Name: "Row", Params: map[string]repository.ParamRange{"meters": {Min: lo, Max: hi}}}
+		}
+		sledPush := repository.MoveMatch{ID: "sled-push", Name: "Sled Push"}
+		for name, tc := range map[string]struct {
+			f    repository.WodFilter
+			want []uuid.UUID
+		}{
+			"all moves":         {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{sledPush, rowMeters(n(2000), nil)}}, []uuid.UUID{twoRows.ID}},
+			"params added up":   {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{rowMeters(n(2000), n(2000))}}, []uuid.UUID{twoRows.ID}},
+			"param upper bound": {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{rowMeters(nil, n(1000))}}, []uuid.UUID{shortRow.ID}},
+			"any moves": {repository.WodFilter{Owner: "alice", AnyMoves: []repository.MoveMatch{{ID: "burpees", Name: "Burpees"}, rowMeters(n(2500), nil)}},
+				[]uuid.UUID{burpees.ID, longRow.ID}},
+			"no moves":          {repository.WodFilter{Owner: "alice", NoMoves: []repository.MoveMatch{{ID: "row", Name: "Row"}}}, []uuid.UUID{burpees.ID}},
+			"missing param":     {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{{ID: "row", Name: "Row", Params: map[string]repository.ParamRange{"reps": {Min: n(1)}}}}}, []uuid.UUID{}},
+			"non numeric param": {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{{Name: "Row", Params: map[string]repository.ParamRange{"note": {Max: n(0)}}}}}, []uuid.UUID{shortRow.ID, longRow.ID, twoRows.ID}},
+			"combined": {repository.WodFilter{Owner: "alice", AllMoves: []repository.MoveMatch{sledPush}, NoMoves: []repository.MoveMatch{rowMeters(n(2000), nil)}},
+				[]uuid.UUID{shortRow.ID}},
+		} {
+			got, err := repo.ListWods(ctx, tc.f, repository.WodPage{Limit: 10})
+			require.NoError(t, err, name)
+			require.Equal(t, tc.want, ids(got), name)
+
+			count, err := repo.CountWods(ctx, tc.f)
+			require.NoError(t, err, name)
+			require.Equal(t, len(tc.want), count, name)
+		}
+	})
+
+	t.Run("sort and seek", func(t *testing.T) {
+		repo := open(t)
+		// a and b share their creation time, the id breaks the tie.
+		a, b, c := wod("alice", 0, 30), wod("alice", 0, 45), wod("alice", 1, 30)
+		if a.ID.String() > b.ID.String() {
+			a.ID, b.ID = b.ID, a.ID
+		}
+		for _, w := range []models.Wod{a, b, c} {
+			_, err := repo.SaveWod(ctx, w)
+			require.NoError(t, err)
+		}
+		f := repository.WodFilter{Owner: "alice"}
+
+		got, err := repo.ListWods(ctx, f, repository.WodPage{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{c.ID, b.ID, a.ID}, ids(got), "newest first")
+
+		got, err = repo.ListWods(ctx, f, repository.WodPage{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{b.ID}, ids(got))
+
+		got, err = repo.ListWods(ctx, f, repository.WodPage{Limit: 10, After: &repository.WodKey{CreatedAt: c.CreatedAt, ID: c.ID}})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{b.ID, a.ID}, ids(got))
+
+		got, err = repo.ListWods(ctx, f, repository.WodPage{Limit: 1, Before: &repository.WodKey{CreatedAt: a.CreatedAt, ID: a.ID}})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{b.ID}, ids(got), "the page right before a")
+
+		asc := repository.WodFilter{Owner: "alice", Sort: repository.WodSortDuration, Ascending: true}
+		got, err = repo.ListWods(ctx, asc, repository.WodPage{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{a.ID, c.ID, b.ID}, ids(got), "shortest first")
+
+		got, err = repo.ListWods(ctx, asc, repository.WodPage{Limit: 10, After: &repository.WodKey{DurationMin: 30, CreatedAt: a.CreatedAt, ID: a.ID}})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{c.ID, b.ID}, ids(got))
+	})
+}
+
+func testResultRepository(t *testing.T, open func(t *testing.T) (repository.WodRepositoryInterface, repository.ResultRepositoryInterface)) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 123456000, time.UTC)
+	n := func(v int) *int { return &v }
+	ids := func(results []models.Result) []uuid.UUID {
+		out := make([]uuid.UUID, len(results))
+		for i, r := range results {
+			out[i] = r.ID
+		}
+		return out
+	}
+
+	t.Run("save and list", func(t *testing.T) {
+		wods, results := open(t)
+		w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0, Level: "beginner", DurationMin: 30,
+			Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+		other := w
+		other.ID = uuid.New()
+		for _, wod := range []models.Wod{w, other} {
+			_, err := wods.SaveWod(ctx, wod)
+			require.NoError(t, err)
+		}
+
+		timed := models.Result{ID: uuid.New(), WodID: w.ID, Subject: "alice", TimeSec: n(1260), RPE: n(8),
+			Splits: []models.Split{{Block: 1, TimeSec: 1260}}, Scaling: "light", CompletedAt: t0, CreatedAt: t0}
+		amrap := models.Result{ID: uuid.New(), WodID: w.ID, Subject: "bob", Rounds: n(5), Reps: n(12),
+			CompletedAt: t0.Add(time.Hour), CreatedAt: t0}
+		elsewhere := models.Result{ID: uuid.New(), WodID: other.ID, Subject: "alice", TimeSec: n(900),
+			CompletedAt: t0.Add(2 * time.Hour), CreatedAt: t0}
+		for _, r := range []models.Result{timed, amrap, elsewhere} {
+			_, err := results.SaveResult(ctx, r)
+			require.NoError(t, err)
+		}
+
+		got, err := results.ListResults(ctx, repository.ResultFilter{WodID: w.ID}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{amrap.ID, timed.ID}, ids(got), "latest completed first")
+		require.True(t, timed.CompletedAt.Equal(got[1].CompletedAt))
+		got[1].CompletedAt, got[1].CreatedAt = timed.CompletedAt, timed.CreatedAt
+		require.Equal(t, timed, got[1])
+		require.Empty(t, got[0].Splits)
+
+		got, err = results.ListResults(ctx, repository.ResultFilter{Subject: "alice"}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{elsewhere.ID, timed.ID}, ids(got))
+
+		got, err = results.ListResults(ctx, repository.ResultFilter{Subject: "alice"}, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{timed.ID}, ids(got))
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		wods, results := open(t)
+		w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0, Level: "beginner", DurationMin: 30,
+			Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+		_, err := wods.SaveWod(ctx, w)
+		require.NoError(t, err)
+		// two results completed together are ordered by ID.
+		for _, at := range []time.Time{t0, t0, t0.Add(time.Hour), t0.Add(-time.Hour)} {
+			_, err := results.SaveResult(ctx, models.Result{ID: uuid.New(), WodID: w.ID, Subject: "alice",
+				TimeSec: n(600), CompletedAt: at, CreatedAt: t0})
+			require.NoError(t, err)
+		}
+		all, err := results.ListResults(ctx, repository.ResultFilter{}, 10, 0)
+		require.NoError(t, err)
+
+		var seeked []uuid.UUID
+		f := repository.ResultFilter{Subject: "alice"}
+		for {
+			page, err := results.ListResults(ctx, f, 1, 0)
+			require.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			seeked = append(seeked, page[0].ID)
+			f.After = &repository.ResultKey{CompletedAt: page[0].CompletedAt, ID: page[0].ID}
+		}
+		require.Equal(t, ids(all), seeked)
+	})
+
+	t.Run("leaderboard filters", func(t *testing.T) {
+		wods, results := open(t)
+		w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0, Level: "beginner", DurationMin: 30,
+			Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+		other, third := w, w
+		other.ID, other.OwnerSub = uuid.New(), "bob"
+		third.ID = uuid.New()
+		for _, wod := range []models.Wod{w, other, third} {
+			_, err := wods.SaveWod(ctx, wod)
+			require.NoError(t, err)
+		}
+
+		load := 102.5
+		rx := models.Result{ID: uuid.New(), WodID: w.ID, Subject: "alice", LoadKg: &load, Division: "rx",
+			CompletedAt: t0, CreatedAt: t0}
+		scaled := models.Result{ID: uuid.New(), WodID: other.ID, Subject: "bob", TimeSec: n(900), Division: "scaled",
+			CompletedAt: t0.Add(time.Hour), CreatedAt: t0}
+		late := models.Result{ID: uuid.New(), WodID: other.ID, Subject: "bob", TimeSec: n(800), Division: "rx",
+			CompletedAt: t0.Add(2 * time.Hour), CreatedAt: t0}
+		elsewhere := models.Result{ID: uuid.New(), WodID: third.ID, Subject: "carol", TimeSec: n(700), Division: "rx",
+			CompletedAt: t0, CreatedAt: t0}
+		for _, r := range []models.Result{rx, scaled, late, elsewhere} {
+			_, err := results.SaveResult(ctx, r)
+			require.NoError(t, err)
+		}
+
+		both := []uuid.UUID{w.ID, other.ID}
+		for name, tc := range map[string]struct {
+			f    repository.ResultFilter
+			want []uuid.UUID
+		}{
+			"wods":       {repository.ResultFilter{WodIDs: both}, []uuid.UUID{late.ID, scaled.ID, rx.ID}},
+			"division":   {repository.ResultFilter{WodIDs: both, Division: "rx"}, []uuid.UUID{late.ID, rx.ID}},
+			"completed":  {repository.ResultFilter{WodIDs: both, CompletedAfter: t0.Add(time.Hour)}, []uuid.UUID{late.ID, scaled.ID}},
+			"before":     {repository.ResultFilter{WodIDs: both, CompletedBefore: t0.Add(time.Hour)}, []uuid.UUID{rx.ID}},
+			"single wod": {repository.ResultFilter{WodID: third.ID}, []uuid.UUID{elsewhere.ID}},
+		} {
+			got, err := results.ListResults(ctx, tc.f, 10, 0)
+			require.NoError(t, err, name)
+			require.Equal(t, tc.want, ids(got), name)
+		}
+
+		got, err := results.ListResults(ctx, repository.ResultFilter{WodID: w.ID}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, 102.5, *got[0].LoadKg)
+		require.Nil(t, got[0].TimeSec)
+		require.Equal(t, "rx", got[0].Division)
+	})
+}
+
+type annotatedRepository interface {
+	repository.WodRepositoryInterface
+	repository.AnnotationRepositoryInterface
+}
+
+func testAnnotationRepository(t *testing.T, open func(t *testing.T) annotatedRepository) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	wod := func(minutes int) models.Wod {
+		return models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0.Add(time.Duration(minutes) * time.Minute), Level: "beginner",
+			DurationMin: 30, Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+	}
+
+	t.Run("annotations save and get", func(t *testing.T) {
+		repo := open(t)
+		w, other := wod(0), wod(1)
+		for _, x := range []models.Wod{w, other} {
+			_, err := repo.SaveWod(ctx, x)
+			require.NoError(t, err)
+		}
+
+		a := models.Annotations{Favorite: true, Note: "heavy sled", Labels: []string{"engine", "sled"}}
+		require.NoError(t, repo.SaveAnnotations(ctx, "alice", w.ID, a))
+		got, err := repo.GetAnnotations(ctx, "alice", []uuid.UUID{w.ID, other.ID})
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID]models.Annotations{w.ID: a}, got)
+
+		got, err = repo.GetAnnotations(ctx, "bob", []uuid.UUID{w.ID})
+		require.NoError(t, err)
+		require.Empty(t, got, "annotations are per subject")
+
+		a = models.Annotations{Labels: []string{"engine"}}
+		require.NoError(t, repo.SaveAnnotations(ctx, "alice", w.ID, a))
+		got, err = repo.GetAnnotations(ctx, "alice", []uuid.UUID{w.ID})
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID]models.Annotations{w.ID: a}, got, "saving replaces")
+
+		require.NoError(t, repo.SaveAnnotations(ctx, "alice", w.ID, models.Annotations{}))
+		got, err = repo.GetAnnotations(ctx, "alice", []uuid.UUID{w.ID})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("annotation filters", func(t *testing.T) {
+		repo := open(t)
+		fav, noted, plain := wod(0), wod(1), wod(2)
+		for _, x := range []models.Wod{fav, noted, plain} {
+			_, err := repo.SaveWod(ctx, x)
+			require.NoError(t, err)
+		}
+		require.NoError(t, repo.SaveAnnotations(ctx, "alice", fav.ID, models.Annotations{Favorite: true, Labels: []string{"engine", "sled"}}))
+		require.NoError(t, repo.SaveAnnotations(ctx, "alice", noted.ID, models.Annotations{Note: "Felt 100% at the end", Labels: []string{"engine"}}))
+		require.NoError(t, repo.SaveAnnotations(ctx, "bob", plain.ID, models.Annotations{Favorite: true}))
+
+		ids := func(wods []models.Wod) []uuid.UUID {
+			out := make([]uuid.UUID, len(wods))
+			for i, w := range wods {
+				out[i] = w.ID
+			}
+			return out
+		}
+		for name, tc := range map[string]struct {
+			f    repository.WodFilter
+			want []uuid.UUID
+		}{
+			"favorite":       {repository.WodFilter{Owner: "alice", Annotator: "alice", Favorite: true}, []uuid.UUID{fav.ID}},
+			"other favorite": {repository.WodFilter{Owner: "alice", Annotator: "bob", Favorite: true}, []uuid.UUID{plain.ID}},
+			"one label":      {repository.WodFilter{Owner: "alice", Annotator: "alice", Labels: []string{"engine"}}, []uuid.UUID{noted.ID, fav.ID}},
+			"all labels":     {repository.WodFilter{Owner: "alice", Annotator: "alice", Labels: []string{"engine", "sled"}}, []uuid.UUID{fav.ID}},
+			"note":           {repository.WodFilter{Owner: "alice", Annotator: "alice", Note: "felt 100%"}, []uuid.UUID{noted.ID}},
+			"note wildcard":  {repository.WodFilter{Owner: "alice", Annotator: "alice", Note: "1_0"}, []uuid.UUID{}},
+		} {
+			got, err := repo.ListWods(ctx, tc.f, repository.WodPage{Limit: 10})
+			require.NoError(t, err, name)
+			require.Equal(t, tc.want, ids(got), name)
+
+			n, err := repo.CountWods(ctx, tc.f)
+			require.NoError(t, err, name)
+			require.Equal(t, len(tc.want), n, name)
+		}
+	})
+}
+
+func testCalendarRepository(t *testing.T, open func(t *testing.T) (repository.WodRepositoryInterface, repository.CalendarRepositoryInterface)) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 123456000, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 9, d, 0, 0, 0, 0, time.UTC) }
+	ids := func(entries []models.CalendarEntry) []uuid.UUID {
+		out := make([]uuid.UUID, len(entries))
+		for i, e := range entries {
+			out[i] = e.ID
+		}
+		return out
+	}
+	seed := func(t *testing.T) (repository.CalendarRepositoryInterface, models.Wod) {
+		wods, calendar := open(t)
+		w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0, Level: "beginner", DurationMin: 30,
+			Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+		_, err := wods.SaveWod(ctx, w)
+		require.NoError(t, err)
+		return calendar, w
+	}
+	entry := func(w models.Wod, subject string, d int, program string) models.CalendarEntry {
+		return models.CalendarEntry{ID: uuid.New(), Subject: subject, WodID: w.ID, Date: day(d), Program: program,
+			CreatedAt: t0, UpdatedAt: t0}
+	}
+
+	t.Run("calendar entries", func(t *testing.T) {
+		calendar, w := seed(t)
+		late := entry(w, "alice", 3, "block-1")
+		early := entry(w, "alice", 1, "block-1")
+		early.Note = "easy"
+		solo := entry(w, "alice", 2, "")
+		bobs := entry(w, "bob", 1, "block-1")
+		require.NoError(t, calendar.SaveEntries(ctx, []models.CalendarEntry{late, early, solo, bobs}))
+
+		got, err := calendar.ListEntries(ctx, repository.CalendarFilter{Subject: "alice", ID: early.ID}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, []models.CalendarEntry{early}, got)
+
+		for name, tc := range map[string]struct {
+			f    repository.CalendarFilter
+			want []uuid.UUID
+		}{
+			"subject": {repository.CalendarFilter{Subject: "alice"}, []uuid.UUID{early.ID, solo.ID, late.ID}},
+			"program": {repository.CalendarFilter{Subject: "alice", Program: "block-1"}, []uuid.UUID{early.ID, late.ID}},
+			"range":   {repository.CalendarFilter{Subject: "alice", From: day(2), To: day(3)}, []uuid.UUID{solo.ID}},
+		} {
+			got, err := calendar.ListEntries(ctx, tc.f, 10, 0)
+			require.NoError(t, err, name)
+			require.Equal(t, tc.want, ids(got), name)
+		}
+
+		moved := early
+		moved.Date, moved.Note, moved.UpdatedAt = day(4), "", t0.Add(time.Hour)
+		require.NoError(t, calendar.UpdateEntry(ctx, moved))
+		got, err = calendar.ListEntries(ctx, repository.CalendarFilter{Subject: "alice", ID: early.ID}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, []models.CalendarEntry{moved}, got)
+
+		moved.Subject = "bob"
+		require.ErrorIs(t, calendar.UpdateEntry(ctx, moved), common.ErrCalendarEntryNotFound)
+
+		n, err := calendar.DeleteEntries(ctx, repository.CalendarFilter{Subject: "alice", Program: "block-1"})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		got, err = calendar.ListEntries(ctx, repository.CalendarFilter{Subject: "alice"}, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{solo.ID}, ids(got))
+	})
+
+	t.Run("calendar feeds", func(t *testing.T) {
+		calendar, _ := seed(t)
+		require.NoError(t, calendar.SaveFeed(ctx, "alice", "hash-1", t0))
+		subject, err := calendar.FeedSubject(ctx, "hash-1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", subject)
+
+		require.NoError(t, calendar.SaveFeed(ctx, "alice", "hash-2", t0))
+		_, err = calendar.FeedSubject(ctx, "hash-1")
+		require.ErrorIs(t, err, common.ErrCalendarFeedNotFound, "a new token replaces the previous one")
+
+		require.NoError(t, calendar.DeleteFeed(ctx, "alice"))
+		_, err = calendar.FeedSubject(ctx, "hash-2")
+		require.ErrorIs(t, err, common.ErrCalendarFeedNotFound)
+	})
+}
+
+// retentionStores are a retention repository and the ones it looks into.
+type retentionStores struct {
+	wods      annotatedRepository
+	results   repository.ResultRepositoryInterface
+	calendar  repository.CalendarRepositoryInterface
+	retention repository.RetentionRepositoryInterface
+}
+
+func testRetentionRepository(t *testing.T, open func(t *testing.T) retentionStores) {
+	ctx := context.Background()
+	t0 := time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC)
+	cutoff := t0.Add(time.Hour)
+
+	t.Run("retention purge", func(t *testing.T) {
+		s := open(t)
+		wod := func(minutes int) models.Wod {
+			w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: t0.Add(time.Duration(minutes) * time.Minute), Level: "beginner",
+				DurationMin: 30, Equipment: []string{}, Blocks: []models.Block{{Name: "Row"}}, Catalog: "hyrox", OwnerSub: "alice"}
+			_, err := s.wods.SaveWod(ctx, w)
+			require.NoError(t, err)
+			return w
+		}
+		oldest, old := wod(0), wod(1)
+		favorite, noted, labeled, logged, scheduled := wod(2), wod(3), wod(4), wod(5), wod(6)
+		recent := wod(120)
+
+		require.NoError(t, s.wods.SaveAnnotations(ctx, "bob", favorite.ID, models.Annotations{Favorite: true}))
+		require.NoError(t, s.wods.SaveAnnotations(ctx, "bob", noted.ID, models.Annotations{Note: "felt good"}))
+		require.NoError(t, s.wods.SaveAnnotations(ctx, "bob", labeled.ID, models.Annotations{Labels: []string{"legs"}}))
+		sec := 900
+		_, err := s.results.SaveResult(ctx, models.Result{ID: uuid.New(), WodID: logged.ID, Subject: "bob", TimeSec: &sec,
+			CompletedAt: t0, CreatedAt: t0})
+		require.NoError(t, err)
+		require.NoError(t, s.calendar.SaveEntries(ctx, []models.CalendarEntry{{ID: uuid.New(), Subject: "bob",
+			WodID: scheduled.ID, Date: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), CreatedAt: t0, UpdatedAt: t0}}))
+
+		n, err := s.retention.CountPurgeable(ctx, cutoff)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		// oldest first.
+		n, err = s.retention.PurgeWods(ctx, cutoff, 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		_, err = s.wods.GetWod(ctx, oldest.ID)
+		require.ErrorIs(t, err, common.ErrWodNotFound)
+		_, err = s.wods.GetWod(ctx, old.ID)
+		require.NoError(t, err)
+
+		n, err = s.retention.PurgeWods(ctx, cutoff, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		n, err = s.retention.CountPurgeable(ctx, cutoff)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		for _, w := range []models.Wod{favorite, noted, labeled, logged, scheduled, recent} {
+			_, err := s.wods.GetWod(ctx, w.ID)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("retention lock", func(t *testing.T) {
+		s := open(t)
+		unlock, ok, err := s.retention.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		_, ok, err = s.retention.TryLock(ctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		unlock()
+		unlock, ok, err = s.retention.TryLock(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		unlock()
+	})
+}
+
+// statsStores are a stats repository and the ones it aggregates.
+type statsStores struct {
+	wods    repository.WodRepositoryInterface
+	results repository.ResultRepositoryInterface
+	stats   repository.StatsRepositoryInterface
+}
+
+func testStatsRepository(t *testing.T, open func(t *testing.T) statsStores) {
+	ctx := context.Background()
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("stats", func(t *testing.T) {
+		s := open(t)
+		row := func(meters any) models.Block {
+			return models.Block{ID: "row", Name: "Row", Params: map[string]any{"meters": meters}}
+		}
+		burpees := func(reps any) models.Block {
+			return models.Block{ID: "burpees", Name: "Burpees", Params: map[string]any{"reps": reps}}
+		}
+		wod := func(owner string, createdAt time.Time, level string, duration int, equipment []string, blocks ...models.Block) models.Wod {
+			w := models.Wod{ID: uuid.New(), Seed: "s", CreatedAt: createdAt, Level: level, DurationMin: duration,
+				Equipment: equipment, Blocks: blocks, Catalog: "hyrox", OwnerSub: owner}
+			_, err := s.wods.SaveWod(ctx, w)
+			require.NoError(t, err)
+			return w
+		}
+		// a Wednesday, in the week of Monday 1 September.
+		a := wod("alice", day(9, 3).Add(8*time.Hour), "beginner", 30, []string{"rower", "skierg"},
+			row(1000), row(500), burpees(20))
+		// a Monday at midnight, the first instant of its week.
+		b := wod("alice", day(9, 8), "intermediate", 45, []string{"rower"},
+			models.Block{Name: "Row", Params: map[string]any{"meters": 2000}},
+			models.Block{ID: "wall-balls", Name: "Wall Balls", Params: map[string]any{"reps": "max"}},
+			burpees(10))
+		wod("alice", day(10, 2).Add(8*time.Hour), "beginner", 30, []string{}, row(250.5), burpees(15))
+		d := wod("bob", day(9, 3).Add(9*time.Hour), "advanced", 60, []string{"sled"}, row(100))
+
+		sec := 900
+		for _, r := range []models.Result{
+			{WodID: a.ID, Subject: "alice", CompletedAt: day(9, 8).Add(-time.Second)},
+			{WodID: b.ID, Subject: "alice", CompletedAt: day(9, 9)},
+			{WodID: d.ID, Subject: "bob", CompletedAt: day(10, 20)},
+		} {
+			r.ID, r.TimeSec, r.CreatedAt = uuid.New(), &sec, r.CompletedAt
+			_, err := s.results.SaveResult(ctx, r)
+			require.NoError(t, err)
+		}
+
+		got, err := s.stats.WodStats(ctx, repository.StatsFilter{Owner: "alice", Period: repository.StatsPeriodWeek})
+		require.NoError(t, err)
+		require.Equal(t, models.Stats{
+			Wods:    3,
+			Results: 2,
+			Moves: []models.MoveStat{
+				{ID: "burpees", Name: "Burpees", Blocks: 3, Wods: 3},
+				{ID: "row", Name: "Row", Blocks: 3, Wods: 2},
+				{Name: "Row", Blocks: 1, Wods: 1},
+				{ID: "wall-balls", Name: "Wall Balls", Blocks: 1, Wods: 1},
+			},
+			Periods: []models.PeriodStat{
+				{Start: day(9, 1), Wods: 1, Results: 1, Meters: 1500, Reps: 20},
+				{Start: day(9, 8), Wods: 1, Results: 1, Meters: 2000, Reps: 10},
+				{Start: day(9, 29), Wods: 1, Meters: 250.5, Reps: 15},
+			},
+			Levels:    []models.CountStat{{Key: "beginner", Wods: 2}, {Key: "intermediate", Wods: 1}},
+			Durations: []models.DurationStat{{DurationMin: 30, Wods: 2}, {DurationMin: 45, Wods: 1}},
+			Equipment: []models.CountStat{{Key: "rower", Wods: 2}, {Key: "skierg", Wods: 1}},
+		}, got)
+
+		got, err = s.stats.WodStats(ctx, repository.StatsFilter{Owner: "alice", From: day(9, 8), To: day(10, 31),
+			Period: repository.StatsPeriodMonth})
+		require.NoError(t, err)
+		require.Equal(t, 2, got.Wods)
+		require.Equal(t, 1, got.Results, "the result of the 7th is before from")
+		require.Equal(t, []models.PeriodStat{
+			{Start: day(9, 1), Wods: 1, Results: 1, Meters: 2000, Reps: 10},
+			{Start: day(10, 1), Wods: 1, Meters: 250.5, Reps: 15},
+		}, got.Periods)
+
+		got, err = s.stats.WodStats(ctx, repository.StatsFilter{AnyOwner: true, Period: repository.StatsPeriodMonth})
+		require.NoError(t, err)
+		require.Equal(t, 4, got.Wods)
+		require.Equal(t, 3, got.Results)
+		require.Equal(t, []models.PeriodStat{
+			{Start: day(9, 1), Wods: 3, Results: 2, Meters: 3600, Reps: 30},
+			{Start: day(10, 1), Wods: 1, Results: 1, Meters: 250.5, Reps: 15},
+		}, got.Periods)
+		require.Equal(t, []models.CountStat{{Key: "rower", Wods: 2}, {Key: "skierg", Wods: 1}, {Key: "sled", Wods: 1}},
+			got.Equipment)
+
+		got, err = s.stats.WodStats(ctx, repository.StatsFilter{Owner: "carol", Period: repository.StatsPeriodWeek})
+		require.NoError(t, err)
+		require.Zero(t, got.Wods)
+		require.Empty(t, got.Periods)
+	})
+}
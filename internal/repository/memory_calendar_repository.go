@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryCalendarRepository keeps the calendars in memory, next to a
+// MemoryWodRepository.
+type MemoryCalendarRepository struct {
+	mu      sync.RWMutex
+	entries []models.CalendarEntry
+	// feeds maps the subjects to the hash of their feed token.
+	feeds map[string]string
+}
+
+func NewMemoryCalendarRepository() *MemoryCalendarRepository {
+	return &MemoryCalendarRepository{feeds: map[string]string{}}
+}
+
+// matches is the WHERE clause of where, evaluated on e.
+func (f CalendarFilter) matches(e models.CalendarEntry) bool {
+	switch {
+	case e.Subject != f.Subject,
+		f.ID != uuid.Nil && e.ID != f.ID,
+		f.Program != "" && e.Program != f.Program,
+		!f.From.IsZero() && e.Date.Before(f.From),
+		!f.To.IsZero() && !e.Date.Before(f.To):
+		return false
+	}
+	return true
+}
+
+func (r *MemoryCalendarRepository) SaveEntries(ctx context.Context, entries []models.CalendarEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *MemoryCalendarRepository) UpdateEntry(ctx context.Context, e models.CalendarEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.entries, func(x models.CalendarEntry) bool { return x.ID == e.ID && x.Subject == e.Subject })
+	if i < 0 {
+		return common.ErrCalendarEntryNotFound
+	}
+	e.CreatedAt = r.entries[i].CreatedAt
+	r.entries[i] = e
+	return nil
+}
+
+func (r *MemoryCalendarRepository) DeleteEntries(ctx context.Context, f CalendarFilter) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.entries)
+	r.entries = slices.DeleteFunc(r.entries, f.matches)
+	return n - len(r.entries), nil
+}
+
+func (r *MemoryCalendarRepository) ListEntries(ctx context.Context, f CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []models.CalendarEntry
+	for _, e := range r.entries {
+		if f.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b models.CalendarEntry) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	entries = entries[min(offset, len(entries)):]
+	return entries[:min(limit, len(entries))], nil
+}
+
+func (r *MemoryCalendarRepository) SaveFeed(ctx context.Context, subject, tokenHash string, createdAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.feeds[subject] = tokenHash
+	return nil
+}
+
+func (r *MemoryCalendarRepository) DeleteFeed(ctx context.Context, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.feeds, subject)
+	return nil
+}
+
+func (r *MemoryCalendarRepository) FeedSubject(ctx context.Context, tokenHash string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for subject, h := range r.feeds {
+		if h == tokenHash {
+			return subject, nil
+		}
+	}
+	return "", common.ErrCalendarFeedNotFound
+}
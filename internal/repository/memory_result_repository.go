@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryResultRepository keeps the results in memory, next to a
+// MemoryWodRepository.
+type MemoryResultRepository struct {
+	mu      sync.RWMutex
+	results []models.Result
+}
+
+func NewMemoryResultRepository() *MemoryResultRepository {
+	return &MemoryResultRepository{}
+}
+
+// matches is the WHERE clause of where, evaluated on res.
+func (f ResultFilter) matches(res models.Result) bool {
+	switch {
+	case f.WodID != uuid.Nil && res.WodID != f.WodID,
+		len(f.WodIDs) > 0 && !slices.Contains(f.WodIDs, res.WodID),
+		f.Subject != "" && res.Subject != f.Subject,
+		f.Division != "" && res.Division != f.Division,
+		!f.CompletedAfter.IsZero() && res.CompletedAt.Before(f.CompletedAfter),
+		!f.CompletedBefore.IsZero() && !res.CompletedAt.Before(f.CompletedBefore),
+		f.After != nil && compareResults(res, *f.After) <= 0:
+		return false
+	}
+	return true
+}
+
+// compareResults orders res against the key in the listing, latest first.
+func compareResults(res models.Result, key ResultKey) int {
+	if c := key.CompletedAt.Compare(res.CompletedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(key.ID[:], res.ID[:])
+}
+
+func (r *MemoryResultRepository) SaveResult(ctx context.Context, res models.Result) (models.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res.Splits = slices.Clone(res.Splits)
+	r.results = append(r.results, res)
+	return res, nil
+}
+
+func (r *MemoryResultRepository) ListResults(ctx context.Context, f ResultFilter, limit, offset int) ([]models.Result, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []models.Result
+	for _, res := range r.results {
+		if f.matches(res) {
+			res.Splits = slices.Clone(res.Splits)
+			results = append(results, res)
+		}
+	}
+	slices.SortFunc(results, func(a, b models.Result) int {
+		return compareResults(a, ResultKey{CompletedAt: b.CompletedAt, ID: b.ID})
+	})
+	results = results[min(offset, len(results)):]
+	return results[:min(limit, len(results))], nil
+}
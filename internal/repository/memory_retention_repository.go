@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryRetentionRepository purges the wods of a MemoryWodRepository, looking
+// up their references in the result and calendar repositories next to it.
+type MemoryRetentionRepository struct {
+	wods     *MemoryWodRepository
+	results  *MemoryResultRepository
+	calendar *MemoryCalendarRepository
+	mu       sync.Mutex
+}
+
+func NewMemoryRetentionRepository(wods *MemoryWodRepository, results *MemoryResultRepository,
+	calendar *MemoryCalendarRepository,
+) *MemoryRetentionRepository {
+	return &MemoryRetentionRepository{wods: wods, results: results, calendar: calendar}
+}
+
+func (r *MemoryRetentionRepository) CountPurgeable(ctx context.Context, cutoff time.Time) (int, error) {
+	r.wods.mu.RLock()
+	defer r.wods.mu.RUnlock()
+	return len(r.purgeable(cutoff)), nil
+}
+
+func (r *MemoryRetentionRepository) PurgeWods(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	r.wods.mu.Lock()
+	defer r.wods.mu.Unlock()
+
+	wods := r.purgeable(cutoff)
+	wods = wods[:min(limit, len(wods))]
+	for _, w := range wods {
+		delete(r.wods.wods, w.ID)
+	}
+	return len(wods), nil
+}
+
+func (r *MemoryRetentionRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	if !r.mu.TryLock() {
+		return nil, false, nil
+	}
+	return r.mu.Unlock, true, nil
+}
+
+// purgeable returns the wods the SQL purgeable clause matches, oldest first.
+// The wods lock must be held, it is taken before the others.
+func (r *MemoryRetentionRepository) purgeable(cutoff time.Time) []models.Wod {
+	kept := make(map[uuid.UUID]bool)
+	for _, byWod := range r.wods.annotations {
+		for id := range byWod {
+			kept[id] = true
+		}
+	}
+	r.results.mu.RLock()
+	for _, res := range r.results.results {
+		kept[res.WodID] = true
+	}
+	r.results.mu.RUnlock()
+	r.calendar.mu.RLock()
+	for _, e := range r.calendar.entries {
+		kept[e.WodID] = true
+	}
+	r.calendar.mu.RUnlock()
+
+	var wods []models.Wod
+	for _, w := range r.wods.wods {
+		if w.CreatedAt.Before(cutoff) && !kept[w.ID] {
+			wods = append(wods, w)
+		}
+	}
+	slices.SortFunc(wods, func(a, b models.Wod) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return wods
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+)
+
+// MemoryStatsRepository aggregates the wods of a MemoryWodRepository and the
+// results of the MemoryResultRepository next to it.
+type MemoryStatsRepository struct {
+	wods    *MemoryWodRepository
+	results *MemoryResultRepository
+}
+
+func NewMemoryStatsRepository(wods *MemoryWodRepository, results *MemoryResultRepository) *MemoryStatsRepository {
+	return &MemoryStatsRepository{wods: wods, results: results}
+}
+
+// WodStats computes in Go what the SQL aggregations of WodStats select.
+func (r *MemoryStatsRepository) WodStats(ctx context.Context, f StatsFilter) (models.Stats, error) {
+	if _, ok := pgStatsPeriods[f.Period]; !ok {
+		return models.Stats{}, fmt.Errorf("unknown stats period %q", f.Period)
+	}
+
+	levels := make(map[string]int)
+	durations := make(map[int]int)
+	equipment := make(map[string]int)
+	moves := make(map[[2]string]*models.MoveStat)
+	periods := make(map[time.Time]*models.PeriodStat)
+	period := func(t time.Time) *models.PeriodStat {
+		start := periodStart(f.Period, t)
+		if periods[start] == nil {
+			periods[start] = &models.PeriodStat{Start: start}
+		}
+		return periods[start]
+	}
+
+	var s models.Stats
+	wods, results := f.wods(), f.results()
+	r.wods.mu.RLock()
+	for _, w := range r.wods.wods {
+		if !wods.matches(w) {
+			continue
+		}
+		s.Wods++
+		levels[w.Level]++
+		durations[w.DurationMin]++
+		for _, e := range slices.Compact(slices.Sorted(slices.Values(w.Equipment))) {
+			equipment[e]++
+		}
+		p := period(w.CreatedAt)
+		p.Wods++
+		countBlocks(w.Blocks, moves, p)
+	}
+	r.wods.mu.RUnlock()
+
+	r.results.mu.RLock()
+	for _, res := range r.results.results {
+		if results.matches(res) {
+			s.Results++
+			period(res.CompletedAt).Results++
+		}
+	}
+	r.results.mu.RUnlock()
+
+	for _, k := range slices.Sorted(maps.Keys(durations)) {
+		s.Durations = append(s.Durations, models.DurationStat{DurationMin: k, Wods: durations[k]})
+	}
+	s.Levels = countStats(levels)
+	s.Equipment = countStats(equipment)
+	for _, m := range moves {
+		s.Moves = append(s.Moves, *m)
+	}
+	slices.SortFunc(s.Moves, func(a, b models.MoveStat) int {
+		return cmp.Or(cmp.Compare(b.Blocks, a.Blocks), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	for _, k := range slices.SortedFunc(maps.Keys(periods), time.Time.Compare) {
+		s.Periods = append(s.Periods, *periods[k])
+	}
+	return s, nil
+}
+
+// countBlocks counts the blocks of a wod in moves, by move ID and name, and
+// sums their meters and reps in p.
+func countBlocks(blocks []models.Block, moves map[[2]string]*models.MoveStat, p *models.PeriodStat) {
+	seen := make(map[[2]string]bool)
+	for _, b := range blocks {
+		key := [2]string{b.ID, b.Name}
+		if moves[key] == nil {
+			moves[key] = &models.MoveStat{ID: b.ID, Name: b.Name}
+		}
+		moves[key].Blocks++
+		if !seen[key] {
+			seen[key] = true
+			moves[key].Wods++
+		}
+		p.Meters += number(b.Params["meters"])
+		p.Reps += number(b.Params["reps"])
+	}
+}
+
+// periodStart is the first day of the week, a Monday, or month of t, at
+// midnight UTC.
+func periodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	if period == StatsPeriodMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// countStats orders counts as the SQL does, most wods first then by key.
+func countStats(counts map[string]int) []models.CountStat {
+	var stats []models.CountStat
+	for k, n := range counts {
+		stats = append(stats, models.CountStat{Key: k, Wods: n})
+	}
+	slices.SortFunc(stats, func(a, b models.CountStat) int {
+		return cmp.Or(cmp.Compare(b.Wods, a.Wods), cmp.Compare(a.Key, b.Key))
+	})
+	return stats
+}
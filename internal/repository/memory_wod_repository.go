@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryWodRepository keeps the wods in memory, for tests and demos: they are
+// lost on restart and not shared between replicas.
+type MemoryWodRepository struct {
+	mu   sync.RWMutex
+	wods map[uuid.UUID]models.Wod
+	// annotations by subject then wod.
+	annotations map[string]map[uuid.UUID]models.Annotations
+}
+
+func NewMemoryWodRepository() *MemoryWodRepository {
+	return &MemoryWodRepository{
+		wods:        make(map[uuid.UUID]models.Wod),
+		annotations: make(map[string]map[uuid.UUID]models.Annotations),
+	}
+}
+
+func (r *MemoryWodRepository) SaveWod(ctx context.Context, w models.Wod) (models.Wod, error) {
+	if _, err := r.SaveWods(ctx, []models.Wod{w}); err != nil {
+		return models.Wod{}, err
+	}
+	return w, nil
+}
+
+func (r *MemoryWodRepository) SaveWods(ctx context.Context, wods []models.Wod) ([]models.Wod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// checked first, so a conflict stores none of them.
+	ids := make(map[uuid.UUID]bool)
+	keys := make(map[[2]string]bool)
+	for _, w := range wods {
+		if _, ok := r.wods[w.ID]; ok || ids[w.ID] {
+			return nil, common.ErrWodExists
+		}
+		ids[w.ID] = true
+		if w.IdempotencyKey == "" {
+			continue
+		}
+		key := [2]string{w.OwnerSub, w.IdempotencyKey}
+		if keys[key] || len(r.matching(WodFilter{Owner: w.OwnerSub, IdempotencyKey: w.IdempotencyKey})) > 0 {
+			return nil, common.ErrWodExists
+		}
+		keys[key] = true
+	}
+	for _, w := range wods {
+		r.wods[w.ID] = cloneWod(w)
+	}
+	return wods, nil
+}
+
+func (r *MemoryWodRepository) UpsertWods(ctx context.Context, wods []models.Wod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// checked first, so a conflict stores none of them.
+	keys := make(map[[2]string]uuid.UUID)
+	for _, w := range wods {
+		if stored, ok := r.wods[w.ID]; ok && stored.OwnerSub != w.OwnerSub {
+			return fmt.Errorf("%w: %s belongs to another owner", common.ErrWodExists, w.ID)
+		}
+		if w.IdempotencyKey == "" {
+			continue
+		}
+		key := [2]string{w.OwnerSub, w.IdempotencyKey}
+		if id, ok := keys[key]; ok && id != w.ID {
+			return common.ErrWodExists
+		}
+		keys[key] = w.ID
+		for _, stored := range r.matching(WodFilter{Owner: w.OwnerSub, IdempotencyKey: w.IdempotencyKey}) {
+			if stored.ID != w.ID {
+				return common.ErrWodExists
+			}
+		}
+	}
+	for _, w := range wods {
+		r.wods[w.ID] = cloneWod(w)
+	}
+	return nil
+}
+
+func (r *MemoryWodRepository) ListWods(ctx context.Context, f WodFilter, p WodPage) ([]models.Wod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// walk the listing towards the page, backwards to seek before a key.
+	reverse := p.Before != nil
+	dir := -1
+	if f.Ascending != reverse {
+		dir = 1
+	}
+	wods := r.matching(f)
+	slices.SortFunc(wods, func(a, b models.Wod) int { return dir * compareKeys(f.Sort, KeyOf(a), KeyOf(b)) })
+	if key := p.key(); key != nil {
+		wods = slices.DeleteFunc(wods, func(w models.Wod) bool { return dir*compareKeys(f.Sort, KeyOf(w), *key) <= 0 })
+	}
+
+	wods = wods[min(p.Offset, len(wods)):]
+	wods = wods[:min(p.Limit, len(wods))]
+	if reverse {
+		slices.Reverse(wods)
+	}
+	return wods, nil
+}
+
+func (r *MemoryWodRepository) CountWods(ctx context.Context, f WodFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.matching(f)), nil
+}
+
+func (r *MemoryWodRepository) GetWod(ctx context.Context, id uuid.UUID) (models.Wod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.wods[id]
+	if !ok {
+		return models.Wod{}, common.ErrWodNotFound
+	}
+	return cloneWod(w), nil
+}
+
+func (r *MemoryWodRepository) GetAnnotations(ctx context.Context, subject string, ids []uuid.UUID) (map[uuid.UUID]models.Annotations, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := map[uuid.UUID]models.Annotations{}
+	for _, id := range ids {
+		if a, ok := r.annotations[subject][id]; ok {
+			a.Labels = slices.Clone(a.Labels)
+			out[id] = a
+		}
+	}
+	return out, nil
+}
+
+func (r *MemoryWodRepository) SaveAnnotations(ctx context.Context, subject string, id uuid.UUID, a models.Annotations) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !a.Favorite && a.Note == "" && len(a.Labels) == 0 {
+		delete(r.annotations[subject], id)
+		return nil
+	}
+	if r.annotations[subject] == nil {
+		r.annotations[subject] = make(map[uuid.UUID]models.Annotations)
+	}
+	a.Labels = slices.Clone(a.Labels)
+	slices.Sort(a.Labels)
+	r.annotations[subject][id] = a
+	return nil
+}
+
+// matching returns copies of the wods matching f, the caller holds the lock.
+func (r *MemoryWodRepository) matching(f WodFilter) []models.Wod {
+	var wods []models.Wod
+	for _, w := range r.wods {
+		if f.matches(w) && f.matchesAnnotations(r.annotations[f.Annotator][w.ID]) {
+			wods = append(wods, cloneWod(w))
+		}
+	}
+	return wods
+}
+
+// matches is the WHERE clause of where, evaluated on w.
+func (f WodFilter) matches(w models.Wod) bool {
+	switch {
+	case !f.AnyOwner && w.OwnerSub != f.Owner,
+		f.Catalog != "" && w.Catalog != f.Catalog,
+		f.CatalogVersion != 0 && w.CatalogVersion != f.CatalogVersion,
+		f.CatalogHash != "" && w.CatalogHash != f.CatalogHash,
+		f.IdempotencyKey != "" && w.IdempotencyKey != f.IdempotencyKey,
+		f.Level != "" && w.Level != f.Level,
+		f.MinDuration > 0 && w.DurationMin < f.MinDuration,
+		f.MaxDuration > 0 && w.DurationMin > f.MaxDuration,
+		!f.CreatedAfter.IsZero() && w.CreatedAt.Before(f.CreatedAfter),
+		!f.CreatedBefore.IsZero() && !w.CreatedAt.Before(f.CreatedBefore),
+		f.Seed != "" && w.Seed != f.Seed,
+		len(f.IDs) > 0 && !slices.Contains(f.IDs, w.ID):
+		return false
+	}
+
+	if len(f.Equipment) > 0 {
+		has := func(e string) bool { return slices.Contains(w.Equipment, e) }
+		if f.AnyEquipment && !slices.ContainsFunc(f.Equipment, has) {
+			return false
+		}
+		if !f.AnyEquipment && slices.ContainsFunc(f.Equipment, func(e string) bool { return !has(e) }) {
+			return false
+		}
+	}
+
+	if f.MoveID != "" || f.MoveName != "" {
+		has := slices.ContainsFunc(w.Blocks, func(b models.Block) bool {
+			return (f.MoveID != "" && b.ID == f.MoveID) || (f.MoveName != "" && b.Name == f.MoveName)
+		})
+		if !has {
+			return false
+		}
+	}
+	has := func(m MoveMatch) bool { return m.matches(w) }
+	switch {
+	case slices.ContainsFunc(f.AllMoves, func(m MoveMatch) bool { return !has(m) }),
+		len(f.AnyMoves) > 0 && !slices.ContainsFunc(f.AnyMoves, has),
+		slices.ContainsFunc(f.NoMoves, has):
+		return false
+	}
+	return true
+}
+
+// matches is where, evaluated on w. Params that are not numbers count for
+// nothing, as in SQL.
+func (m MoveMatch) matches(w models.Wod) bool {
+	is := func(b models.Block) bool { return (m.ID != "" && b.ID == m.ID) || (m.Name != "" && b.Name == m.Name) }
+	if !slices.ContainsFunc(w.Blocks, is) {
+		return false
+	}
+	for k, r := range m.Params {
+		var total float64
+		for _, b := range w.Blocks {
+			if is(b) {
+				total += number(b.Params[k])
+			}
+		}
+		if (r.Min != nil && total < *r.Min) || (r.Max != nil && total > *r.Max) {
+			return false
+		}
+	}
+	return true
+}
+
+// number is the value of a param, 0 when it is not a number.
+func number(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	case json.Number:
+		f, _ := n.Float64()
+		return f
+	}
+	return 0
+}
+
+// matchesAnnotations is the annotation part of the WHERE clause of where,
+// evaluated on the annotations a of the annotator.
+func (f WodFilter) matchesAnnotations(a models.Annotations) bool {
+	switch {
+	case f.Favorite && !a.Favorite,
+		slices.ContainsFunc(f.Labels, func(l string) bool { return !slices.Contains(a.Labels, l) }),
+		f.Note != "" && !strings.Contains(strings.ToLower(a.Note), strings.ToLower(f.Note)):
+		return false
+	}
+	return true
+}
+
+// compareKeys orders two wods ascending on the sort keys, ties broken by id
+// the way Postgres compares uuids.
+func compareKeys(sort string, a, b WodKey) int {
+	if sort == WodSortDuration {
+		if c := cmp.Compare(a.DurationMin, b.DurationMin); c != 0 {
+			return c
+		}
+	}
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+func cloneWod(w models.Wod) models.Wod {
+	w.Equipment = slices.Clone(w.Equipment)
+	w.Blocks = slices.Clone(w.Blocks)
+	return w
+}
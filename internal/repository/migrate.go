@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+)
+
+// migrateLockKey is the advisory lock held while migrating, so replicas
+// booting together apply each migration once.
+const migrateLockKey int64 = 0x776f6467656e // "wodgen"
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a schema change, NNN_name.up.sql and its optional
+// NNN_name.down.sql.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is the version the schema is at and the migrations left to
+// apply.
+type MigrationStatus struct {
+	Version int64
+	Dirty   bool
+	Pending []Migration
+}
+
+// Migrator applies the migrations and records the schema version in
+// schema_migrations, the table and layout golang-migrate uses, so databases
+// it migrated carry on from their version.
+type Migrator struct {
+	db         *sql.DB
+	dialect    migrateDialect
+	migrations []Migration
+}
+
+// migrateDialect holds the statements of the Migrator its backends don't
+// share.
+type migrateDialect struct {
+	// tableExists tells whether schema_migrations exists.
+	tableExists string
+	// lock and unlock take migrateLockKey on the migrating connection,
+	// nothing is locked when empty.
+	lock, unlock string
+}
+
+var (
+	postgresDialect = migrateDialect{
+		tableExists: `SELECT to_regclass('schema_migrations') IS NOT NULL`,
+		lock:        `SELECT pg_advisory_lock($1)`,
+		unlock:      `SELECT pg_advisory_unlock($1)`,
+	}
+	// a SQLite file has a single writer, nothing boots alongside it.
+	sqliteDialect = migrateDialect{
+		tableExists: `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')`,
+	}
+)
+
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, postgresDialect, fsys)
+}
+
+// NewSQLiteMigrator migrates a SQLite file, with the migrations of
+// db.SQLiteMigrations.
+func NewSQLiteMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(db, sqliteDialect, fsys)
+}
+
+func newMigrator(db *sql.DB, dialect migrateDialect, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// LoadMigrations reads the migrations of fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("fs.Glob: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, p := range paths {
+		m := migrationFile.FindStringSubmatch(path.Base(p))
+		if m == nil {
+			return nil, fmt.Errorf("%w: %s is not NNN_name.up.sql or NNN_name.down.sql", common.ErrInvalidMigration, p)
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s has no version", common.ErrInvalidMigration, p)
+		}
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("fs.ReadFile(%s): %w", p, err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("%w: version %d is both %s and %s", common.ErrInvalidMigration, version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(raw)
+		} else {
+			mig.Down = string(raw)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("%w: %03d_%s has no up migration", common.ErrInvalidMigration, mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// Status reads the schema version without taking the lock.
+func (m *Migrator) Status(ctx context.Context) (MigrationStatus, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx, m.dialect.tableExists).Scan(&exists)
+	if err != nil {
+		return MigrationStatus{}, fmt.Errorf("db.QueryRowContext: %w", err)
+	}
+
+	var st MigrationStatus
+	if exists {
+		st.Version, st.Dirty, err = version(ctx, m.db)
+		if err != nil {
+			return MigrationStatus{}, err
+		}
+	}
+	st.Pending = m.pending(st.Version)
+	return st, nil
+}
+
+// Up applies the pending migrations in order, each in its own transaction
+// with the version it brings the schema to.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(conn *sql.Conn, current int64) error {
+		for _, mig := range m.pending(current) {
+			if err := apply(ctx, conn, mig.Up, mig.Version); err != nil {
+				return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, err)
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps migrations applied, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.locked(ctx, func(conn *sql.Conn, current int64) error {
+		i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version == current })
+		if current != 0 && i < 0 {
+			return fmt.Errorf("%w: the schema is at version %d, unknown to this binary", common.ErrInvalidMigration, current)
+		}
+		for ; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.migrations[i]
+			if mig.Down == "" {
+				return fmt.Errorf("%w: %03d_%s has no down migration", common.ErrInvalidMigration, mig.Version, mig.Name)
+			}
+			var previous int64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := apply(ctx, conn, mig.Down, previous); err != nil {
+				return fmt.Errorf("%03d_%s: %w", mig.Version, mig.Name, err)
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+func (m *Migrator) pending(current int64) []Migration {
+	i := slices.IndexFunc(m.migrations, func(mig Migration) bool { return mig.Version > current })
+	if i < 0 {
+		return nil
+	}
+	return m.migrations[i:]
+}
+
+// locked runs fn on a connection holding the migration lock, once the
+// versions table exists and the schema is known clean.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, current int64) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("db.Conn: %w", err)
+	}
+	defer conn.Close()
+
+	// a session lock belongs to the connection, hold one for the whole run.
+	if m.dialect.lock != "" {
+		if _, err = conn.ExecContext(ctx, m.dialect.lock, migrateLockKey); err != nil {
+			return fmt.Errorf("lock migrations: %w", err)
+		}
+		defer func() {
+			if _, err := conn.ExecContext(context.WithoutCancel(ctx), m.dialect.unlock, migrateLockKey); err != nil {
+				slog.Warn("unlock migrations", slog.Any("err", err))
+			}
+		}()
+	}
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	current, dirty, err := version(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w (version %d)", common.ErrDirtySchema, current)
+	}
+	return fn(conn, current)
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func version(ctx context.Context, q queryRower) (int64, bool, error) {
+	var v int64
+	var dirty bool
+	err := q.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&v, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	return v, dirty, nil
+}
+
+// apply runs a migration script and records the version it leaves the
+// schema at, 0 for none, in one transaction.
+func apply(ctx context.Context, conn *sql.Conn, script string, to int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("conn.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("tx.ExecContext: %w", err)
+	}
+	if to != 0 {
+		if _, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, to); err != nil {
+			return fmt.Errorf("tx.ExecContext: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/db"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func migrationsFS() fstest.MapFS {
+	return fstest.MapFS{
+		"001_init.up.sql":     {Data: []byte("CREATE TABLE wods (id UUID)")},
+		"001_init.down.sql":   {Data: []byte("DROP TABLE wods")},
+		"002_owner.up.sql":    {Data: []byte("ALTER TABLE wods ADD owner_sub TEXT")},
+		"002_owner.down.sql":  {Data: []byte("ALTER TABLE wods DROP owner_sub")},
+		"010_search.up.sql":   {Data: []byte("CREATE INDEX idx_wods_owner ON wods(owner_sub)")},
+		"010_search.down.sql": {Data: []byte("DROP INDEX idx_wods_owner")},
+	}
+}
+
+func expectLock(mock sqlmock.Sqlmock, version int64) {
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version != 0 {
+		rows.AddRow(version, false)
+	}
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).WillReturnRows(rows)
+}
+
+func expectApply(mock sqlmock.Sqlmock, script string, to int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(script).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 1))
+	if to != 0 {
+		mock.ExpectExec(`INSERT INTO schema_migrations`).WithArgs(to).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	for name, fsys := range map[string]fs.FS{"postgres": db.Migrations(), "sqlite": db.SQLiteMigrations()} {
+		migrations, err := repository.LoadMigrations(fsys)
+		require.NoError(t, err, name)
+		require.NotEmpty(t, migrations, name)
+		for i, m := range migrations {
+			require.Equal(t, int64(i+1), m.Version, "%s versions follow each other", name)
+			require.NotEmpty(t, m.Down, "%s %03d_%s can be reverted", name, m.Version, m.Name)
+		}
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"no up":        {"001_init.down.sql": {Data: []byte("DROP TABLE wods")}},
+		"bad name":     {"init.sql": {Data: []byte("CREATE TABLE wods (id UUID)")}},
+		"same version": {"001_a.up.sql": {Data: []byte("SELECT 1")}, "001_b.up.sql": {Data: []byte("SELECT 1")}},
+	} {
+		_, err := repository.LoadMigrations(fsys)
+		require.ErrorIs(t, err, common.ErrInvalidMigration, name)
+	}
+}
+
+func TestMigrator_UpAppliesPending(t *testing.T) {
+	database, mock, _ := sqlmock.New()
+	migrator, err := repository.NewMigrator(database, migrationsFS())
+	require.NoError(t, err)
+
+	expectLock(mock, 1)
+	expectApply(mock, `ALTER TABLE wods ADD owner_sub TEXT`, 2)
+	expectApply(mock, `CREATE INDEX idx_wods_owner`, 10)
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	require.Equal(t, "owner", applied[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpStopsAtFailure(t *testing.T) {
+	database, mock, _ := sqlmock.New()
+	migrator, err := repository.NewMigrator(database, migrationsFS())
+	require.NoError(t, err)
+
+	expectLock(mock, 0)
+	expectApply(mock, `CREATE TABLE wods`, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(`ALTER TABLE wods ADD owner_sub TEXT`).WillReturnError(context.DeadlineExceeded)
+	mock.ExpectRollback()
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "002_owner")
+	require.Len(t, applied, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DownRevertsSteps(t *testing.T) {
+	database, mock, _ := sqlmock.New()
+	migrator, err := repository.NewMigrator(database, migrationsFS())
+	require.NoError(t, err)
+
+	expectLock(mock, 10)
+	expectApply(mock, `DROP INDEX idx_wods_owner`, 2)
+	expectApply(mock, `ALTER TABLE wods DROP owner_sub`, 1)
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reverted, err := migrator.Down(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, reverted, 2)
+	require.Equal(t, int64(10), reverted[0].Version)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DirtySchema(t *testing.T) {
+	database, mock, _ := sqlmock.New()
+	migrator, err := repository.NewMigrator(database, migrationsFS())
+	require.NoError(t, err)
+
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, true))
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = migrator.Up(context.Background())
+	require.ErrorIs(t, err, common.ErrDirtySchema)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	database, mock, _ := sqlmock.New()
+	migrator, err := repository.NewMigrator(database, migrationsFS())
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT to_regclass\('schema_migrations'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+
+	st, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), st.Version)
+	require.Len(t, st.Pending, 1)
+	require.Equal(t, int64(10), st.Pending[0].Version)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLiteMigrator(t *testing.T) {
+	ctx := context.Background()
+	database, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "wodgen.db"))
+	require.NoError(t, err)
+	defer database.Close()
+
+	migrations, err := repository.LoadMigrations(db.SQLiteMigrations())
+	require.NoError(t, err)
+	migrator, err := repository.NewSQLiteMigrator(database, db.SQLiteMigrations())
+	require.NoError(t, err)
+	st, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, repository.MigrationStatus{Pending: migrations}, st)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrations, applied)
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	st, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, repository.MigrationStatus{Version: migrations[len(migrations)-1].Version}, st)
+
+	reverted, err := migrator.Down(ctx, len(migrations))
+	require.NoError(t, err)
+	require.Len(t, reverted, len(migrations))
+	var tables int
+	require.NoError(t, database.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name != 'schema_migrations'`).Scan(&tables))
+	require.Zero(t, tables)
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+type ResultRepositoryInterface interface {
+	SaveResult(ctx context.Context, r models.Result) (models.Result, error)
+	ListResults(ctx context.Context, f ResultFilter, limit, offset int) ([]models.Result, error)
+}
+
+const resultColumns = "id, wod_id, subject, time_sec, rounds, reps, load_kg, division, splits, rpe, scaling, " +
+	"completed_at, created_at"
+
+// ResultFilter narrows the results listed, the zero values match everything.
+// They are listed latest completion first.
+type ResultFilter struct {
+	WodID uuid.UUID
+	// WodIDs matches the results on any of these wods.
+	WodIDs   []uuid.UUID
+	Subject  string
+	Division string
+	// CompletedAfter is inclusive, CompletedBefore exclusive.
+	CompletedAfter  time.Time
+	CompletedBefore time.Time
+	// After seeks the results listed after this one, which stays stable
+	// while results are logged, offsets shift.
+	After *ResultKey
+}
+
+// ResultKey is the position of a result in the listing.
+type ResultKey struct {
+	CompletedAt time.Time
+	ID          uuid.UUID
+}
+
+// where renders f as a WHERE clause, with placeholder p(n) for the n-th arg
+// and timestamps passed as t(ts).
+func (f ResultFilter) where(p func(n int) string, t func(time.Time) any) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, cond+p(len(args)))
+	}
+	if f.WodID != uuid.Nil {
+		add("wod_id = ", f.WodID.String())
+	}
+	if len(f.WodIDs) > 0 {
+		in := make([]string, len(f.WodIDs))
+		for i, id := range f.WodIDs {
+			args = append(args, id.String())
+			in[i] = p(len(args))
+		}
+		conds = append(conds, "wod_id IN ("+strings.Join(in, ", ")+")")
+	}
+	if f.Subject != "" {
+		add("subject = ", f.Subject)
+	}
+	if f.Division != "" {
+		add("division = ", f.Division)
+	}
+	if !f.CompletedAfter.IsZero() {
+		add("completed_at >= ", t(f.CompletedAfter))
+	}
+	if !f.CompletedBefore.IsZero() {
+		add("completed_at < ", t(f.CompletedBefore))
+	}
+	if f.After != nil {
+		args = append(args, t(f.After.CompletedAt), f.After.ID.String())
+		conds = append(conds, fmt.Sprintf("(completed_at, id) < (%s, %s)", p(len(args)-1), p(len(args))))
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+func pgPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+func pgTime(t time.Time) any { return t }
+
+type ResultRepository struct {
+	db *sql.DB
+}
+
+func NewResultRepository(db *sql.DB) *ResultRepository {
+	return &ResultRepository{db: db}
+}
+
+func (r *ResultRepository) SaveResult(ctx context.Context, res models.Result) (models.Result, error) {
+	splits, err := json.Marshal(splitsOrEmpty(res.Splits))
+	if err != nil {
+		return models.Result{}, fmt.Errorf("json.Marshal: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO wod_results (`+resultColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, res.ID, res.WodID, res.Subject, res.TimeSec, res.Rounds, res.Reps, res.LoadKg, res.Division, splits, res.RPE,
+		res.Scaling, res.CompletedAt, res.CreatedAt,
+	)
+	if err != nil {
+		return models.Result{}, fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return res, nil
+}
+
+func (r *ResultRepository) ListResults(ctx context.Context, f ResultFilter, limit, offset int) ([]models.Result, error) {
+	where, args := f.where(pgPlaceholder, pgTime)
+	args = append(args, limit, offset)
+	return queryResults(ctx, r.db, fmt.Sprintf(`
+		SELECT %s
+		FROM wod_results
+		%s
+		ORDER BY completed_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, resultColumns, where, len(args)-1, len(args)), args, scanResult)
+}
+
+// queryResults runs a query of resultColumns and scans its rows with scan.
+func queryResults(ctx context.Context, db *sql.DB, query string, args []any,
+	scan func(row interface{ Scan(dest ...any) error }) (models.Result, error),
+) ([]models.Result, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	var results []models.Result
+	for rows.Next() {
+		res, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return results, nil
+}
+
+// scanResult reads a row of resultColumns.
+func scanResult(row interface{ Scan(dest ...any) error }) (models.Result, error) {
+	var res models.Result
+	var timeSec, rounds, reps, rpe sql.NullInt64
+	var loadKg sql.NullFloat64
+	var splits []byte
+	err := row.Scan(&res.ID, &res.WodID, &res.Subject, &timeSec, &rounds, &reps, &loadKg, &res.Division, &splits, &rpe,
+		&res.Scaling, &res.CompletedAt, &res.CreatedAt)
+	if err != nil {
+		return models.Result{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	res.TimeSec, res.Rounds, res.Reps, res.RPE = intOrNil(timeSec), intOrNil(rounds), intOrNil(reps), intOrNil(rpe)
+	res.LoadKg = floatOrNil(loadKg)
+	if err := json.Unmarshal(splits, &res.Splits); err != nil {
+		return models.Result{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return res, nil
+}
+
+func intOrNil(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+func floatOrNil(n sql.NullFloat64) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Float64
+}
+
+func splitsOrEmpty(splits []models.Split) []models.Split {
+	if splits == nil {
+		return []models.Split{}
+	}
+	return splits
+}
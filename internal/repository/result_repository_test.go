@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveResult_DBError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec("INSERT INTO wod_results").WillReturnError(errors.New("db fail"))
+
+	repo := repository.NewResultRepository(db)
+	_, err := repo.SaveResult(context.Background(), models.Result{ID: uuid.New(), WodID: uuid.New()})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "db.ExecContext")
+}
+
+func TestListResults_ByWod(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	id, wodID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "wod_id", "subject", "time_sec", "rounds", "reps", "load_kg", "division", "splits", "rpe", "scaling",
+		"completed_at", "created_at",
+	}).AddRow(id, wodID, "alice", nil, 5, 12, nil, "rx", []byte(`[{"block":1,"time_sec":240}]`), 8, "", now, now)
+	mock.ExpectQuery(`FROM wod_results\s+WHERE wod_id = \$1\s+ORDER BY completed_at DESC, id DESC\s+LIMIT \$2 OFFSET \$3`).
+		WithArgs(wodID.String(), 20, 0).
+		WillReturnRows(rows)
+
+	repo := repository.NewResultRepository(db)
+	results, err := repo.ListResults(context.Background(), repository.ResultFilter{WodID: wodID}, 20, 0)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].TimeSec)
+	require.Equal(t, 5, *results[0].Rounds)
+	require.Nil(t, results[0].LoadKg)
+	require.Equal(t, "rx", results[0].Division)
+	require.Equal(t, []models.Split{{Block: 1, TimeSec: 240}}, results[0].Splits)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListResults_Leaderboard(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	a, b := uuid.New(), uuid.New()
+	after := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`WHERE wod_id IN \(\$1, \$2\) AND division = \$3 AND completed_at >= \$4\s+ORDER BY`).
+		WithArgs(a.String(), b.String(), "rx", after, 100, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewResultRepository(db)
+	f := repository.ResultFilter{WodIDs: []uuid.UUID{a, b}, Division: "rx", CompletedAfter: after}
+	_, err := repo.ListResults(context.Background(), f, 100, 0)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListResults_After(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	key := repository.ResultKey{CompletedAt: time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC), ID: uuid.New()}
+
+	mock.ExpectQuery(`WHERE subject = \$1 AND \(completed_at, id\) < \(\$2, \$3\)\s+ORDER BY completed_at DESC, id DESC`).
+		WithArgs("alice", key.CompletedAt, key.ID.String(), 500, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewResultRepository(db)
+	_, err := repo.ListResults(context.Background(), repository.ResultFilter{Subject: "alice", After: &key}, 500, 0)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
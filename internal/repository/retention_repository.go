@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// purgeLockKey is the advisory lock held while purging, so a single replica
+// purges at a time.
+const purgeLockKey int64 = 0x776f64707267 // "wodprg"
+
+// RetentionRepositoryInterface purges the wods nobody kept: created before a
+// cutoff, with no result, favorite, note, label or calendar entry.
+type RetentionRepositoryInterface interface {
+	// CountPurgeable counts the wods PurgeWods would delete, all of them.
+	CountPurgeable(ctx context.Context, cutoff time.Time) (int, error)
+	// PurgeWods deletes up to limit of them, oldest first, and returns how many.
+	PurgeWods(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	// TryLock takes the purge lock without waiting, ok is false when another
+	// process holds it. unlock releases it.
+	TryLock(ctx context.Context) (unlock func(), ok bool, err error)
+}
+
+// purgeable is the WHERE clause of the wods w created before the cutoff,
+// placeholder p, that nothing references.
+func purgeable(p string) string {
+	return `w.created_at < ` + p + `
+		AND NOT EXISTS (SELECT 1 FROM wod_results r WHERE r.wod_id = w.id)
+		AND NOT EXISTS (SELECT 1 FROM wod_favorites f WHERE f.wod_id = w.id)
+		AND NOT EXISTS (SELECT 1 FROM wod_notes n WHERE n.wod_id = w.id)
+		AND NOT EXISTS (SELECT 1 FROM wod_labels l WHERE l.wod_id = w.id)
+		AND NOT EXISTS (SELECT 1 FROM calendar_entries c WHERE c.wod_id = w.id)`
+}
+
+type RetentionRepository struct {
+	db *sql.DB
+}
+
+func NewRetentionRepository(db *sql.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+func (r *RetentionRepository) CountPurgeable(ctx context.Context, cutoff time.Time) (int, error) {
+	return countPurgeable(ctx, r.db, `SELECT COUNT(*) FROM wods w WHERE `+purgeable("$1"), cutoff)
+}
+
+// PurgeWods skips the wods locked by a writer, a favorite or a result being
+// added, the next run gets them if they are still unreferenced.
+func (r *RetentionRepository) PurgeWods(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	return deleteEntries(ctx, r.db, `
+		DELETE FROM wods WHERE id IN (
+			SELECT w.id FROM wods w
+			WHERE `+purgeable("$1")+`
+			ORDER BY w.created_at, w.id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, []any{cutoff, limit})
+}
+
+// TryLock holds a session advisory lock on a connection of its own until
+// unlock, replicas of the server share it with the purge command.
+func (r *RetentionRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("db.Conn: %w", err)
+	}
+	var ok bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, purgeLockKey).Scan(&ok); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("pg_try_advisory_lock: %w", err)
+	}
+	if !ok {
+		conn.Close()
+		return nil, false, nil
+	}
+	unlock := func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, purgeLockKey); err != nil {
+			slog.Warn("pg_advisory_unlock", slog.Any("err", err))
+		}
+		conn.Close()
+	}
+	return unlock, true, nil
+}
+
+func countPurgeable(ctx context.Context, db *sql.DB, query string, cutoff any) (int, error) {
+	var n int
+	if err := db.QueryRowContext(ctx, query, cutoff).Scan(&n); err != nil {
+		return 0, fmt.Errorf("db.QueryRowContext: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeWods_SkipsLocked(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	cutoff := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`DELETE FROM wods WHERE id IN \(\s+SELECT w.id FROM wods w\s+WHERE w.created_at < \$1 .*`+
+		`ORDER BY w.created_at, w.id\s+LIMIT \$2\s+FOR UPDATE SKIP LOCKED`).
+		WithArgs(cutoff, 500).
+		WillReturnResult(sqlmock.NewResult(0, 42))
+
+	n, err := repository.NewRetentionRepository(db).PurgeWods(context.Background(), cutoff, 500)
+
+	require.NoError(t, err)
+	require.Equal(t, 42, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTryLock_HeldElsewhere(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+	unlock, ok, err := repository.NewRetentionRepository(db).TryLock(context.Background())
+
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, unlock)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTryLock_Unlocks(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	unlock, ok, err := repository.NewRetentionRepository(db).TryLock(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	unlock()
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
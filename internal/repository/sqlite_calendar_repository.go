@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// SQLiteCalendarRepository stores the calendars next to a SQLiteWodRepository.
+type SQLiteCalendarRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteCalendarRepository(db *sql.DB) *SQLiteCalendarRepository {
+	return &SQLiteCalendarRepository{db: db}
+}
+
+func (r *SQLiteCalendarRepository) SaveEntries(ctx context.Context, entries []models.CalendarEntry) error {
+	return saveEntries(ctx, r.db, `
+		INSERT INTO calendar_entries (`+calendarColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, entries, func(t time.Time) any { return t.UTC().Format(sqliteTime) })
+}
+
+func (r *SQLiteCalendarRepository) UpdateEntry(ctx context.Context, e models.CalendarEntry) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE calendar_entries SET wod_id = ?, day = ?, program = ?, note = ?, updated_at = ?
+		WHERE subject = ? AND id = ?
+	`, e.WodID.String(), e.Date.Format(calendarDay), e.Program, e.Note, e.UpdatedAt.UTC().Format(sqliteTime),
+		e.Subject, e.ID.String())
+	return updated(res, err)
+}
+
+func (r *SQLiteCalendarRepository) DeleteEntries(ctx context.Context, f CalendarFilter) (int, error) {
+	where, args := f.where(func(int) string { return "?" })
+	return deleteEntries(ctx, r.db, `DELETE FROM calendar_entries `+where, args)
+}
+
+func (r *SQLiteCalendarRepository) ListEntries(ctx context.Context, f CalendarFilter, limit, offset int) ([]models.CalendarEntry, error) {
+	where, args := f.where(func(int) string { return "?" })
+	args = append(args, limit, offset)
+	return queryEntries(ctx, r.db, fmt.Sprintf(`
+		SELECT %s
+		FROM calendar_entries
+		%s
+		ORDER BY day, created_at, id
+		LIMIT ? OFFSET ?
+	`, calendarColumns, where), args, scanSQLiteEntry)
+}
+
+func (r *SQLiteCalendarRepository) SaveFeed(ctx context.Context, subject, tokenHash string, createdAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO calendar_feeds (subject, token_hash, created_at) VALUES (?, ?, ?)
+		ON CONFLICT (subject) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at
+	`, subject, tokenHash, createdAt.UTC().Format(sqliteTime))
+	if err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteCalendarRepository) DeleteFeed(ctx context.Context, subject string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM calendar_feeds WHERE subject = ?`, subject); err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteCalendarRepository) FeedSubject(ctx context.Context, tokenHash string) (string, error) {
+	return feedSubject(ctx, r.db, `SELECT subject FROM calendar_feeds WHERE token_hash = ?`, tokenHash)
+}
+
+// scanSQLiteEntry reads a row of calendarColumns stored by SQLiteCalendarRepository.
+func scanSQLiteEntry(row interface{ Scan(dest ...any) error }) (models.CalendarEntry, error) {
+	var e models.CalendarEntry
+	var id, wodID, day, createdAt, updatedAt string
+	err := row.Scan(&id, &e.Subject, &wodID, &day, &e.Program, &e.Note, &createdAt, &updatedAt)
+	if err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	if e.ID, err = uuid.Parse(id); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("uuid.Parse: %w", err)
+	}
+	if e.WodID, err = uuid.Parse(wodID); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("uuid.Parse: %w", err)
+	}
+	if e.Date, err = time.Parse(calendarDay, day); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	if e.CreatedAt, err = time.Parse(sqliteTime, createdAt); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	if e.UpdatedAt, err = time.Parse(sqliteTime, updatedAt); err != nil {
+		return models.CalendarEntry{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	return e, nil
+}
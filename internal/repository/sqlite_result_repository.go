@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+)
+
+// SQLiteResultRepository stores the results next to a SQLiteWodRepository.
+type SQLiteResultRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteResultRepository(db *sql.DB) *SQLiteResultRepository {
+	return &SQLiteResultRepository{db: db}
+}
+
+func (r *SQLiteResultRepository) SaveResult(ctx context.Context, res models.Result) (models.Result, error) {
+	splits, err := json.Marshal(splitsOrEmpty(res.Splits))
+	if err != nil {
+		return models.Result{}, fmt.Errorf("json.Marshal: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO wod_results (`+resultColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, res.ID.String(), res.WodID.String(), res.Subject, res.TimeSec, res.Rounds, res.Reps, res.LoadKg, res.Division,
+		string(splits), res.RPE, res.Scaling, res.CompletedAt.UTC().Format(sqliteTime), res.CreatedAt.UTC().Format(sqliteTime),
+	)
+	if err != nil {
+		return models.Result{}, fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return res, nil
+}
+
+func (r *SQLiteResultRepository) ListResults(ctx context.Context, f ResultFilter, limit, offset int) ([]models.Result, error) {
+	where, args := f.where(func(int) string { return "?" }, func(t time.Time) any { return t.UTC().Format(sqliteTime) })
+	args = append(args, limit, offset)
+	return queryResults(ctx, r.db, fmt.Sprintf(`
+		SELECT %s
+		FROM wod_results
+		%s
+		ORDER BY completed_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, resultColumns, where), args, scanSQLiteResult)
+}
+
+// scanSQLiteResult reads a row of resultColumns stored by SQLiteResultRepository.
+func scanSQLiteResult(row interface{ Scan(dest ...any) error }) (models.Result, error) {
+	var res models.Result
+	var id, wodID, splits, completedAt, createdAt string
+	var timeSec, rounds, reps, rpe sql.NullInt64
+	var loadKg sql.NullFloat64
+	err := row.Scan(&id, &wodID, &res.Subject, &timeSec, &rounds, &reps, &loadKg, &res.Division, &splits, &rpe,
+		&res.Scaling, &completedAt, &createdAt)
+	if err != nil {
+		return models.Result{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	res.TimeSec, res.Rounds, res.Reps, res.RPE = intOrNil(timeSec), intOrNil(rounds), intOrNil(reps), intOrNil(rpe)
+	res.LoadKg = floatOrNil(loadKg)
+	if res.ID, err = uuid.Parse(id); err != nil {
+		return models.Result{}, fmt.Errorf("uuid.Parse: %w", err)
+	}
+	if res.WodID, err = uuid.Parse(wodID); err != nil {
+		return models.Result{}, fmt.Errorf("uuid.Parse: %w", err)
+	}
+	if res.CompletedAt, err = time.Parse(sqliteTime, completedAt); err != nil {
+		return models.Result{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	if res.CreatedAt, err = time.Parse(sqliteTime, createdAt); err != nil {
+		return models.Result{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	if err := json.Unmarshal([]byte(splits), &res.Splits); err != nil {
+		return models.Result{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// SQLiteRetentionRepository purges the wods of the SQLite repositories. The
+// file has a single writer, the lock only keeps purges of this process apart.
+type SQLiteRetentionRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewSQLiteRetentionRepository(db *sql.DB) *SQLiteRetentionRepository {
+	return &SQLiteRetentionRepository{db: db}
+}
+
+func (r *SQLiteRetentionRepository) CountPurgeable(ctx context.Context, cutoff time.Time) (int, error) {
+	return countPurgeable(ctx, r.db, `SELECT COUNT(*) FROM wods w WHERE `+purgeable("?"), cutoff.UTC().Format(sqliteTime))
+}
+
+func (r *SQLiteRetentionRepository) PurgeWods(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	return deleteEntries(ctx, r.db, `
+		DELETE FROM wods WHERE id IN (
+			SELECT w.id FROM wods w
+			WHERE `+purgeable("?")+`
+			ORDER BY w.created_at, w.id
+			LIMIT ?
+		)
+	`, []any{cutoff.UTC().Format(sqliteTime), limit})
+}
+
+func (r *SQLiteRetentionRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	if !r.mu.TryLock() {
+		return nil, false, nil
+	}
+	return r.mu.Unlock, true, nil
+}
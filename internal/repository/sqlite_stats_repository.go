@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+)
+
+// sqliteStatsPeriods render the first day of the period of a sqliteTime
+// column: weeks start on Monday, the day after the Sunday 'weekday 0' is.
+var sqliteStatsPeriods = map[string]string{
+	StatsPeriodWeek:  "date(substr(%s, 1, 10), 'weekday 0', '-6 days')",
+	StatsPeriodMonth: "substr(%s, 1, 7) || '-01'",
+}
+
+// SQLiteStatsRepository aggregates the wods and results of the SQLite
+// repositories.
+type SQLiteStatsRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteStatsRepository(db *sql.DB) *SQLiteStatsRepository {
+	return &SQLiteStatsRepository{db: db}
+}
+
+func (r *SQLiteStatsRepository) WodStats(ctx context.Context, f StatsFilter) (models.Stats, error) {
+	format, ok := sqliteStatsPeriods[f.Period]
+	if !ok {
+		return models.Stats{}, fmt.Errorf("unknown stats period %q", f.Period)
+	}
+	period := func(col string) string { return fmt.Sprintf(format, col) }
+	// a param set to anything but a number counts as 0.
+	sum := func(param string) string {
+		return fmt.Sprintf(`(SELECT SUM(CASE WHEN json_type(b.value, '$.params.%[1]s') IN ('integer', 'real')
+			THEN json_extract(b.value, '$.params.%[1]s') END) FROM json_each(wods.blocks) b)`, param)
+	}
+
+	where, args := f.wods().sqliteWhere(WodPage{})
+	resultsWhere, resultsArgs := f.results().where(func(int) string { return "?" },
+		func(t time.Time) any { return t.UTC().Format(sqliteTime) })
+	return queryStats(ctx, r.db, nil, statsQueries{
+		levels: `SELECT level, COUNT(*) FROM wods ` + where + `
+			GROUP BY level ORDER BY COUNT(*) DESC, level`,
+		durations: `SELECT duration_min, COUNT(*) FROM wods ` + where + `
+			GROUP BY duration_min ORDER BY duration_min`,
+		equipment: `SELECT e.value, COUNT(DISTINCT wods.id) FROM wods CROSS JOIN json_each(wods.equipment) e ` + where + `
+			GROUP BY e.value ORDER BY COUNT(DISTINCT wods.id) DESC, e.value`,
+		moves: `SELECT COALESCE(json_extract(b.value, '$.id'), ''), COALESCE(json_extract(b.value, '$.name'), ''),
+				COUNT(*), COUNT(DISTINCT wods.id)
+			FROM wods CROSS JOIN json_each(wods.blocks) b ` + where + `
+			GROUP BY 1, 2 ORDER BY 3 DESC, 2, 1`,
+		periods: `SELECT period, COUNT(*), COALESCE(SUM(meters), 0), COALESCE(SUM(reps), 0) FROM (
+				SELECT ` + period("created_at") + ` AS period, ` + sum("meters") + ` AS meters, ` + sum("reps") + ` AS reps
+				FROM wods ` + where + `
+			) GROUP BY period ORDER BY period`,
+		results: `SELECT ` + period("completed_at") + `, COUNT(*) FROM wod_results ` + resultsWhere + `
+			GROUP BY 1 ORDER BY 1`,
+		wodArgs:     args,
+		resultsArgs: resultsArgs,
+	})
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteTime stores timestamps as fixed width UTC text, so they compare
+// and sort as strings.
+const sqliteTime = "2006-01-02T15:04:05.000000000Z"
+
+// SQLiteWodRepository stores the wods in a SQLite file, for single node
+// deployments, migrated with db.SQLiteMigrations.
+type SQLiteWodRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteWodRepository(db *sql.DB) *SQLiteWodRepository {
+	return &SQLiteWodRepository{db: db}
+}
+
+func (r *SQLiteWodRepository) SaveWod(ctx context.Context, w models.Wod) (models.Wod, error) {
+	if err := insertSQLiteWod(ctx, r.db, w, false); err != nil {
+		return models.Wod{}, err
+	}
+	return w, nil
+}
+
+func (r *SQLiteWodRepository) SaveWods(ctx context.Context, wods []models.Wod) ([]models.Wod, error) {
+	if len(wods) == 0 {
+		return wods, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	for _, w := range wods {
+		if err := insertSQLiteWod(ctx, tx, w, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return wods, nil
+}
+
+func (r *SQLiteWodRepository) UpsertWods(ctx context.Context, wods []models.Wod) error {
+	if len(wods) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	for _, w := range wods {
+		if err := insertSQLiteWod(ctx, tx, w, true); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+func insertSQLiteWod(ctx context.Context, db execer, w models.Wod, upsert bool) error {
+	equipment, err := json.Marshal(w.Equipment)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	if w.Equipment == nil {
+		equipment = []byte("[]")
+	}
+	blocks, err := json.Marshal(w.Blocks)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	query := `
+		INSERT INTO wods (id, seed, created_at, level, duration_min, equipment, blocks, catalog, catalog_version, catalog_hash,
+			owner_sub, idempotency_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''))`
+	if upsert {
+		query += wodUpsert
+	}
+	res, err := db.ExecContext(ctx, query, w.ID.String(), w.Seed, w.CreatedAt.UTC().Format(sqliteTime), w.Level, w.DurationMin,
+		string(equipment), string(blocks), w.Catalog, w.CatalogVersion, w.CatalogHash, w.OwnerSub, w.IdempotencyKey,
+	)
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+		return fmt.Errorf("db.ExecContext: %w", common.ErrWodExists)
+	}
+	if err != nil {
+		return fmt.Errorf("db.ExecContext: %w", err)
+	}
+	return otherOwner(res, w)
+}
+
+func (r *SQLiteWodRepository) ListWods(ctx context.Context, f WodFilter, p WodPage) ([]models.Wod, error) {
+	where, args := f.sqliteWhere(p)
+	args = append(args, p.Limit, p.Offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM wods
+		%s
+		%s
+		LIMIT ? OFFSET ?
+	`, wodColumns, where, f.orderBy(p.Before != nil)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	var wods []models.Wod
+	for rows.Next() {
+		w, err := scanSQLiteWod(rows)
+		if err != nil {
+			return nil, err
+		}
+		wods = append(wods, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	if p.Before != nil {
+		slices.Reverse(wods)
+	}
+	return wods, nil
+}
+
+func (r *SQLiteWodRepository) CountWods(ctx context.Context, f WodFilter) (int, error) {
+	where, args := f.sqliteWhere(WodPage{})
+	var n int
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM wods `+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("db.QueryRowContext: %w", err)
+	}
+	return n, nil
+}
+
+func (r *SQLiteWodRepository) GetWod(ctx context.Context, id uuid.UUID) (models.Wod, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+wodColumns+` FROM wods WHERE id = ?`, id.String())
+	w, err := scanSQLiteWod(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Wod{}, common.ErrWodNotFound
+	}
+	return w, err
+}
+
+func (r *SQLiteWodRepository) GetAnnotations(ctx context.Context, subject string, ids []uuid.UUID) (map[uuid.UUID]models.Annotations, error) {
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+	return queryAnnotations(ctx, r.db, `
+		SELECT wod_id, 'favorite', '' FROM wod_favorites WHERE subject = ?1 AND wod_id IN (SELECT value FROM json_each(?2))
+		UNION ALL
+		SELECT wod_id, 'note', body FROM wod_notes WHERE subject = ?1 AND wod_id IN (SELECT value FROM json_each(?2))
+		UNION ALL
+		SELECT wod_id, 'label', label FROM wod_labels WHERE subject = ?1 AND wod_id IN (SELECT value FROM json_each(?2))
+		ORDER BY 3
+	`, subject, string(raw))
+}
+
+func (r *SQLiteWodRepository) SaveAnnotations(ctx context.Context, subject string, id uuid.UUID, a models.Annotations) error {
+	return saveAnnotations(ctx, r.db, a, time.Now().UTC(), annotationStatements{
+		deleteFavorite: `DELETE FROM wod_favorites WHERE subject = ? AND wod_id = ?`,
+		insertFavorite: `INSERT INTO wod_favorites (subject, wod_id, created_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`,
+		deleteNote:     `DELETE FROM wod_notes WHERE subject = ? AND wod_id = ?`,
+		upsertNote: `INSERT INTO wod_notes (subject, wod_id, body, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (subject, wod_id) DO UPDATE SET body = excluded.body, updated_at = excluded.updated_at`,
+		deleteLabels: `DELETE FROM wod_labels WHERE subject = ? AND wod_id = ?`,
+		insertLabel:  `INSERT INTO wod_labels (subject, wod_id, label) VALUES (?, ?, ?)`,
+	}, subject, id, func(t time.Time) any { return t.UTC().Format(sqliteTime) })
+}
+
+// sqliteWhere is where for SQLite: the array and jsonb operators become
+// json_each lookups.
+func (f WodFilter) sqliteWhere(p WodPage) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, a ...any) {
+		conds = append(conds, cond)
+		args = append(args, a...)
+	}
+	if !f.AnyOwner {
+		add("owner_sub = ?", f.Owner)
+	}
+	if f.Catalog != "" {
+		add("catalog = ?", f.Catalog)
+	}
+	if f.CatalogVersion != 0 {
+		add("catalog_version = ?", f.CatalogVersion)
+	}
+	if f.CatalogHash != "" {
+		add("catalog_hash = ?", f.CatalogHash)
+	}
+	if f.IdempotencyKey != "" {
+		add("idempotency_key = ?", f.IdempotencyKey)
+	}
+	if f.Level != "" {
+		add("level = ?", f.Level)
+	}
+	if f.MinDuration > 0 {
+		add("duration_min >= ?", f.MinDuration)
+	}
+	if f.MaxDuration > 0 {
+		add("duration_min <= ?", f.MaxDuration)
+	}
+	if len(f.Equipment) > 0 {
+		equipment, _ := json.Marshal(f.Equipment) //nolint:errchkjson // a []string always marshals
+		if f.AnyEquipment {
+			add("EXISTS (SELECT 1 FROM json_each(wods.equipment) e WHERE e.value IN (SELECT value FROM json_each(?)))", string(equipment))
+		} else {
+			add("NOT EXISTS (SELECT 1 FROM json_each(?) w WHERE w.value NOT IN (SELECT value FROM json_each(wods.equipment)))", string(equipment))
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= ?", f.CreatedAfter.UTC().Format(sqliteTime))
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < ?", f.CreatedBefore.UTC().Format(sqliteTime))
+	}
+	if f.Seed != "" {
+		add("seed = ?", f.Seed)
+	}
+	if len(f.IDs) > 0 {
+		ids, _ := json.Marshal(f.IDs) //nolint:errchkjson // uuids always marshal
+		add("id IN (SELECT value FROM json_each(?))", string(ids))
+	}
+	if f.MoveID != "" || f.MoveName != "" {
+		var moves []string
+		if f.MoveID != "" {
+			moves = append(moves, "json_extract(b.value, '$.id') = ?")
+			args = append(args, f.MoveID)
+		}
+		if f.MoveName != "" {
+			moves = append(moves, "json_extract(b.value, '$.name') = ?")
+			args = append(args, f.MoveName)
+		}
+		conds = append(conds, "EXISTS (SELECT 1 FROM json_each(wods.blocks) b WHERE "+strings.Join(moves, " OR ")+")")
+	}
+	conds = append(conds, f.movesWhere(&args, MoveMatch.sqliteWhere)...)
+	if f.Favorite {
+		add("EXISTS (SELECT 1 FROM wod_favorites fav WHERE fav.wod_id = wods.id AND fav.subject = ?)", f.Annotator)
+	}
+	if len(f.Labels) > 0 {
+		labels, _ := json.Marshal(f.Labels) //nolint:errchkjson // a []string always marshals
+		add("(SELECT count(*) FROM wod_labels l WHERE l.wod_id = wods.id AND l.subject = ? "+
+			"AND l.label IN (SELECT value FROM json_each(?))) = ?", f.Annotator, string(labels), len(f.Labels))
+	}
+	if f.Note != "" {
+		add(`EXISTS (SELECT 1 FROM wod_notes n WHERE n.wod_id = wods.id AND n.subject = ? AND n.body LIKE ? ESCAPE '\')`,
+			f.Annotator, likePattern(f.Note))
+	}
+	if key := p.key(); key != nil {
+		op := "<"
+		if f.Ascending != (p.Before != nil) {
+			op = ">"
+		}
+		createdAt := key.CreatedAt.UTC().Format(sqliteTime)
+		if f.Sort == WodSortDuration {
+			add("(duration_min, created_at, id) "+op+" (?, ?, ?)", key.DurationMin, createdAt, key.ID.String())
+		} else {
+			add("(created_at, id) "+op+" (?, ?)", createdAt, key.ID.String())
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// sqliteWhere renders m on the blocks of wods in SQLite. The blocks of the
+// move are looked up with json_each, then their params are added up.
+func (m MoveMatch) sqliteWhere(args *[]any) string {
+	var is []string
+	var isArgs []any
+	if m.ID != "" {
+		is = append(is, "json_extract(b.value, '$.id') = ?")
+		isArgs = append(isArgs, m.ID)
+	}
+	if m.Name != "" {
+		is = append(is, "json_extract(b.value, '$.name') = ?")
+		isArgs = append(isArgs, m.Name)
+	}
+	blocks := "FROM json_each(wods.blocks) b WHERE (" + strings.Join(is, " OR ") + ")"
+
+	conds := []string{"EXISTS (SELECT 1 " + blocks + ")"}
+	*args = append(*args, isArgs...)
+	for _, k := range slices.Sorted(maps.Keys(m.Params)) {
+		path := `$.params."` + k + `"`
+		total := "(SELECT COALESCE(SUM(json_extract(b.value, ?)), 0) " + blocks + " AND json_type(b.value, ?) IN ('integer', 'real'))"
+		for _, bound := range []struct {
+			op string
+			v  *float64
+		}{{">=", m.Params[k].Min}, {"<=", m.Params[k].Max}} {
+			if bound.v == nil {
+				continue
+			}
+			conds = append(conds, total+" "+bound.op+" ?")
+			*args = append(*args, path)
+			*args = append(*args, isArgs...)
+			*args = append(*args, path, *bound.v)
+		}
+	}
+	return "(" + strings.Join(conds, " AND ") + ")"
+}
+
+// scanSQLiteWod reads a row of wodColumns stored by SQLiteWodRepository.
+func scanSQLiteWod(row interface{ Scan(dest ...any) error }) (models.Wod, error) {
+	var w models.Wod
+	var id, createdAt, equipment, blocks string
+	var idempotencyKey sql.NullString
+	err := row.Scan(
+		&id,
+		&w.Seed,
+		&createdAt,
+		&w.Level,
+		&w.DurationMin,
+		&equipment,
+		&blocks,
+		&w.Catalog,
+		&w.CatalogVersion,
+		&w.CatalogHash,
+		&w.OwnerSub,
+		&idempotencyKey,
+	)
+	if err != nil {
+		return models.Wod{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	w.IdempotencyKey = idempotencyKey.String
+	if w.ID, err = uuid.Parse(id); err != nil {
+		return models.Wod{}, fmt.Errorf("uuid.Parse: %w", err)
+	}
+	if w.CreatedAt, err = time.Parse(sqliteTime, createdAt); err != nil {
+		return models.Wod{}, fmt.Errorf("time.Parse: %w", err)
+	}
+	if err := json.Unmarshal([]byte(equipment), &w.Equipment); err != nil {
+		return models.Wod{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if err := json.Unmarshal([]byte(blocks), &w.Blocks); err != nil {
+		return models.Wod{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return w, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+)
+
+// Stats periods.
+const (
+	StatsPeriodWeek  = "week"
+	StatsPeriodMonth = "month"
+)
+
+// StatsRepositoryInterface aggregates the stored wods and results.
+type StatsRepositoryInterface interface {
+	WodStats(ctx context.Context, f StatsFilter) (models.Stats, error)
+}
+
+// StatsFilter scopes the stats to the wods of Owner and the results Owner
+// logged, or everyone's with AnyOwner. The wods created and the results
+// completed from From, inclusive, to To, exclusive, count; a zero bound is
+// open.
+type StatsFilter struct {
+	Owner    string
+	AnyOwner bool
+	From     time.Time
+	To       time.Time
+	// Period is StatsPeriodWeek or StatsPeriodMonth, the periods of Stats.
+	Period string
+}
+
+func (f StatsFilter) wods() WodFilter {
+	return WodFilter{Owner: f.Owner, AnyOwner: f.AnyOwner, CreatedAfter: f.From, CreatedBefore: f.To}
+}
+
+func (f StatsFilter) results() ResultFilter {
+	r := ResultFilter{CompletedAfter: f.From, CompletedBefore: f.To}
+	if !f.AnyOwner {
+		r.Subject = f.Owner
+	}
+	return r
+}
+
+// pgStatsPeriods are the date_trunc fields of the periods, ISO weeks start
+// on Monday.
+var pgStatsPeriods = map[string]string{
+	StatsPeriodWeek:  "week",
+	StatsPeriodMonth: "month",
+}
+
+type StatsRepository struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// WodStats runs the aggregations in a read only snapshot, so they add up
+// while wods are generated.
+func (r *StatsRepository) WodStats(ctx context.Context, f StatsFilter) (models.Stats, error) {
+	field, ok := pgStatsPeriods[f.Period]
+	if !ok {
+		return models.Stats{}, fmt.Errorf("unknown stats period %q", f.Period)
+	}
+	period := func(col string) string {
+		return fmt.Sprintf("to_char(date_trunc('%s', %s AT TIME ZONE 'UTC'), 'YYYY-MM-DD')", field, col)
+	}
+	// a param set to anything but a number counts as 0.
+	sum := func(param string) string {
+		return fmt.Sprintf(`(SELECT SUM(CASE WHEN jsonb_typeof(b->'params'->'%[1]s') = 'number'
+			THEN (b->'params'->>'%[1]s')::numeric END) FROM jsonb_array_elements(wods.blocks) b)`, param)
+	}
+
+	where, args := f.wods().where(WodPage{})
+	resultsWhere, resultsArgs := f.results().where(pgPlaceholder, pgTime)
+	return queryStats(ctx, r.db, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, statsQueries{
+		levels: `SELECT level, COUNT(*) FROM wods ` + where + `
+			GROUP BY level ORDER BY COUNT(*) DESC, level`,
+		durations: `SELECT duration_min, COUNT(*) FROM wods ` + where + `
+			GROUP BY duration_min ORDER BY duration_min`,
+		equipment: `SELECT e, COUNT(DISTINCT wods.id) FROM wods CROSS JOIN unnest(wods.equipment) e ` + where + `
+			GROUP BY e ORDER BY COUNT(DISTINCT wods.id) DESC, e`,
+		moves: `SELECT COALESCE(b->>'id', ''), COALESCE(b->>'name', ''), COUNT(*), COUNT(DISTINCT wods.id)
+			FROM wods CROSS JOIN jsonb_array_elements(wods.blocks) b ` + where + `
+			GROUP BY 1, 2 ORDER BY 3 DESC, 2, 1`,
+		periods: `SELECT period, COUNT(*), COALESCE(SUM(meters), 0)::float8, COALESCE(SUM(reps), 0)::float8 FROM (
+				SELECT ` + period("created_at") + ` AS period, ` + sum("meters") + ` AS meters, ` + sum("reps") + ` AS reps
+				FROM wods ` + where + `
+			) p GROUP BY period ORDER BY period`,
+		results: `SELECT ` + period("completed_at") + `, COUNT(*) FROM wod_results ` + resultsWhere + `
+			GROUP BY 1 ORDER BY 1`,
+		wodArgs:     args,
+		resultsArgs: resultsArgs,
+	})
+}
+
+// statsQueries are the statements of a backend computing Stats:
+//   - levels, equipment: key, wods
+//   - durations: duration_min, wods
+//   - moves: id, name, blocks, wods
+//   - periods: start as YYYY-MM-DD, wods, meters, reps
+//   - results: start as YYYY-MM-DD, results
+//
+// results takes resultsArgs, the others wodArgs.
+type statsQueries struct {
+	levels, durations, equipment, moves, periods, results string
+	wodArgs, resultsArgs                                  []any
+}
+
+func queryStats(ctx context.Context, db *sql.DB, opts *sql.TxOptions, q statsQueries) (models.Stats, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return models.Stats{}, fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	var s models.Stats
+	if s.Levels, err = queryRows(ctx, tx, q.levels, q.wodArgs, func(row interface{ Scan(dest ...any) error }) (models.CountStat, error) {
+		var c models.CountStat
+		return c, row.Scan(&c.Key, &c.Wods)
+	}); err != nil {
+		return models.Stats{}, fmt.Errorf("levels: %w", err)
+	}
+	if s.Durations, err = queryRows(ctx, tx, q.durations, q.wodArgs, func(row interface{ Scan(dest ...any) error }) (models.DurationStat, error) {
+		var d models.DurationStat
+		return d, row.Scan(&d.DurationMin, &d.Wods)
+	}); err != nil {
+		return models.Stats{}, fmt.Errorf("durations: %w", err)
+	}
+	if s.Equipment, err = queryRows(ctx, tx, q.equipment, q.wodArgs, func(row interface{ Scan(dest ...any) error }) (models.CountStat, error) {
+		var c models.CountStat
+		return c, row.Scan(&c.Key, &c.Wods)
+	}); err != nil {
+		return models.Stats{}, fmt.Errorf("equipment: %w", err)
+	}
+	if s.Moves, err = queryRows(ctx, tx, q.moves, q.wodArgs, func(row interface{ Scan(dest ...any) error }) (models.MoveStat, error) {
+		var m models.MoveStat
+		return m, row.Scan(&m.ID, &m.Name, &m.Blocks, &m.Wods)
+	}); err != nil {
+		return models.Stats{}, fmt.Errorf("moves: %w", err)
+	}
+	if s.Periods, err = queryRows(ctx, tx, q.periods, q.wodArgs, func(row interface{ Scan(dest ...any) error }) (models.PeriodStat, error) {
+		var p models.PeriodStat
+		return p, scanPeriod(row, &p, &p.Wods, &p.Meters, &p.Reps)
+	}); err != nil {
+		return models.Stats{}, fmt.Errorf("periods: %w", err)
+	}
+	results, err := queryRows(ctx, tx, q.results, q.resultsArgs, func(row interface{ Scan(dest ...any) error }) (models.PeriodStat, error) {
+		var p models.PeriodStat
+		return p, scanPeriod(row, &p, &p.Results)
+	})
+	if err != nil {
+		return models.Stats{}, fmt.Errorf("results: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Stats{}, fmt.Errorf("tx.Commit: %w", err)
+	}
+
+	s.Periods = mergePeriods(s.Periods, results)
+	for _, l := range s.Levels {
+		s.Wods += l.Wods
+	}
+	for _, p := range s.Periods {
+		s.Results += p.Results
+	}
+	return s, nil
+}
+
+// queryRows runs query in tx and scans its rows with scan.
+func queryRows[T any](ctx context.Context, tx *sql.Tx, query string, args []any,
+	scan func(row interface{ Scan(dest ...any) error }) (T, error),
+) ([]T, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("tx.QueryContext: %w", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Warn("failed to close rows: ", slog.Any("err", err))
+		}
+	}()
+
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return items, nil
+}
+
+// scanPeriod scans the start of a period, as YYYY-MM-DD, into p and the
+// rest of the row into dest.
+func scanPeriod(row interface{ Scan(dest ...any) error }, p *models.PeriodStat, dest ...any) error {
+	var start string
+	if err := row.Scan(append([]any{&start}, dest...)...); err != nil {
+		return err
+	}
+	var err error
+	p.Start, err = time.Parse(calendarDay, start)
+	return err
+}
+
+// mergePeriods adds the results of each period to the wods of periods, both
+// ordered by start, and keeps that order.
+func mergePeriods(periods, results []models.PeriodStat) []models.PeriodStat {
+	merged := make([]models.PeriodStat, 0, len(periods)+len(results))
+	for len(periods) > 0 || len(results) > 0 {
+		switch {
+		case len(results) == 0 || len(periods) > 0 && periods[0].Start.Before(results[0].Start):
+			merged = append(merged, periods[0])
+			periods = periods[1:]
+		case len(periods) == 0 || results[0].Start.Before(periods[0].Start):
+			merged = append(merged, results[0])
+			results = results[1:]
+		default:
+			p := periods[0]
+			p.Results = results[0].Results
+			merged = append(merged, p)
+			periods, results = periods[1:], results[1:]
+		}
+	}
+	return merged
+}
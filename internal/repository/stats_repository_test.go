@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/LinaKACI-pro/wod-gen/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWodStats_Queries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+	month := `to_char\(date_trunc\('month', %s AT TIME ZONE 'UTC'\), 'YYYY-MM-DD'\)`
+	wodsWhere := `WHERE owner_sub = \$1 AND created_at >= \$2 AND created_at < \$3\s+`
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT level, COUNT\(\*\) FROM wods `+wodsWhere+`GROUP BY level`).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"level", "count"}).AddRow("beginner", 2).AddRow("advanced", 1))
+	mock.ExpectQuery(`SELECT duration_min, COUNT\(\*\) FROM wods `+wodsWhere+`GROUP BY duration_min`).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"duration_min", "count"}).AddRow(30, 3))
+	mock.ExpectQuery(`SELECT e, COUNT\(DISTINCT wods.id\) FROM wods CROSS JOIN unnest\(wods.equipment\) e `+wodsWhere).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"e", "count"}).AddRow("rower", 2))
+	mock.ExpectQuery(`FROM wods CROSS JOIN jsonb_array_elements\(wods.blocks\) b `+wodsWhere+`GROUP BY 1, 2`).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "blocks", "wods"}).AddRow("row", "Row", 4, 3))
+	mock.ExpectQuery(`SELECT `+fmt.Sprintf(month, "created_at")+` AS period, `+
+		`\(SELECT SUM\(CASE WHEN jsonb_typeof\(b->'params'->'meters'\) = 'number'\s+`+
+		`THEN \(b->'params'->>'meters'\)::numeric END\) FROM jsonb_array_elements\(wods.blocks\) b\) AS meters, .* AS reps\s+`+
+		`FROM wods `+wodsWhere+`\) p GROUP BY period ORDER BY period`).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "count", "meters", "reps"}).
+			AddRow("2025-09-01", 2, 3000.0, 40.0).AddRow("2025-11-01", 1, 500.0, 0.0))
+	mock.ExpectQuery(`SELECT `+fmt.Sprintf(month, "completed_at")+`, COUNT\(\*\) FROM wod_results `+
+		`WHERE subject = \$1 AND completed_at >= \$2 AND completed_at < \$3\s+GROUP BY 1`).
+		WithArgs("alice", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "count"}).AddRow("2025-10-01", 9).AddRow("2025-11-01", 2))
+	mock.ExpectCommit()
+
+	got, err := repository.NewStatsRepository(db).WodStats(context.Background(), repository.StatsFilter{
+		Owner: "alice", From: from, To: to, Period: repository.StatsPeriodMonth,
+	})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, 3, got.Wods)
+	require.Equal(t, 11, got.Results)
+	require.Equal(t, []models.MoveStat{{ID: "row", Name: "Row", Blocks: 4, Wods: 3}}, got.Moves)
+	require.Equal(t, []models.PeriodStat{
+		{Start: from, Wods: 2, Meters: 3000, Reps: 40},
+		{Start: from.AddDate(0, 1, 0), Results: 9},
+		{Start: from.AddDate(0, 2, 0), Wods: 1, Results: 2, Meters: 500},
+	}, got.Periods, "the periods with results only are merged in order")
+}
+
+func TestWodStats_QueryError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT level, COUNT\(\*\) FROM wods\s+GROUP BY level`).
+		WithoutArgs().
+		WillReturnError(errors.New("db fail"))
+	mock.ExpectRollback()
+
+	_, err := repository.NewStatsRepository(db).WodStats(context.Background(), repository.StatsFilter{
+		AnyOwner: true, Period: repository.StatsPeriodWeek,
+	})
+
+	require.ErrorContains(t, err, "levels: tx.QueryContext")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWodStats_UnknownPeriod(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	_, err := repository.NewStatsRepository(db).WodStats(context.Background(), repository.StatsFilter{Period: "day"})
+
+	require.ErrorContains(t, err, "unknown stats period")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,16 +4,314 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/models"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
 type WodRepositoryInterface interface {
 	SaveWod(ctx context.Context, w models.Wod) (models.Wod, error)
-	ListWods(ctx context.Context, limit, offset int) ([]models.Wod, error)
+	// SaveWods stores wods in a single transaction, none of them when one
+	// fails.
+	SaveWods(ctx context.Context, wods []models.Wod) ([]models.Wod, error)
+	// UpsertWods stores wods in a single transaction, replacing the wods
+	// stored with their IDs. It never changes the owner of a wod: an ID of
+	// another owner is common.ErrWodExists, and none of them is stored.
+	UpsertWods(ctx context.Context, wods []models.Wod) error
+	ListWods(ctx context.Context, f WodFilter, p WodPage) ([]models.Wod, error)
+	CountWods(ctx context.Context, f WodFilter) (int, error)
+	GetWod(ctx context.Context, id uuid.UUID) (models.Wod, error)
+}
+
+const wodColumns = "id, seed, created_at, level, duration_min, equipment, blocks, catalog, catalog_version, catalog_hash, owner_sub, idempotency_key"
+
+// wodUpsert replaces the wod stored with the id of the inserted one, unless
+// another owner has it. Postgres and SQLite share the syntax.
+const wodUpsert = `
+		ON CONFLICT (id) DO UPDATE SET seed = excluded.seed, created_at = excluded.created_at, level = excluded.level,
+			duration_min = excluded.duration_min, equipment = excluded.equipment, blocks = excluded.blocks,
+			catalog = excluded.catalog, catalog_version = excluded.catalog_version, catalog_hash = excluded.catalog_hash,
+			idempotency_key = excluded.idempotency_key
+		WHERE wods.owner_sub = excluded.owner_sub`
+
+// Sort keys of the wods listed.
+const (
+	WodSortCreatedAt = "created_at"
+	WodSortDuration  = "duration"
+)
+
+// WodFilter narrows the wods listed. The wods are those of Owner unless
+// AnyOwner is set, the other zero values match everything.
+type WodFilter struct {
+	Owner          string
+	AnyOwner       bool
+	Catalog        string
+	CatalogVersion int64
+	CatalogHash    string
+	// IdempotencyKey finds the wod generated with this key.
+	IdempotencyKey string
+	// IDs matches the wods with any of these ids.
+	IDs []uuid.UUID
+
+	Level       string
+	MinDuration int
+	MaxDuration int
+	// Equipment the wods must all list, or at least one of with AnyEquipment.
+	Equipment    []string
+	AnyEquipment bool
+	// CreatedAfter is inclusive, CreatedBefore exclusive.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Seed          string
+	// MoveID and MoveName match a block of the wod by move ID or by name,
+	// blocks stored before moves had IDs only have a name.
+	MoveID   string
+	MoveName string
+	// AllMoves, AnyMoves and NoMoves match the wods with every, at least one
+	// and none of these moves.
+	AllMoves []MoveMatch
+	AnyMoves []MoveMatch
+	NoMoves  []MoveMatch
+	// Annotator is the subject whose annotations Favorite, Labels and Note
+	// match: favorites only, wods with all the labels, and wods whose note
+	// contains Note, case insensitive.
+	Annotator string
+	Favorite  bool
+	Labels    []string
+	Note      string
+
+	// Sort is WodSortCreatedAt (the default) or WodSortDuration, newest and
+	// longest first unless Ascending.
+	Sort      string
+	Ascending bool
+}
+
+// MoveMatch matches the wods with a block of the move ID or Name, both
+// tried when set, whose params added up over these blocks are in Params.
+type MoveMatch struct {
+	ID     string
+	Name   string
+	Params map[string]ParamRange
+}
+
+// ParamRange bounds a param, inclusive, a nil bound is open.
+type ParamRange struct {
+	Min *float64
+	Max *float64
+}
+
+// WodKey is the position of a wod in a listing, the values it is sorted on.
+type WodKey struct {
+	CreatedAt   time.Time
+	DurationMin int
+	ID          uuid.UUID
+}
+
+// KeyOf returns the position of w in a listing.
+func KeyOf(w models.Wod) WodKey {
+	return WodKey{CreatedAt: w.CreatedAt, DurationMin: w.DurationMin, ID: w.ID}
+}
+
+// WodPage selects the Limit wods listed from Offset, or seeking: right after
+// After or right before Before in the listing order. Seeking stays stable
+// while wods are inserted, offsets shift.
+type WodPage struct {
+	Limit  int
+	Offset int
+	After  *WodKey
+	Before *WodKey
+}
+
+// where renders f, and the seek of p, as a WHERE clause, its args numbered from 1.
+func (f WodFilter) where(p WodPage) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if !f.AnyOwner {
+		add("owner_sub = $%d", f.Owner)
+	}
+	if f.Catalog != "" {
+		add("catalog = $%d", f.Catalog)
+	}
+	if f.CatalogVersion != 0 {
+		add("catalog_version = $%d", f.CatalogVersion)
+	}
+	if f.CatalogHash != "" {
+		add("catalog_hash = $%d", f.CatalogHash)
+	}
+	if f.IdempotencyKey != "" {
+		add("idempotency_key = $%d", f.IdempotencyKey)
+	}
+	if len(f.IDs) > 0 {
+		ids := make([]string, len(f.IDs))
+		for i, id := range f.IDs {
+			ids[i] = id.String()
+		}
+		add("id = ANY($%d::uuid[])", pq.Array(ids))
+	}
+	if f.Level != "" {
+		add("level = $%d", f.Level)
+	}
+	if f.MinDuration > 0 {
+		add("duration_min >= $%d", f.MinDuration)
+	}
+	if f.MaxDuration > 0 {
+		add("duration_min <= $%d", f.MaxDuration)
+	}
+	if len(f.Equipment) > 0 {
+		if f.AnyEquipment {
+			add("equipment && $%d", pq.Array(f.Equipment))
+		} else {
+			add("equipment @> $%d", pq.Array(f.Equipment))
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= $%d", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < $%d", f.CreatedBefore)
+	}
+	if f.Seed != "" {
+		add("seed = $%d", f.Seed)
+	}
+	if f.MoveID != "" || f.MoveName != "" {
+		// containment keeps the GIN index on blocks usable.
+		var moves []string
+		if f.MoveID != "" {
+			args = append(args, f.MoveID)
+			moves = append(moves, fmt.Sprintf("blocks @> jsonb_build_array(jsonb_build_object('id', $%d::text))", len(args)))
+		}
+		if f.MoveName != "" {
+			args = append(args, f.MoveName)
+			moves = append(moves, fmt.Sprintf("blocks @> jsonb_build_array(jsonb_build_object('name', $%d::text))", len(args)))
+		}
+		conds = append(conds, "("+strings.Join(moves, " OR ")+")")
+	}
+	conds = append(conds, f.movesWhere(&args, MoveMatch.where)...)
+	if f.Favorite {
+		add("EXISTS (SELECT 1 FROM wod_favorites fav WHERE fav.wod_id = wods.id AND fav.subject = $%d)", f.Annotator)
+	}
+	if len(f.Labels) > 0 {
+		args = append(args, f.Annotator, pq.Array(f.Labels), len(f.Labels))
+		conds = append(conds, fmt.Sprintf(
+			"(SELECT count(*) FROM wod_labels l WHERE l.wod_id = wods.id AND l.subject = $%d AND l.label = ANY($%d)) = $%d",
+			len(args)-2, len(args)-1, len(args)))
+	}
+	if f.Note != "" {
+		args = append(args, f.Annotator, likePattern(f.Note))
+		conds = append(conds, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM wod_notes n WHERE n.wod_id = wods.id AND n.subject = $%d AND n.body ILIKE $%d)",
+			len(args)-1, len(args)))
+	}
+	if key := p.key(); key != nil {
+		// rows compare in the listing order, the page goes away from the key.
+		op := "<"
+		if f.Ascending != (p.Before != nil) {
+			op = ">"
+		}
+		if f.Sort == WodSortDuration {
+			args = append(args, key.DurationMin, key.CreatedAt, key.ID)
+			conds = append(conds, fmt.Sprintf("(duration_min, created_at, id) %s ($%d, $%d, $%d)", op, len(args)-2, len(args)-1, len(args)))
+		} else {
+			args = append(args, key.CreatedAt, key.ID)
+			conds = append(conds, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", op, len(args)-1, len(args)))
+		}
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// movesWhere renders AllMoves, AnyMoves and NoMoves as conditions, each
+// move rendered by cond with its args appended to args.
+func (f WodFilter) movesWhere(args *[]any, cond func(m MoveMatch, args *[]any) string) []string {
+	var conds []string
+	for _, m := range f.AllMoves {
+		conds = append(conds, cond(m, args))
+	}
+	if len(f.AnyMoves) > 0 {
+		anyOf := make([]string, len(f.AnyMoves))
+		for i, m := range f.AnyMoves {
+			anyOf[i] = cond(m, args)
+		}
+		conds = append(conds, "("+strings.Join(anyOf, " OR ")+")")
+	}
+	for _, m := range f.NoMoves {
+		conds = append(conds, "NOT "+cond(m, args))
+	}
+	return conds
+}
+
+// where renders m on the blocks of wods. The blocks of the move are found by
+// containment, which the GIN index on blocks serves, then their params are
+// added up.
+func (m MoveMatch) where(args *[]any) string {
+	arg := func(v any) string {
+		*args = append(*args, v)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+	var contains, is []string
+	if m.ID != "" {
+		id := arg(m.ID)
+		contains = append(contains, "blocks @> jsonb_build_array(jsonb_build_object('id', "+id+"::text))")
+		is = append(is, "b->>'id' = "+id)
+	}
+	if m.Name != "" {
+		name := arg(m.Name)
+		contains = append(contains, "blocks @> jsonb_build_array(jsonb_build_object('name', "+name+"::text))")
+		is = append(is, "b->>'name' = "+name)
+	}
+	conds := []string{"(" + strings.Join(contains, " OR ") + ")"}
+	for _, k := range slices.Sorted(maps.Keys(m.Params)) {
+		param := arg(k)
+		total := fmt.Sprintf("(SELECT COALESCE(sum((b->'params'->>%[1]s::text)::numeric), 0) FROM jsonb_array_elements(wods.blocks) b "+
+			"WHERE (%[2]s) AND jsonb_typeof(b->'params'->%[1]s::text) = 'number')", param, strings.Join(is, " OR "))
+		if r := m.Params[k]; r.Min != nil {
+			conds = append(conds, total+" >= "+arg(*r.Min))
+		}
+		if r := m.Params[k]; r.Max != nil {
+			conds = append(conds, total+" <= "+arg(*r.Max))
+		}
+	}
+	return "(" + strings.Join(conds, " AND ") + ")"
+}
+
+// likePattern matches the strings containing s, its wildcards escaped.
+func likePattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+func (p WodPage) key() *WodKey {
+	if p.After != nil {
+		return p.After
+	}
+	return p.Before
+}
+
+// orderBy renders the sort of f, ties broken by id for a stable order. The
+// order is reversed to seek backwards.
+func (f WodFilter) orderBy(reverse bool) string {
+	dir := "DESC"
+	if f.Ascending != reverse {
+		dir = "ASC"
+	}
+	if f.Sort == WodSortDuration {
+		return fmt.Sprintf("ORDER BY duration_min %[1]s, created_at %[1]s, id %[1]s", dir)
+	}
+	return fmt.Sprintf("ORDER BY created_at %[1]s, id %[1]s", dir)
 }
 
 type WodRepository struct {
@@ -24,31 +322,113 @@ func NewWodRepository(db *sql.DB) *WodRepository {
 	return &WodRepository{db: db}
 }
 
+// SaveWod stores w, common.ErrWodExists when its owner already has a wod with
+// its idempotency key.
 func (r *WodRepository) SaveWod(ctx context.Context, w models.Wod) (models.Wod, error) {
+	if err := insertWod(ctx, r.db, w, false); err != nil {
+		return models.Wod{}, err
+	}
+	return w, nil
+}
+
+func (r *WodRepository) SaveWods(ctx context.Context, wods []models.Wod) ([]models.Wod, error) {
+	if len(wods) == 0 {
+		return wods, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	for _, w := range wods {
+		if err := insertWod(ctx, tx, w, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return wods, nil
+}
+
+func (r *WodRepository) UpsertWods(ctx context.Context, wods []models.Wod) error {
+	if len(wods) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer rollback(tx)
+
+	for _, w := range wods {
+		if err := insertWod(ctx, tx, w, true); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// execer is a *sql.DB or a *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertWod stores w, or replaces the wod stored with its id with upsert.
+func insertWod(ctx context.Context, db execer, w models.Wod, upsert bool) error {
 	blocks, err := json.Marshal(w.Blocks)
 	if err != nil {
-		return models.Wod{}, fmt.Errorf("json.Marshal: %w", err)
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	query := `
+		INSERT INTO wods (id, seed, created_at, level, duration_min, equipment, blocks, catalog, catalog_version, catalog_hash,
+			owner_sub, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))`
+	if upsert {
+		query += wodUpsert
 	}
-	_, err = r.db.ExecContext(ctx, `
-		INSERT INTO wods (id, seed, created_at, level, duration_min, equipment, blocks)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, w.ID, w.Seed, w.CreatedAt, w.Level, w.DurationMin,
-		pq.Array(w.Equipment), blocks,
+	res, err := db.ExecContext(ctx, query, w.ID, w.Seed, w.CreatedAt, w.Level, w.DurationMin,
+		pq.Array(w.Equipment), blocks, w.Catalog, w.CatalogVersion, w.CatalogHash, w.OwnerSub, w.IdempotencyKey,
 	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return fmt.Errorf("db.ExecContext: %w", common.ErrWodExists)
+	}
 	if err != nil {
-		return models.Wod{}, fmt.Errorf("db.ExecContext: %w", err)
+		return fmt.Errorf("db.ExecContext: %w", err)
 	}
+	return otherOwner(res, w)
+}
 
-	return w, err
+// otherOwner is common.ErrWodExists when the upsert of w changed no row,
+// its id being another owner's.
+func otherOwner(res sql.Result, w models.Wod) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s belongs to another owner", common.ErrWodExists, w.ID)
+	}
+	return nil
 }
 
-func (r *WodRepository) ListWods(ctx context.Context, limit, offset int) ([]models.Wod, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, seed, created_at, level, duration_min, equipment, blocks
+// ListWods returns the page p of the wods matching f, in the listing order
+// whichever way p seeks.
+func (r *WodRepository) ListWods(ctx context.Context, f WodFilter, p WodPage) ([]models.Wod, error) {
+	where, args := f.where(p)
+	args = append(args, p.Limit, p.Offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
 		FROM wods
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`, limit, offset)
+		%s
+		%s
+		LIMIT $%d OFFSET $%d
+	`, wodColumns, where, f.orderBy(p.Before != nil), len(args)-1, len(args)), args...)
 	if err != nil {
 		return nil, fmt.Errorf("db.QueryContext: %w", err)
 	}
@@ -60,22 +440,9 @@ func (r *WodRepository) ListWods(ctx context.Context, limit, offset int) ([]mode
 
 	var wods []models.Wod
 	for rows.Next() {
-		var w models.Wod
-		var rawBlocks []byte
-		err := rows.Scan(
-			&w.ID,
-			&w.Seed,
-			&w.CreatedAt,
-			&w.Level,
-			&w.DurationMin,
-			pq.Array(&w.Equipment),
-			&rawBlocks,
-		)
+		w, err := scanWod(rows)
 		if err != nil {
-			return nil, fmt.Errorf("rows.Scan: %w", err)
-		}
-		if err := json.Unmarshal(rawBlocks, &w.Blocks); err != nil {
-			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+			return nil, err
 		}
 		wods = append(wods, w)
 	}
@@ -83,6 +450,59 @@ func (r *WodRepository) ListWods(ctx context.Context, limit, offset int) ([]mode
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows.Err: %w", err)
 	}
+	if p.Before != nil {
+		slices.Reverse(wods)
+	}
 
 	return wods, nil
 }
+
+// CountWods counts the wods matching f.
+func (r *WodRepository) CountWods(ctx context.Context, f WodFilter) (int, error) {
+	where, args := f.where(WodPage{})
+	var n int
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM wods `+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("db.QueryRowContext: %w", err)
+	}
+	return n, nil
+}
+
+// GetWod returns the wod id whoever owns it, common.ErrWodNotFound when there
+// is none.
+func (r *WodRepository) GetWod(ctx context.Context, id uuid.UUID) (models.Wod, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+wodColumns+` FROM wods WHERE id = $1`, id)
+	w, err := scanWod(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Wod{}, common.ErrWodNotFound
+	}
+	return w, err
+}
+
+// scanWod reads a row of wodColumns.
+func scanWod(row interface{ Scan(dest ...any) error }) (models.Wod, error) {
+	var w models.Wod
+	var rawBlocks []byte
+	var idempotencyKey sql.NullString
+	err := row.Scan(
+		&w.ID,
+		&w.Seed,
+		&w.CreatedAt,
+		&w.Level,
+		&w.DurationMin,
+		pq.Array(&w.Equipment),
+		&rawBlocks,
+		&w.Catalog,
+		&w.CatalogVersion,
+		&w.CatalogHash,
+		&w.OwnerSub,
+		&idempotencyKey,
+	)
+	if err != nil {
+		return models.Wod{}, fmt.Errorf("rows.Scan: %w", err)
+	}
+	w.IdempotencyKey = idempotencyKey.String
+	if err := json.Unmarshal(rawBlocks, &w.Blocks); err != nil {
+		return models.Wod{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return w, nil
+}
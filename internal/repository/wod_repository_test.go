@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/LinaKACI-pro/wod-gen/internal/common"
 	"github.com/LinaKACI-pro/wod-gen/internal/models"
 	"github.com/LinaKACI-pro/wod-gen/internal/repository"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +24,7 @@ func newWod() models.Wod {
 		DurationMin: 20,
 		Equipment:   []string{"rower"},
 		Blocks:      []models.Block{{Name: "Run", Params: map[string]interface{}{"meters": 200}}},
+		Catalog:     "hyrox",
 	}
 }
 
@@ -54,6 +57,100 @@ func TestSaveWod_DBError(t *testing.T) {
 	require.Contains(t, err.Error(), "db.ExecContext")
 }
 
+func TestSaveWod_IdempotencyKeyTaken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	wod := newWod()
+	wod.IdempotencyKey = "retry-1"
+	mock.ExpectExec(`INSERT INTO wods`).
+		WithArgs(wod.ID, wod.Seed, wod.CreatedAt, wod.Level, wod.DurationMin, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			wod.Catalog, wod.CatalogVersion, wod.CatalogHash, wod.OwnerSub, "retry-1").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.SaveWod(context.Background(), wod)
+
+	require.ErrorIs(t, err, common.ErrWodExists)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveWods_Transaction(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	wods := []models.Wod{newWod(), newWod()}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO wods").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO wods").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewWodRepository(db)
+	got, err := repo.SaveWods(context.Background(), wods)
+
+	require.NoError(t, err)
+	require.Equal(t, wods, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveWods_RollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO wods").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO wods").WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.SaveWods(context.Background(), []models.Wod{newWod(), newWod()})
+
+	require.ErrorIs(t, err, common.ErrWodExists)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertWods_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO wods .* ON CONFLICT \(id\) DO UPDATE SET .* WHERE wods.owner_sub = excluded.owner_sub`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewWodRepository(db)
+	err := repo.UpsertWods(context.Background(), []models.Wod{newWod()})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertWods_OtherOwner(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	wod := newWod()
+	mock.ExpectBegin()
+	mock.ExpectExec(`ON CONFLICT \(id\) DO UPDATE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := repository.NewWodRepository(db)
+	err := repo.UpsertWods(context.Background(), []models.Wod{wod})
+
+	require.ErrorIs(t, err, common.ErrWodExists)
+	require.ErrorContains(t, err, wod.ID.String()+" belongs to another owner")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWods_ByIdempotencyKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectQuery(`WHERE owner_sub = \$1 AND idempotency_key = \$2\s+ORDER BY`).
+		WithArgs("alice", "retry-1", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{Owner: "alice", IdempotencyKey: "retry-1"}, repository.WodPage{Limit: 20})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestListWods_Success(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 
@@ -61,18 +158,101 @@ func TestListWods_Success(t *testing.T) {
 	blocks := `[{"name":"Run","params":{"meters":200}}]`
 
 	rows := sqlmock.NewRows([]string{
-		"id", "seed", "created_at", "level", "duration_min", "equipment", "blocks",
-	}).AddRow(wod.ID, wod.Seed, wod.CreatedAt, wod.Level, wod.DurationMin, `{rower}`, blocks)
+		"id", "seed", "created_at", "level", "duration_min", "equipment", "blocks", "catalog", "catalog_version", "catalog_hash", "owner_sub", "idempotency_key",
+	}).AddRow(wod.ID, wod.Seed, wod.CreatedAt, wod.Level, wod.DurationMin, `{rower}`, blocks, "running", 4, "abc123", "alice", nil)
 
 	mock.ExpectQuery("SELECT id, seed").
 		WillReturnRows(rows)
 
 	repo := repository.NewWodRepository(db)
-	wods, err := repo.ListWods(context.Background(), 5, 0)
+	wods, err := repo.ListWods(context.Background(), repository.WodFilter{Owner: "alice"}, repository.WodPage{Limit: 5})
 
 	require.NoError(t, err)
 	require.Len(t, wods, 1)
+	require.Equal(t, "alice", wods[0].OwnerSub)
 	require.Equal(t, wod.Level, wods[0].Level)
+	require.Equal(t, "running", wods[0].Catalog)
+	require.Equal(t, int64(4), wods[0].CatalogVersion)
+	require.Equal(t, "abc123", wods[0].CatalogHash)
+}
+
+func TestListWods_FilterByCatalogVersion(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectQuery(`WHERE catalog = \$1 AND catalog_version = \$2\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$3 OFFSET \$4`).
+		WithArgs("hyrox", int64(3), 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	wods, err := repo.ListWods(context.Background(), repository.WodFilter{AnyOwner: true, Catalog: "hyrox", CatalogVersion: 3}, repository.WodPage{Limit: 5})
+
+	require.NoError(t, err)
+	require.Empty(t, wods)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWods_ScopedToOwner(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectQuery(`WHERE owner_sub = \$1\s+ORDER BY`).
+		WithArgs("alice", 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{Owner: "alice"}, repository.WodPage{Limit: 5})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWods_Filters(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	after := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE owner_sub = \$1 AND level = \$2 AND duration_min >= \$3 AND duration_min <= \$4 `+
+		`AND equipment && \$5 AND created_at >= \$6 AND seed = \$7 `+
+		`AND \(blocks @> jsonb_build_array\(jsonb_build_object\('id', \$8::text\)\) `+
+		`OR blocks @> jsonb_build_array\(jsonb_build_object\('name', \$9::text\)\)\)\s+`+
+		`ORDER BY duration_min ASC, created_at ASC, id ASC\s+LIMIT \$10 OFFSET \$11`).
+		WithArgs("alice", "beginner", 20, 40, sqlmock.AnyArg(), after, "abc", "row", "Row", 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{
+		Owner: "alice", Level: "beginner", MinDuration: 20, MaxDuration: 40,
+		Equipment: []string{"rower", "skierg"}, AnyEquipment: true, CreatedAfter: after, Seed: "abc",
+		MoveID: "row", MoveName: "Row", Sort: repository.WodSortDuration, Ascending: true,
+	}, repository.WodPage{Limit: 5})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWods_Moves(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	meters := 2000.0
+	mock.ExpectQuery(`WHERE owner_sub = \$1 `+
+		`AND \(\(blocks @> jsonb_build_array\(jsonb_build_object\('id', \$2::text\)\) `+
+		`OR blocks @> jsonb_build_array\(jsonb_build_object\('name', \$3::text\)\)\) `+
+		`AND \(SELECT COALESCE\(sum\(\(b->'params'->>\$4::text\)::numeric\), 0\) FROM jsonb_array_elements\(wods.blocks\) b `+
+		`WHERE \(b->>'id' = \$2 OR b->>'name' = \$3\) AND jsonb_typeof\(b->'params'->\$4::text\) = 'number'\) >= \$5\) `+
+		`AND \(\(\(blocks @> jsonb_build_array\(jsonb_build_object\('name', \$6::text\)\)\)\)\) `+
+		`AND NOT \(\(blocks @> jsonb_build_array\(jsonb_build_object\('id', \$7::text\)\)\)\)\s+`+
+		`ORDER BY created_at DESC, id DESC\s+LIMIT \$8 OFFSET \$9`).
+		WithArgs("alice", "row", "Row", "meters", meters, "Burpees", "ski", 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{
+		Owner:    "alice",
+		AllMoves: []repository.MoveMatch{{ID: "row", Name: "Row", Params: map[string]repository.ParamRange{"meters": {Min: &meters}}}},
+		AnyMoves: []repository.MoveMatch{{Name: "Burpees"}},
+		NoMoves:  []repository.MoveMatch{{ID: "ski"}},
+	}, repository.WodPage{Limit: 5})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListWods_QueryError(t *testing.T) {
@@ -82,8 +262,91 @@ func TestListWods_QueryError(t *testing.T) {
 		WillReturnError(errors.New("db fail"))
 
 	repo := repository.NewWodRepository(db)
-	_, err := repo.ListWods(context.Background(), 5, 0)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{}, repository.WodPage{Limit: 5})
 
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "db.QueryContext")
 }
+
+func TestGetWod_Success(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	wod := newWod()
+	rows := sqlmock.NewRows([]string{
+		"id", "seed", "created_at", "level", "duration_min", "equipment", "blocks", "catalog", "catalog_version", "catalog_hash", "owner_sub", "idempotency_key",
+	}).AddRow(wod.ID, wod.Seed, wod.CreatedAt, wod.Level, wod.DurationMin, `{rower}`, `[{"name":"Run"}]`, "hyrox", 1, "abc123", "alice", "retry-1")
+	mock.ExpectQuery(`FROM wods WHERE id = \$1`).WithArgs(wod.ID).WillReturnRows(rows)
+
+	repo := repository.NewWodRepository(db)
+	got, err := repo.GetWod(context.Background(), wod.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, wod.ID, got.ID)
+	require.Equal(t, []string{"rower"}, got.Equipment)
+	require.Equal(t, "Run", got.Blocks[0].Name)
+	require.Equal(t, "retry-1", got.IdempotencyKey)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWod_NotFound(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectQuery("FROM wods WHERE id").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.GetWod(context.Background(), uuid.New())
+
+	require.ErrorIs(t, err, common.ErrWodNotFound)
+}
+
+func TestListWods_SeeksBeforeKeyBackwards(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	key := repository.WodKey{CreatedAt: time.Now(), ID: uuid.New()}
+	first, second := newWod(), newWod()
+	rows := sqlmock.NewRows([]string{
+		"id", "seed", "created_at", "level", "duration_min", "equipment", "blocks", "catalog", "catalog_version", "catalog_hash", "owner_sub", "idempotency_key",
+	}).
+		AddRow(second.ID, "s", second.CreatedAt, "beginner", 20, `{}`, `[]`, "hyrox", 1, "", "alice", nil).
+		AddRow(first.ID, "s", first.CreatedAt, "beginner", 20, `{}`, `[]`, "hyrox", 1, "", "alice", nil)
+	mock.ExpectQuery(`WHERE owner_sub = \$1 AND \(created_at, id\) > \(\$2, \$3\)\s+ORDER BY created_at ASC, id ASC\s+LIMIT \$4 OFFSET \$5`).
+		WithArgs("alice", key.CreatedAt, key.ID, 2, 0).
+		WillReturnRows(rows)
+
+	repo := repository.NewWodRepository(db)
+	wods, err := repo.ListWods(context.Background(), repository.WodFilter{Owner: "alice"}, repository.WodPage{Limit: 2, Before: &key})
+
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{wods[0].ID, wods[1].ID}, "back in listing order")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWods_SeeksAfterKeyByDuration(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	key := repository.WodKey{CreatedAt: time.Now(), DurationMin: 30, ID: uuid.New()}
+	mock.ExpectQuery(`WHERE owner_sub = \$1 AND \(duration_min, created_at, id\) < \(\$2, \$3, \$4\)\s+ORDER BY duration_min DESC`).
+		WithArgs("alice", 30, key.CreatedAt, key.ID, 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := repository.NewWodRepository(db)
+	_, err := repo.ListWods(context.Background(), repository.WodFilter{Owner: "alice", Sort: repository.WodSortDuration},
+		repository.WodPage{Limit: 5, After: &key})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountWods(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM wods WHERE owner_sub = \$1 AND level = \$2`).
+		WithArgs("alice", "beginner").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	repo := repository.NewWodRepository(db)
+	n, err := repo.CountWods(context.Background(), repository.WodFilter{Owner: "alice", Level: "beginner"})
+
+	require.NoError(t, err)
+	require.Equal(t, 42, n)
+}
@@ -9,6 +9,15 @@ import (
 
 var ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
 
+const RoleAdmin = "admin"
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role,omitempty"`
+	// Tenant selects the catalog overlay applied to the caller's requests.
+	Tenant string `json:"tenant,omitempty"`
+}
+
 type JWTManager struct {
 	secretKey     []byte
 	tokenDuration time.Duration
@@ -22,18 +31,20 @@ func NewJWTManager(secret string, duration time.Duration) *JWTManager {
 }
 
 func (m *JWTManager) Generate(subject string) (string, error) {
-	claims := jwt.RegisteredClaims{
-		Subject:   subject,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.tokenDuration)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-	}
+	return m.GenerateClaims(Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}})
+}
+
+// GenerateClaims signs claims, overriding their expiry and issue date.
+func (m *JWTManager) GenerateClaims(claims Claims) (string, error) {
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(m.tokenDuration))
+	claims.IssuedAt = jwt.NewNumericDate(time.Now())
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(m.secretKey)
 }
 
-func (m *JWTManager) Verify(tokenStr string) (*jwt.RegisteredClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &jwt.RegisteredClaims{}, func(t *jwt.Token) (interface{}, error) {
+func (m *JWTManager) Verify(tokenStr string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		// verify the algorithm is HS256
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrUnexpectedSigningMethod
@@ -45,7 +56,7 @@ func (m *JWTManager) Verify(tokenStr string) (*jwt.RegisteredClaims, error) {
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
 		return nil, jwt.ErrTokenInvalidClaims
 	}
@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CtxLocale is the key of the negotiated locale on the gin context.
+const CtxLocale = "locale"
+
+// AcceptLanguage stores on the gin context the locale negotiated from the
+// Accept-Language header among supported, the first one being the default.
+func AcceptLanguage(supported ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(CtxLocale, NegotiateLocale(c.GetHeader("Accept-Language"), supported))
+		c.Next()
+	}
+}
+
+// NegotiateLocale picks the supported locale with the highest q-value in an
+// Accept-Language header, matching on the primary subtag so "fr-CA" selects
+// "fr". A bare tag such as "fr" works too.
+func NegotiateLocale(header string, supported []string) string {
+	best, bestQ := supported[0], 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if i := strings.IndexAny(tag, "-_"); i >= 0 {
+			tag = tag[:i]
+		}
+		for _, s := range supported {
+			if tag == s && q > bestQ {
+				best, bestQ = s, q
+			}
+		}
+	}
+	return best
+}
+
+// Locale returns the locale negotiated by AcceptLanguage, empty when the
+// middleware did not run.
+func Locale(ctx context.Context) string {
+	return toString(ctx.Value(CtxLocale))
+}
@@ -13,16 +13,34 @@ import (
 	"github.com/google/uuid"
 )
 
-func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+// Keys set on the gin context by AuthJWT.
+const (
+	CtxSubject = "sub"
+	CtxRole    = "role"
+	CtxTenant  = "tenant"
+)
+
+// TimeoutMiddleware bounds the requests to timeout. The routes of long, by
+// full path, stream their response and get their own timeout instead, which
+// also replaces the write deadline the server set.
+func TimeoutMiddleware(timeout time.Duration, long map[string]time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		d, ok := long[c.FullPath()]
+		if ok {
+			// only recorders can't, they have no deadline.
+			_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(d))
+		} else {
+			d = timeout
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
 		defer cancel()
 
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 
-		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// a response under way can't become a 504.
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
 			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
 				"code":    http.StatusGatewayTimeout,
 				"message": "request timeout",
@@ -85,6 +103,10 @@ func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if rec := recover(); rec != nil {
+				// a handler aborting its response has net/http drop the connection.
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
 				reqID, _ := c.Get("request_id")
 				logger.Error("panic recovered",
 					slog.Any("error", rec),
@@ -101,9 +123,23 @@ func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
 	}
 }
 
-func AuthJWT(m *JWTManager, logger *slog.Logger) gin.HandlerFunc {
+// AuthJWT rejects requests without a valid bearer token. Routes listed in
+// public, as "METHOD /full/path", also accept anonymous callers; a token
+// sent to them is still verified.
+func AuthJWT(m *JWTManager, logger *slog.Logger, public ...string) gin.HandlerFunc {
+	publicRoutes := make(map[string]struct{}, len(public))
+	for _, r := range public {
+		publicRoutes[r] = struct{}{}
+	}
+
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			if _, ok := publicRoutes[c.Request.Method+" "+c.FullPath()]; ok {
+				c.Next()
+				return
+			}
+		}
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
@@ -117,7 +153,24 @@ func AuthJWT(m *JWTManager, logger *slog.Logger) gin.HandlerFunc {
 			return
 		}
 
-		c.Set("sub", claims.Subject)
+		c.Set(CtxSubject, claims.Subject)
+		c.Set(CtxRole, claims.Role)
+		c.Set(CtxTenant, claims.Tenant)
 		c.Next()
 	}
 }
+
+// Subject returns the JWT subject of the caller, ctx being the gin context
+// handed to the strict handlers.
+func Subject(ctx context.Context) string {
+	return toString(ctx.Value(CtxSubject))
+}
+
+func IsAdmin(ctx context.Context) bool {
+	return toString(ctx.Value(CtxRole)) == RoleAdmin
+}
+
+// Tenant returns the tenant claim of the caller, empty when the token has none.
+func Tenant(ctx context.Context) string {
+	return toString(ctx.Value(CtxTenant))
+}
@@ -1,6 +1,7 @@
 package obs
 
 import (
+	"context"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -30,3 +31,14 @@ func MetricsMiddleware() gin.HandlerFunc {
 		latencyHist.Record(c, durMs, metric.WithAttributes(attrs...))
 	}
 }
+
+// WodsPurged counts the wods deleted by the retention purge, as
+// wods_purged_total.
+func WodsPurged() func(ctx context.Context, n int) {
+	meter := otel.GetMeterProvider().Meter("wod-gen/retention")
+	purgedCounter, _ := meter.Int64Counter("wods_purged_total")
+
+	return func(ctx context.Context, n int) {
+		purgedCounter.Add(ctx, int64(n))
+	}
+}